@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/supragya/EtherScope/libs/config"
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/util"
+	"github.com/supragya/EtherScope/services/node"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backfillFrom    uint64
+	backfillTo      uint64
+	backfillWorkers int
+)
+
+// BackfillCmd indexes a bounded range of blocks and exits
+var BackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "run geth indexer for a bounded range of blocks",
+	Long: `run geth indexer for a bounded range of blocks [--from, --to].
+ranges are fetched over multiple workers in parallel and are sent
+to output sink in order of height. exits once the range is indexed`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if cfgFile == "" {
+			cfgFile = util.GetUserHomedir() + "/.supragya/escope/config.yaml"
+		}
+		util.ENOK(config.LoadViperConfig(cfgFile))
+
+		// Setup logger
+		log, err := logger.NewDefaultLogger(logLevel)
+		if err != nil {
+			panic(err)
+		}
+		globalLogger = log
+	},
+	Run: StartBackfillNode,
+}
+
+func StartBackfillNode(cmd *cobra.Command, args []string) {
+	var log = globalLogger
+
+	log.Info("setting up a new backfill geth indexer node",
+		"from", backfillFrom,
+		"to", backfillTo,
+		"workers", backfillWorkers)
+	_n, err := node.NewBackfillNodeWithViperFields(globalLogger, backfillFrom, backfillTo, backfillWorkers)
+	if err != nil {
+		log.Fatal(err.Error(), nil)
+	}
+
+	if err := _n.Start(context.Background()); err != nil {
+		log.Fatal("error while starting backfill node", "error", err.Error())
+	}
+
+	go handleSig(_n, log)
+	_n.Wait()
+	log.Info("backfill node exited")
+}
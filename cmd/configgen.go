@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/supragya/EtherScope/libs/config"
+	"github.com/supragya/EtherScope/libs/processors/generic"
+	priceresolver "github.com/supragya/EtherScope/libs/pricing"
 	"github.com/supragya/EtherScope/services/ethrpc"
 	localbackend "github.com/supragya/EtherScope/services/local_backend"
 	"github.com/supragya/EtherScope/services/node"
@@ -53,8 +55,22 @@ func GenConfig(cmd *cobra.Command, args []string) {
 		localbackend.BadgerCFGHeader, localbackend.BadgerCFGFields[:])
 	content += sectionGen(outputsink.RabbitMQCFGSection, outputsink.RabbitMQCFGNecessity,
 		outputsink.RabbitMQCFGHeader, outputsink.RabbitMQCFGFields[:])
+	content += sectionGen(outputsink.KafkaCFGSection, outputsink.KafkaCFGNecessity,
+		outputsink.KafkaCFGHeader, outputsink.KafkaCFGFields[:])
+	content += sectionGen(outputsink.PostgresCFGSection, outputsink.PostgresCFGNecessity,
+		outputsink.PostgresCFGHeader, outputsink.PostgresCFGFields[:])
+	content += sectionGen(outputsink.FileCFGSection, outputsink.FileCFGNecessity,
+		outputsink.FileCFGHeader, outputsink.FileCFGFields[:])
+	content += sectionGen(outputsink.FanoutCFGSection, outputsink.FanoutCFGNecessity,
+		outputsink.FanoutCFGHeader, outputsink.FanoutCFGFields[:])
+	content += sectionGen(generic.GenericCFGSection, generic.GenericCFGNecessity,
+		generic.GenericCFGHeader, generic.GenericCFGFields[:])
 	content += sectionGen(ethrpc.EthRPCMSPoolCFGSection, ethrpc.EthRPCMSPoolCFGNecessity,
 		ethrpc.EthRPCMSPoolCFGHeader, ethrpc.EthRPCMSPoolCFGFields[:])
+	content += sectionGen(ethrpc.EthRPCFixtureCFGSection, ethrpc.EthRPCFixtureCFGNecessity,
+		ethrpc.EthRPCFixtureCFGHeader, ethrpc.EthRPCFixtureCFGFields[:])
+	content += sectionGen(priceresolver.PricingCEXCFGSection, priceresolver.PricingCEXCFGNecessity,
+		priceresolver.PricingCEXCFGHeader, priceresolver.PricingCEXCFGFields[:])
 
 	if err := os.WriteFile(cfgFile, []byte(content), 0600); err != nil {
 		panic(err)
@@ -90,7 +106,14 @@ func sectionGen(section, necessity string, header []string, fields []config.Fiel
 				content += fmt.Sprintf("#   %-5s  %-60s\n", "", line)
 			}
 		}
-		content += fmt.Sprintf("    %s: %v\n", field.Name, field.Default)
+		if list, ok := field.Default.([]string); ok {
+			content += fmt.Sprintf("    %s:\n", field.Name)
+			for _, item := range list {
+				content += fmt.Sprintf("    - %s\n", item)
+			}
+		} else {
+			content += fmt.Sprintf("    %s: %v\n", field.Name, field.Default)
+		}
 		content += "\n"
 	}
 	content += "\n"
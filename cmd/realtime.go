@@ -14,6 +14,7 @@ import (
 	"github.com/supragya/EtherScope/services/instrumentation"
 	"github.com/supragya/EtherScope/services/node"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var globalLogger logger.Logger
@@ -35,10 +36,16 @@ var RealtimeCmd = &cobra.Command{
 			panic(err)
 		}
 		globalLogger = log
+
+		if resumeFromCheckpoint {
+			viper.Set(node.NodeCFGSection+".resumeFromCheckpoint", true)
+		}
 	},
 	Run: StartRealtimeNode,
 }
 
+var resumeFromCheckpoint bool
+
 func StartRealtimeNode(cmd *cobra.Command, args []string) {
 	var log = globalLogger
 
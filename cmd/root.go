@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/supragya/EtherScope/libs/util"
 	"github.com/supragya/EtherScope/version"
 	"github.com/spf13/cobra"
 )
@@ -23,10 +24,21 @@ var RootCmd = &cobra.Command{
 
 func init() {
 	RootCmd.AddCommand(RealtimeCmd)
+	RootCmd.AddCommand(BackfillCmd)
 	RootCmd.AddCommand(OracleCmd)
 	RootCmd.AddCommand(ConfigGen)
 	RootCmd.AddCommand(AlgoIndexerCmd)
+	RootCmd.AddCommand(TokenMetaCmd)
 
 	RootCmd.PersistentFlags().StringVarP(&logLevel, "loglevel", "l", "info", "loglevel (default is INFO)")
 	RootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is $HOME/.supragya/escope/config.yaml)")
+
+	RealtimeCmd.Flags().BoolVar(&resumeFromCheckpoint, "resume-from-checkpoint", false,
+		"resume from last height checkpointed in localbackend, ignoring remote resume")
+
+	BackfillCmd.Flags().Uint64Var(&backfillFrom, "from", 0, "first block to backfill")
+	BackfillCmd.Flags().Uint64Var(&backfillTo, "to", 0, "last block to backfill (inclusive)")
+	BackfillCmd.Flags().IntVar(&backfillWorkers, "workers", 4, "number of parallel fetch workers")
+	util.ENOK(BackfillCmd.MarkFlagRequired("from"))
+	util.ENOK(BackfillCmd.MarkFlagRequired("to"))
 }
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/supragya/EtherScope/libs/config"
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/util"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	lb "github.com/supragya/EtherScope/services/local_backend"
+	"github.com/spf13/cobra"
+)
+
+var tokenMetaFile string
+
+// TokenMetaCmd manages token metadata persisted in localbackend
+var TokenMetaCmd = &cobra.Command{
+	Use:   "tokenmeta",
+	Short: "export or import token metadata seed files",
+	Long: `export or import token metadata (pair tokens, erc20 decimals,
+names, symbols and contract checks) persisted in badgerdb localbackend
+as a seed file. seed files can be shipped along with releases and set
+as ethRPCMSPool.tokenMetadataSeedFile to avoid refetching metadata`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if cfgFile == "" {
+			cfgFile = util.GetUserHomedir() + "/.supragya/escope/config.yaml"
+		}
+		util.ENOK(config.LoadViperConfig(cfgFile))
+
+		// Setup logger
+		log, err := logger.NewDefaultLogger(logLevel)
+		if err != nil {
+			panic(err)
+		}
+		globalLogger = log
+	},
+}
+
+var tokenMetaExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "export token metadata from localbackend into a seed file",
+	Run: func(cmd *cobra.Command, args []string) {
+		withTokenMetadataStore(func(store *ethrpc.TokenMetadataStore) error {
+			file, err := os.Create(tokenMetaFile)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			exported, err := store.Export(file)
+			if err != nil {
+				return err
+			}
+			globalLogger.Info("exported token metadata", "seed", tokenMetaFile, "entries", exported)
+			return file.Sync()
+		})
+	},
+}
+
+var tokenMetaImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import token metadata from a seed file into localbackend",
+	Run: func(cmd *cobra.Command, args []string) {
+		withTokenMetadataStore(func(store *ethrpc.TokenMetadataStore) error {
+			file, err := os.Open(tokenMetaFile)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			imported, err := store.Import(file)
+			if err != nil {
+				return err
+			}
+			globalLogger.Info("imported token metadata", "seed", tokenMetaFile, "newEntries", imported)
+			return nil
+		})
+	},
+}
+
+// withTokenMetadataStore runs foo on token metadata store of badgerdb
+// localbackend from config, syncing localbackend afterwards
+func withTokenMetadataStore(foo func(*ethrpc.TokenMetadataStore) error) {
+	var log = globalLogger
+
+	localBackend, err := lb.NewBadgerDBWithViperFields(log.With("service", "localbackend"))
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	if err := localBackend.Start(context.Background()); err != nil {
+		log.Fatal("error while starting localbackend", "error", err.Error())
+	}
+	defer localBackend.Stop()
+
+	if err := foo(ethrpc.NewTokenMetadataStore(localBackend)); err != nil {
+		log.Error("token metadata operation failed", "error", err.Error())
+		return
+	}
+	if err := localBackend.Sync(); err != nil {
+		log.Error("localbackend sync failed", "error", err.Error())
+	}
+}
+
+func init() {
+	TokenMetaCmd.AddCommand(tokenMetaExportCmd)
+	TokenMetaCmd.AddCommand(tokenMetaImportCmd)
+	TokenMetaCmd.PersistentFlags().StringVarP(&tokenMetaFile, "file", "f", "tokenmeta.seed.json", "seed file")
+}
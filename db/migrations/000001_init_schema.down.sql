@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS transfers_geth;
+DROP TABLE IF EXISTS pool_actions_geth;
+DROP TABLE IF EXISTS blocks;
@@ -0,0 +1,67 @@
+CREATE TABLE IF NOT EXISTS blocks (
+    height                      BIGINT PRIMARY KEY,
+    block_time                  BIGINT NOT NULL,
+    node_moniker                TEXT NOT NULL,
+    network                     TEXT NOT NULL,
+    indexing_time_nanos         BIGINT NOT NULL,
+    processing_duration_nanos   BIGINT NOT NULL,
+    pricing_duration_nanos      BIGINT NOT NULL,
+    events_scanned              BIGINT NOT NULL,
+    events_priced               BIGINT NOT NULL,
+    events_user_distribution    JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE IF NOT EXISTS pool_actions_geth (
+    tx_hash         TEXT NOT NULL,
+    log_idx         BIGINT NOT NULL,
+    type            TEXT NOT NULL,
+    height          BIGINT NOT NULL,
+    block_time      BIGINT NOT NULL,
+    sender          TEXT NOT NULL,
+    tx_sender       TEXT NOT NULL,
+    receiver        TEXT,
+    pair_contract   TEXT NOT NULL,
+    token0          TEXT NOT NULL,
+    token1          TEXT NOT NULL,
+    amount0         NUMERIC,
+    amount1         NUMERIC,
+    reserve0        NUMERIC,
+    reserve1        NUMERIC,
+    amount_usd      NUMERIC,
+    price0          NUMERIC,
+    price1          NUMERIC,
+    PRIMARY KEY (tx_hash, log_idx)
+);
+
+CREATE INDEX IF NOT EXISTS pool_actions_geth_height_idx ON pool_actions_geth (height);
+CREATE INDEX IF NOT EXISTS pool_actions_geth_pair_idx ON pool_actions_geth (pair_contract);
+
+CREATE TABLE IF NOT EXISTS transfers_geth (
+    tx_hash         TEXT NOT NULL,
+    log_idx         BIGINT NOT NULL,
+    type            TEXT NOT NULL,
+    height          BIGINT NOT NULL,
+    block_time      BIGINT NOT NULL,
+    token           TEXT NOT NULL,
+    sender          TEXT NOT NULL,
+    tx_sender       TEXT NOT NULL,
+    receiver        TEXT NOT NULL,
+    amount          NUMERIC,
+    amount_usd      NUMERIC,
+    PRIMARY KEY (tx_hash, log_idx)
+);
+
+CREATE INDEX IF NOT EXISTS transfers_geth_height_idx ON transfers_geth (height);
+CREATE INDEX IF NOT EXISTS transfers_geth_token_idx ON transfers_geth (token);
+
+-- proguser is used by escope postgres output sink. Upserts and
+-- rollbacks on reorg need update and delete besides insert
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'proguser') THEN
+        CREATE ROLE proguser LOGIN PASSWORD 'progpass';
+    END IF;
+END
+$$;
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON blocks, pool_actions_geth, transfers_geth TO proguser;
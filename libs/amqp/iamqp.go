@@ -18,6 +18,13 @@ type (
 	AMQPChannel interface {
 		Publish(string, string, bool, bool, amqp.Publishing) error
 		Close() error
+		// Confirm puts channel in confirm mode, broker then acks or
+		// nacks every publishing with sequential delivery tags
+		Confirm(noWait bool) error
+		NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation
+		// NotifyReturn registers for publishings with mandatory set
+		// which broker could not route, returned ahead of their ack
+		NotifyReturn(c chan amqp.Return) chan amqp.Return
 	}
 )
 
@@ -7,6 +7,11 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Necessity of fields which may be left out of config. These are set
+// to their Default when unset, so that configs written before a field
+// was introduced keep loading
+const Optional = "optional"
+
 type Field struct {
 	Name      string
 	Type      string
@@ -88,6 +93,10 @@ func LoadViperConfig(file string) error {
 func EnsureFieldIntegrity(section string, f Field) error {
 	fieldName := section + "." + f.Name
 	if !viper.IsSet(fieldName) {
+		if f.Necessity == Optional {
+			viper.SetDefault(fieldName, f.Default)
+			return nil
+		}
 		return errors.New("config error: unset mandatory field: " + fieldName + " (" + f.Type + "); description:" + SFmt(f.Info))
 	}
 	var castOK bool = true
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestEnsureFieldIntegrityOptional(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("svc.depth", 12)
+
+	// Unset optional fields take their default, set ones are kept
+	for _, f := range []Field{
+		{Name: "depth", Type: "uint64", Necessity: Optional, Default: 64},
+		{Name: "mode", Type: "string", Necessity: Optional, Default: "logs"},
+		{Name: "names", Type: "[]string", Necessity: Optional, Default: []string{"a", "b"}},
+	} {
+		if err := EnsureFieldIntegrity("svc", f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if viper.GetUint64("svc.depth") != 12 || viper.GetString("svc.mode") != "logs" ||
+		strings.Join(viper.GetStringSlice("svc.names"), ",") != "a,b" {
+		t.Errorf("unexpected values %v %v %v", viper.Get("svc.depth"), viper.Get("svc.mode"), viper.Get("svc.names"))
+	}
+
+	err := EnsureFieldIntegrity("svc", Field{Name: "host", Type: "string", Necessity: "always needed"})
+	if err == nil || !strings.Contains(err.Error(), "unset mandatory field: svc.host") {
+		t.Errorf("expected unset mandatory field error, got %v", err)
+	}
+}
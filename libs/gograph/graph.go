@@ -105,7 +105,7 @@ func (g *Graph[V, W, H, M]) GetBFSCandidates(maxRoutes int,
 	// Initialize DS
 	visited := make(map[V]bool, g.VertexCount)
 	paths := [][]WeightedEdge[V, W, H, M]{}
-	queue := make([]Route[V, W, H, M], g.EdgeCount)
+	queue := make([]Route[V, W, H, M], 0, g.EdgeCount)
 
 	// Initialize queue
 	visited[from] = true
@@ -130,6 +130,9 @@ func (g *Graph[V, W, H, M]) GetBFSCandidates(maxRoutes int,
 			if maxRoutes == 0 {
 				break
 			}
+			// Routes do not pass through destination, expanding
+			// it would mark it visited for other routes
+			continue
 		}
 
 		// We cannot have too long of a derivation
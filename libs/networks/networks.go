@@ -0,0 +1,83 @@
+package networks
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Profile holds chain specific facts of an evm compatible network
+type Profile struct {
+	Name    string
+	ChainID uint64
+
+	// Chainlink feed registry and height it was deployed at. Zero
+	// address if chainlink has no feed registry on network
+	ChainlinkFeedRegistry       common.Address
+	ChainlinkFeedRegistryHeight uint64
+}
+
+// Denomination used by chainlink feed registry for USD, same on all
+// networks (chainlink Denominations.USD)
+var ChainlinkUSDDenomination = common.HexToAddress("0x0000000000000000000000000000000000000348")
+
+var profiles = map[string]Profile{
+	"ethereum-mainnet": {
+		Name:                        "ethereum-mainnet",
+		ChainID:                     1,
+		ChainlinkFeedRegistry:       common.HexToAddress("0x47Fb2585D2C56Fe188D0E6ec628a38b74fCeeeDf"),
+		ChainlinkFeedRegistryHeight: 12864088,
+	},
+	"arbitrum-one": {
+		Name:    "arbitrum-one",
+		ChainID: 42161,
+	},
+	"avalanche-c": {
+		Name:    "avalanche-c",
+		ChainID: 43114,
+	},
+	"bsc-mainnet": {
+		Name:    "bsc-mainnet",
+		ChainID: 56,
+	},
+	"polygon-mainnet": {
+		Name:    "polygon-mainnet",
+		ChainID: 137,
+	},
+}
+
+// Get returns built-in profile of network, false if network is
+// not known
+func Get(network string) (Profile, bool) {
+	profile, ok := profiles[network]
+	return profile, ok
+}
+
+// Names returns names of networks having built-in profiles, sorted
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasFeedRegistry returns true if chainlink feed registry is known
+// for network
+func (p Profile) HasFeedRegistry() bool {
+	return p.ChainlinkFeedRegistry != (common.Address{})
+}
+
+// ExpectedChainID returns chain ID upstreams of network are expected to
+// be on. configured chain ID takes precedence over that of profile, 0
+// is returned if neither is known
+func ExpectedChainID(network string, configured uint64) uint64 {
+	if configured != 0 {
+		return configured
+	}
+	if profile, ok := Get(network); ok {
+		return profile.ChainID
+	}
+	return 0
+}
@@ -0,0 +1,37 @@
+package networks
+
+import (
+	"testing"
+)
+
+func TestProfilesConsistent(t *testing.T) {
+	chainIDs := make(map[uint64]string)
+	for _, name := range Names() {
+		profile, ok := Get(name)
+		if !ok {
+			t.Fatalf("profile %s listed but not found", name)
+		}
+		if profile.Name != name {
+			t.Errorf("profile %s named %s", name, profile.Name)
+		}
+		if other, ok := chainIDs[profile.ChainID]; ok || profile.ChainID == 0 {
+			t.Errorf("profile %s has chain ID %d clashing with %s", name, profile.ChainID, other)
+		}
+		chainIDs[profile.ChainID] = name
+		if profile.HasFeedRegistry() != (profile.ChainlinkFeedRegistryHeight != 0) {
+			t.Errorf("profile %s has feed registry without deploy height", name)
+		}
+	}
+}
+
+func TestExpectedChainID(t *testing.T) {
+	if id := ExpectedChainID("polygon-mainnet", 0); id != 137 {
+		t.Errorf("expected chain ID 137 from profile, got %d", id)
+	}
+	if id := ExpectedChainID("polygon-mainnet", 80001); id != 80001 {
+		t.Errorf("expected configured chain ID to take precedence, got %d", id)
+	}
+	if id := ExpectedChainID("unknown", 0); id != 0 {
+		t.Errorf("expected no chain ID for unknown network, got %d", id)
+	}
+}
@@ -0,0 +1,530 @@
+package payloadpb
+
+import (
+	"encoding/json"
+	"math/big"
+
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/proto"
+)
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative payload.proto
+
+// Encode returns wire encoding of payload
+func Encode(payload *Payload) ([]byte, error) {
+	return proto.Marshal(payload)
+}
+
+// Decode parses wire encoded payload
+func Decode(data []byte) (*Payload, error) {
+	payload := &Payload{}
+	if err := proto.Unmarshal(data, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// NewBigFloat returns exact representation of f, nil for nil or
+// infinite f
+func NewBigFloat(f *big.Float) *BigFloat {
+	if f == nil || f.IsInf() {
+		return nil
+	}
+	if f.Sign() == 0 {
+		return &BigFloat{}
+	}
+	mant := new(big.Float)
+	exp := f.MantExp(mant)
+	// Shift mantissa left until integral
+	prec := int(mant.MinPrec())
+	mant.SetMantExp(mant, prec)
+	integral, _ := mant.Int(nil)
+	return &BigFloat{
+		Mantissa: integral.Abs(integral).Bytes(),
+		Exponent: int32(exp - prec),
+		Negative: f.Signbit(),
+	}
+}
+
+// Float returns value of b, nil for nil b
+func (b *BigFloat) Float() *big.Float {
+	if b == nil {
+		return nil
+	}
+	mant := new(big.Int).SetBytes(b.Mantissa)
+	if b.Negative {
+		mant.Neg(mant)
+	}
+	f := new(big.Float).SetInt(mant)
+	return f.SetMantExp(f, int(b.Exponent))
+}
+
+// NewBigInt returns representation of i, nil for nil i
+func NewBigInt(i *big.Int) *BigInt {
+	if i == nil {
+		return nil
+	}
+	return &BigInt{
+		Magnitude: new(big.Int).Abs(i).Bytes(),
+		Negative:  i.Sign() < 0,
+	}
+}
+
+// Int returns value of b, nil for nil b
+func (b *BigInt) Int() *big.Int {
+	if b == nil {
+		return nil
+	}
+	i := new(big.Int).SetBytes(b.Magnitude)
+	if b.Negative {
+		i.Neg(i)
+	}
+	return i
+}
+
+func NewBlockSynopsis(bs *itypes.BlockSynopsis) *BlockSynopsis {
+	if bs == nil {
+		return nil
+	}
+	return &BlockSynopsis{
+		Height:                  bs.Height,
+		BlockTime:               bs.BlockTime,
+		IndexingTimeNanos:       bs.IndexingTimeNanos,
+		ProcessingDurationNanos: bs.ProcessingDurationNanos,
+		PricingDurationNanos:    bs.PricingDurationNanos,
+		EventsScanned:           bs.EventsScanned,
+		EventsPriced:            bs.EventsPriced,
+		EventsUserDistribution:  bs.EventsUserDistribution,
+		EventsIndexed:           bs.EventsIndexed,
+	}
+}
+
+func NewUniV2Metadata(m itypes.UniV2Metadata) *UniV2Metadata {
+	return &UniV2Metadata{
+		Description: m.Description,
+		Pair:        m.Pair.Bytes(),
+		Token0:      m.Token0.Bytes(),
+		Token1:      m.Token1.Bytes(),
+		Res0:        NewBigFloat(m.Res0),
+		Res1:        NewBigFloat(m.Res1),
+	}
+}
+
+func NewPriceResult(p *itypes.PriceResult) *PriceResult {
+	if p == nil {
+		return nil
+	}
+	result := &PriceResult{
+		Price:        NewBigFloat(p.Price),
+		Confidence:   p.Confidence,
+		LiquidityUsd: NewBigFloat(p.LiquidityUSD),
+	}
+	for _, hop := range p.Path {
+		switch h := hop.(type) {
+		case itypes.UniV2Metadata:
+			result.Path = append(result.Path, &PriceHop{
+				Description: h.Description,
+				Hop: &PriceHop_Dex{Dex: &DexHop{
+					Pair:   h.Pair.Bytes(),
+					Token0: h.Token0.Bytes(),
+					Token1: h.Token1.Bytes(),
+					Res0:   NewBigFloat(h.Res0),
+					Res1:   NewBigFloat(h.Res1),
+				}},
+			})
+		case itypes.UniV3Metadata:
+			result.Path = append(result.Path, &PriceHop{
+				Description: h.Description,
+				Hop: &PriceHop_Dex{Dex: &DexHop{
+					Pair:         h.Pool.Bytes(),
+					Token0:       h.Token0.Bytes(),
+					Token1:       h.Token1.Bytes(),
+					Res0:         NewBigFloat(h.Res0),
+					Res1:         NewBigFloat(h.Res1),
+					Concentrated: true,
+				}},
+			})
+		case itypes.WrappedCLMetadata:
+			result.Path = append(result.Path, &PriceHop{
+				Description: h.Description,
+				Hop: &PriceHop_Chainlink{Chainlink: &ChainlinkHop{
+					Oracle:    h.Oracle.Bytes(),
+					From:      h.From.Bytes(),
+					To:        h.To.Bytes(),
+					Answer:    NewBigInt(h.Data.Answer),
+					UpdatedAt: NewBigInt(h.Data.UpdatedAt),
+				}},
+			})
+		case itypes.CounterPartyResolutionMetadata:
+			result.Path = append(result.Path, &PriceHop{
+				Description: h.Description,
+				Hop: &PriceHop_Counterparty{Counterparty: &CounterpartyHop{
+					Price: NewBigFloat(h.Price),
+				}},
+			})
+		case itypes.RejectedOracleMetadata:
+			result.Path = append(result.Path, &PriceHop{
+				Description: h.Description,
+				Hop: &PriceHop_RejectedOracle{RejectedOracle: &RejectedOracleHop{
+					Oracle: h.Oracle.Bytes(),
+					Reason: h.Reason,
+				}},
+			})
+		case itypes.CEXMetadata:
+			result.Path = append(result.Path, &PriceHop{
+				Description: h.Description,
+				Hop: &PriceHop_Cex{Cex: &CEXHop{
+					Token:  h.Token.Bytes(),
+					Symbol: h.Symbol,
+					Price:  NewBigFloat(h.Price),
+					Time:   h.Time,
+				}},
+			})
+		case itypes.RejectedCEXMetadata:
+			result.Path = append(result.Path, &PriceHop{
+				Description: h.Description,
+				Hop: &PriceHop_RejectedCex{RejectedCex: &RejectedCEXHop{
+					Symbol: h.Symbol,
+					Reason: h.Reason,
+				}},
+			})
+		}
+	}
+	return result
+}
+
+// NewItem converts an indexed item, nil for unsupported items
+func NewItem(item interface{}) *Item {
+	switch i := item.(type) {
+	case *itypes.Mint:
+		return &Item{Item: &Item_Mint{Mint: &Mint{
+			Type:         i.Type,
+			LogIdx:       uint64(i.LogIdx),
+			Transaction:  i.Transaction.Bytes(),
+			Time:         i.Time,
+			Height:       i.Height,
+			Sender:       i.Sender.Bytes(),
+			TxSender:     i.TxSender.Bytes(),
+			PairContract: i.PairContract.Bytes(),
+			Token0:       i.Token0.Bytes(),
+			Token1:       i.Token1.Bytes(),
+			Amount0:      NewBigFloat(i.Amount0),
+			Amount1:      NewBigFloat(i.Amount1),
+			Reserve0:     NewBigFloat(i.Reserve0),
+			Reserve1:     NewBigFloat(i.Reserve1),
+			AmountUsd:    NewBigFloat(i.AmountUSD),
+			Price0:       NewPriceResult(i.Price0),
+			Price1:       NewPriceResult(i.Price1),
+		}}}
+	case *itypes.Burn:
+		return &Item{Item: &Item_Burn{Burn: &Burn{
+			Type:         i.Type,
+			LogIdx:       uint64(i.LogIdx),
+			Transaction:  i.Transaction.Bytes(),
+			Time:         i.Time,
+			Height:       i.Height,
+			Sender:       i.Sender.Bytes(),
+			TxSender:     i.TxSender.Bytes(),
+			PairContract: i.PairContract.Bytes(),
+			Token0:       i.Token0.Bytes(),
+			Token1:       i.Token1.Bytes(),
+			Amount0:      NewBigFloat(i.Amount0),
+			Amount1:      NewBigFloat(i.Amount1),
+			Reserve0:     NewBigFloat(i.Reserve0),
+			Reserve1:     NewBigFloat(i.Reserve1),
+			AmountUsd:    NewBigFloat(i.AmountUSD),
+			Price0:       NewPriceResult(i.Price0),
+			Price1:       NewPriceResult(i.Price1),
+		}}}
+	case *itypes.Swap:
+		swap := &Swap{
+			Type:         i.Type,
+			LogIdx:       uint64(i.LogIdx),
+			Transaction:  i.Transaction.Bytes(),
+			Time:         i.Time,
+			Height:       i.Height,
+			Sender:       i.Sender.Bytes(),
+			TxSender:     i.TxSender.Bytes(),
+			Receiver:     i.Receiver.Bytes(),
+			PairContract: i.PairContract.Bytes(),
+			Token0:       i.Token0.Bytes(),
+			Token1:       i.Token1.Bytes(),
+			Amount0:      NewBigFloat(i.Amount0),
+			Amount1:      NewBigFloat(i.Amount1),
+			Reserve0:     NewBigFloat(i.Reserve0),
+			Reserve1:     NewBigFloat(i.Reserve1),
+			AmountUsd:    NewBigFloat(i.AmountUSD),
+			Price0:       NewPriceResult(i.Price0),
+			Price1:       NewPriceResult(i.Price1),
+		}
+		if state, ok := i.ExtraData.(itypes.UniV3SwapExtraData); ok {
+			swap.Univ3State = &UniV3SwapState{
+				SqrtPriceX96: NewBigInt(state.SqrtPriceX96),
+				Liquidity:    NewBigInt(state.Liquidity),
+				Decimals0:    uint32(state.Decimals0),
+				Decimals1:    uint32(state.Decimals1),
+			}
+			if state.Tick != nil {
+				swap.Univ3State.Tick = state.Tick.Int64()
+			}
+		}
+		return &Item{Item: &Item_Swap{Swap: swap}}
+	case *itypes.Transfer:
+		return &Item{Item: &Item_Transfer{Transfer: &Transfer{
+			Type:                i.Type,
+			LogIdx:              uint64(i.LogIdx),
+			Transaction:         i.Transaction.Bytes(),
+			Time:                i.Time,
+			Height:              i.Height,
+			Token:               i.Token.Bytes(),
+			Sender:              i.Sender.Bytes(),
+			TxSender:            i.TxSender.Bytes(),
+			Receiver:            i.Receiver.Bytes(),
+			Amount:              NewBigFloat(i.Amount),
+			AmountUsd:           NewBigFloat(i.AmountUSD),
+			PriceDerivationMeta: NewPriceResult(i.PriceDerivationMeta),
+		}}}
+	case *itypes.Rollback:
+		rollback := &Rollback{
+			Type:           i.Type,
+			CommonAncestor: i.CommonAncestor,
+			OrphanedHeight: i.OrphanedHeight,
+		}
+		for _, hash := range i.OrphanedHashes {
+			rollback.OrphanedHashes = append(rollback.OrphanedHashes, hash.Bytes())
+		}
+		return &Item{Item: &Item_Rollback{Rollback: rollback}}
+	case *itypes.GenericEvent:
+		event := &GenericEvent{
+			Type:        i.Type,
+			LogIdx:      uint64(i.LogIdx),
+			Transaction: i.Transaction.Bytes(),
+			Time:        i.Time,
+			Height:      i.Height,
+			Contract:    i.Contract.Bytes(),
+			Event:       i.Event,
+			Signature:   i.Signature,
+		}
+		for _, field := range i.Fields {
+			value, _ := json.Marshal(field.Value)
+			event.Fields = append(event.Fields, &GenericField{
+				Name:      field.Name,
+				Type:      field.Type,
+				Indexed:   field.Indexed,
+				JsonValue: value,
+			})
+		}
+		return &Item{Item: &Item_GenericEvent{GenericEvent: event}}
+	}
+	return nil
+}
+
+// ToPriceResult converts price result back. Path holds
+// itypes.UniV2Metadata, itypes.UniV3Metadata, itypes.WrappedCLMetadata,
+// itypes.CounterPartyResolutionMetadata, itypes.RejectedOracleMetadata,
+// itypes.CEXMetadata or itypes.RejectedCEXMetadata entries
+func (p *PriceResult) ToPriceResult() *itypes.PriceResult {
+	if p == nil {
+		return nil
+	}
+	result := &itypes.PriceResult{
+		Price:        p.Price.Float(),
+		Path:         []interface{}{},
+		LiquidityUSD: p.LiquidityUsd.Float(),
+		Confidence:   p.Confidence,
+	}
+	for _, hop := range p.Path {
+		switch h := hop.Hop.(type) {
+		case *PriceHop_Dex:
+			if h.Dex.Concentrated {
+				result.Path = append(result.Path, itypes.UniV3Metadata{
+					Description: hop.Description,
+					Pool:        common.BytesToAddress(h.Dex.Pair),
+					Token0:      common.BytesToAddress(h.Dex.Token0),
+					Token1:      common.BytesToAddress(h.Dex.Token1),
+					Res0:        h.Dex.Res0.Float(),
+					Res1:        h.Dex.Res1.Float(),
+				})
+				continue
+			}
+			result.Path = append(result.Path, itypes.UniV2Metadata{
+				Description: hop.Description,
+				Pair:        common.BytesToAddress(h.Dex.Pair),
+				Token0:      common.BytesToAddress(h.Dex.Token0),
+				Token1:      common.BytesToAddress(h.Dex.Token1),
+				Res0:        h.Dex.Res0.Float(),
+				Res1:        h.Dex.Res1.Float(),
+			})
+		case *PriceHop_Chainlink:
+			result.Path = append(result.Path, itypes.WrappedCLMetadata{
+				Description: hop.Description,
+				Oracle:      common.BytesToAddress(h.Chainlink.Oracle),
+				From:        common.BytesToAddress(h.Chainlink.From),
+				To:          common.BytesToAddress(h.Chainlink.To),
+				Data: itypes.ChainlinkLatestRoundData{
+					Answer:    h.Chainlink.Answer.Int(),
+					UpdatedAt: h.Chainlink.UpdatedAt.Int(),
+				},
+			})
+		case *PriceHop_Counterparty:
+			result.Path = append(result.Path, itypes.CounterPartyResolutionMetadata{
+				Description: hop.Description,
+				Price:       h.Counterparty.Price.Float(),
+			})
+		case *PriceHop_RejectedOracle:
+			result.Path = append(result.Path, itypes.RejectedOracleMetadata{
+				Description: hop.Description,
+				Oracle:      common.BytesToAddress(h.RejectedOracle.Oracle),
+				Reason:      h.RejectedOracle.Reason,
+			})
+		case *PriceHop_Cex:
+			result.Path = append(result.Path, itypes.CEXMetadata{
+				Description: hop.Description,
+				Token:       common.BytesToAddress(h.Cex.Token),
+				Symbol:      h.Cex.Symbol,
+				Price:       h.Cex.Price.Float(),
+				Time:        h.Cex.Time,
+			})
+		case *PriceHop_RejectedCex:
+			result.Path = append(result.Path, itypes.RejectedCEXMetadata{
+				Description: hop.Description,
+				Symbol:      h.RejectedCex.Symbol,
+				Reason:      h.RejectedCex.Reason,
+			})
+		}
+	}
+	return result
+}
+
+// ToItem converts item back to one of *itypes.Mint, *itypes.Burn,
+// *itypes.Swap, *itypes.Transfer, *itypes.Rollback or
+// *itypes.GenericEvent. Generic event field values are left JSON
+// encoded as json.RawMessage
+func (i *Item) ToItem() interface{} {
+	switch it := i.GetItem().(type) {
+	case *Item_Mint:
+		m := it.Mint
+		return &itypes.Mint{
+			Type:         m.Type,
+			LogIdx:       uint(m.LogIdx),
+			Transaction:  common.BytesToHash(m.Transaction),
+			Time:         m.Time,
+			Height:       m.Height,
+			Sender:       common.BytesToAddress(m.Sender),
+			TxSender:     common.BytesToAddress(m.TxSender),
+			PairContract: common.BytesToAddress(m.PairContract),
+			Token0:       common.BytesToAddress(m.Token0),
+			Token1:       common.BytesToAddress(m.Token1),
+			Amount0:      m.Amount0.Float(),
+			Amount1:      m.Amount1.Float(),
+			Reserve0:     m.Reserve0.Float(),
+			Reserve1:     m.Reserve1.Float(),
+			AmountUSD:    m.AmountUsd.Float(),
+			Price0:       m.Price0.ToPriceResult(),
+			Price1:       m.Price1.ToPriceResult(),
+		}
+	case *Item_Burn:
+		b := it.Burn
+		return &itypes.Burn{
+			Type:         b.Type,
+			LogIdx:       uint(b.LogIdx),
+			Transaction:  common.BytesToHash(b.Transaction),
+			Time:         b.Time,
+			Height:       b.Height,
+			Sender:       common.BytesToAddress(b.Sender),
+			TxSender:     common.BytesToAddress(b.TxSender),
+			PairContract: common.BytesToAddress(b.PairContract),
+			Token0:       common.BytesToAddress(b.Token0),
+			Token1:       common.BytesToAddress(b.Token1),
+			Amount0:      b.Amount0.Float(),
+			Amount1:      b.Amount1.Float(),
+			Reserve0:     b.Reserve0.Float(),
+			Reserve1:     b.Reserve1.Float(),
+			AmountUSD:    b.AmountUsd.Float(),
+			Price0:       b.Price0.ToPriceResult(),
+			Price1:       b.Price1.ToPriceResult(),
+		}
+	case *Item_Swap:
+		s := it.Swap
+		swap := &itypes.Swap{
+			Type:         s.Type,
+			LogIdx:       uint(s.LogIdx),
+			Transaction:  common.BytesToHash(s.Transaction),
+			Time:         s.Time,
+			Height:       s.Height,
+			Sender:       common.BytesToAddress(s.Sender),
+			TxSender:     common.BytesToAddress(s.TxSender),
+			Receiver:     common.BytesToAddress(s.Receiver),
+			PairContract: common.BytesToAddress(s.PairContract),
+			Token0:       common.BytesToAddress(s.Token0),
+			Token1:       common.BytesToAddress(s.Token1),
+			Amount0:      s.Amount0.Float(),
+			Amount1:      s.Amount1.Float(),
+			Reserve0:     s.Reserve0.Float(),
+			Reserve1:     s.Reserve1.Float(),
+			AmountUSD:    s.AmountUsd.Float(),
+			Price0:       s.Price0.ToPriceResult(),
+			Price1:       s.Price1.ToPriceResult(),
+		}
+		if state := s.Univ3State; state != nil {
+			swap.ExtraData = itypes.UniV3SwapExtraData{
+				SqrtPriceX96: state.SqrtPriceX96.Int(),
+				Liquidity:    state.Liquidity.Int(),
+				Tick:         big.NewInt(state.Tick),
+				Decimals0:    uint8(state.Decimals0),
+				Decimals1:    uint8(state.Decimals1),
+			}
+		}
+		return swap
+	case *Item_Transfer:
+		t := it.Transfer
+		return &itypes.Transfer{
+			Type:                t.Type,
+			LogIdx:              uint(t.LogIdx),
+			Transaction:         common.BytesToHash(t.Transaction),
+			Time:                t.Time,
+			Height:              t.Height,
+			Token:               common.BytesToAddress(t.Token),
+			Sender:              common.BytesToAddress(t.Sender),
+			TxSender:            common.BytesToAddress(t.TxSender),
+			Receiver:            common.BytesToAddress(t.Receiver),
+			Amount:              t.Amount.Float(),
+			AmountUSD:           t.AmountUsd.Float(),
+			PriceDerivationMeta: t.PriceDerivationMeta.ToPriceResult(),
+		}
+	case *Item_Rollback:
+		r := it.Rollback
+		rollback := &itypes.Rollback{
+			Type:           r.Type,
+			CommonAncestor: r.CommonAncestor,
+			OrphanedHeight: r.OrphanedHeight,
+		}
+		for _, hash := range r.OrphanedHashes {
+			rollback.OrphanedHashes = append(rollback.OrphanedHashes, common.BytesToHash(hash))
+		}
+		return rollback
+	case *Item_GenericEvent:
+		g := it.GenericEvent
+		event := &itypes.GenericEvent{
+			Type:        g.Type,
+			LogIdx:      uint(g.LogIdx),
+			Transaction: common.BytesToHash(g.Transaction),
+			Time:        g.Time,
+			Height:      g.Height,
+			Contract:    common.BytesToAddress(g.Contract),
+			Event:       g.Event,
+			Signature:   g.Signature,
+		}
+		for _, field := range g.Fields {
+			event.Fields = append(event.Fields, itypes.GenericField{
+				Name:    field.Name,
+				Type:    field.Type,
+				Indexed: field.Indexed,
+				Value:   json.RawMessage(field.JsonValue),
+			})
+		}
+		return event
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package payloadpb
+
+import (
+	"math/big"
+	"testing"
+
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBigFloatRoundTrip(t *testing.T) {
+	huge, _ := new(big.Float).SetString("123456789012345678901234567890.125")
+	for _, f := range []*big.Float{
+		big.NewFloat(0),
+		big.NewFloat(1),
+		big.NewFloat(-3.75),
+		big.NewFloat(1e-30),
+		huge,
+	} {
+		got := NewBigFloat(f).Float()
+		if got.Cmp(f) != 0 {
+			t.Errorf("round trip of %s gave %s", f.Text('g', 40), got.Text('g', 40))
+		}
+	}
+
+	if NewBigFloat(nil) != nil || (*BigFloat)(nil).Float() != nil {
+		t.Error("nil big float not preserved")
+	}
+}
+
+func TestBigIntRoundTrip(t *testing.T) {
+	for _, i := range []*big.Int{big.NewInt(0), big.NewInt(-42), new(big.Int).Lsh(big.NewInt(1), 200)} {
+		if got := NewBigInt(i).Int(); got.Cmp(i) != 0 {
+			t.Errorf("round trip of %s gave %s", i, got)
+		}
+	}
+}
+
+func TestPayloadRoundTrip(t *testing.T) {
+	swap := &itypes.Swap{
+		Type:         "uniswapv3swap",
+		LogIdx:       7,
+		Transaction:  common.HexToHash("0x01"),
+		Height:       100,
+		PairContract: common.HexToAddress("0x02"),
+		Amount0:      big.NewFloat(-1.5),
+		Amount1:      big.NewFloat(3000),
+		Price0: &itypes.PriceResult{
+			Price:        big.NewFloat(2000),
+			LiquidityUSD: big.NewFloat(250000),
+			Confidence:   0.75,
+			Path: []interface{}{
+				itypes.CounterPartyResolutionMetadata{Description: "cp", Price: big.NewFloat(2000)},
+				itypes.WrappedCLMetadata{
+					Oracle: common.HexToAddress("0x03"),
+					Data:   itypes.ChainlinkLatestRoundData{Answer: big.NewInt(-5)},
+				},
+				itypes.RejectedOracleMetadata{
+					Description: "Chainlink oracle rejected",
+					Oracle:      common.HexToAddress("0x04"),
+					Reason:      "stale round, updated 90000s before block with heartbeat 86400s",
+				},
+			},
+		},
+		ExtraData: itypes.UniV3SwapExtraData{
+			SqrtPriceX96: big.NewInt(1 << 40),
+			Liquidity:    big.NewInt(1000),
+			Tick:         big.NewInt(-887272),
+			Decimals0:    18,
+			Decimals1:    6,
+		},
+	}
+
+	swap.Price1 = &itypes.PriceResult{
+		Price: big.NewFloat(1.25),
+		Path: []interface{}{
+			itypes.RejectedCEXMetadata{Description: "CEX price rejected", Symbol: "FOO", Reason: "no candle before block"},
+			itypes.CEXMetadata{
+				Description: "CEX (FOOUSDT)",
+				Token:       common.HexToAddress("0x05"),
+				Symbol:      "FOOUSDT",
+				Price:       big.NewFloat(1.25),
+				Time:        1700000000,
+			},
+		},
+	}
+
+	data, err := Encode(&Payload{
+		PersistenceVersion: 8,
+		BlockSynopsis:      NewBlockSynopsis(&itypes.BlockSynopsis{Height: 100}),
+		Items:              []*Item{NewItem(swap)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.PersistenceVersion != 8 || decoded.BlockSynopsis.Height != 100 {
+		t.Fatalf("unexpected payload header: %v", decoded)
+	}
+	got, ok := decoded.Items[0].ToItem().(*itypes.Swap)
+	if !ok {
+		t.Fatalf("unexpected item: %T", decoded.Items[0].ToItem())
+	}
+	if got.Transaction != swap.Transaction || got.PairContract != swap.PairContract || got.LogIdx != 7 {
+		t.Errorf("identifiers not preserved: %+v", got)
+	}
+	if got.Amount0.Cmp(swap.Amount0) != 0 || got.Price0.Price.Cmp(swap.Price0.Price) != 0 {
+		t.Errorf("amounts not preserved: %+v", got)
+	}
+	if got.Price0.Confidence != 0.75 || got.Price0.LiquidityUSD.Cmp(swap.Price0.LiquidityUSD) != 0 {
+		t.Errorf("price confidence not preserved: %+v", got.Price0)
+	}
+	if cl, ok := got.Price0.Path[1].(itypes.WrappedCLMetadata); !ok || cl.Data.Answer.Int64() != -5 {
+		t.Errorf("price path not preserved: %+v", got.Price0.Path)
+	}
+	if rejected, ok := got.Price0.Path[2].(itypes.RejectedOracleMetadata); !ok || rejected != swap.Price0.Path[2] {
+		t.Errorf("rejected oracle not preserved: %+v", got.Price0.Path)
+	}
+	if len(got.Price1.Path) != 2 {
+		t.Fatalf("CEX price path not preserved: %+v", got.Price1.Path)
+	}
+	if rejected, ok := got.Price1.Path[0].(itypes.RejectedCEXMetadata); !ok || rejected != swap.Price1.Path[0] {
+		t.Errorf("rejected CEX price not preserved: %+v", got.Price1.Path[0])
+	}
+	want := swap.Price1.Path[1].(itypes.CEXMetadata)
+	if cex, ok := got.Price1.Path[1].(itypes.CEXMetadata); !ok || cex.Token != want.Token ||
+		cex.Symbol != want.Symbol || cex.Time != want.Time || cex.Price.Cmp(want.Price) != 0 {
+		t.Errorf("CEX price not preserved: %+v", got.Price1.Path[1])
+	}
+	state, ok := got.ExtraData.(itypes.UniV3SwapExtraData)
+	if !ok || state.Tick.Int64() != -887272 || state.Decimals0 != 18 {
+		t.Errorf("uniswap v3 state not preserved: %+v", got.ExtraData)
+	}
+}
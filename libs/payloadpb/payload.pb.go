@@ -0,0 +1,3033 @@
+// Binary schema of payloads sent by escope to output sinks configured
+// with `encoding: protobuf`. Schema changes bump
+// version.PersistenceVersion, carried in Payload.persistence_version.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: payload.proto
+
+package payloadpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BigFloat is an exact binary floating point number:
+// (-1)^negative * mantissa * 2^exponent. mantissa is big endian
+// unsigned magnitude, empty for zero. Unset BigFloat fields are nil.
+type BigFloat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mantissa []byte `protobuf:"bytes,1,opt,name=mantissa,proto3" json:"mantissa,omitempty"`
+	Exponent int32  `protobuf:"zigzag32,2,opt,name=exponent,proto3" json:"exponent,omitempty"`
+	Negative bool   `protobuf:"varint,3,opt,name=negative,proto3" json:"negative,omitempty"`
+}
+
+func (x *BigFloat) Reset() {
+	*x = BigFloat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BigFloat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BigFloat) ProtoMessage() {}
+
+func (x *BigFloat) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BigFloat.ProtoReflect.Descriptor instead.
+func (*BigFloat) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BigFloat) GetMantissa() []byte {
+	if x != nil {
+		return x.Mantissa
+	}
+	return nil
+}
+
+func (x *BigFloat) GetExponent() int32 {
+	if x != nil {
+		return x.Exponent
+	}
+	return 0
+}
+
+func (x *BigFloat) GetNegative() bool {
+	if x != nil {
+		return x.Negative
+	}
+	return false
+}
+
+// BigInt is an arbitrary precision integer, magnitude is big endian
+// unsigned, empty for zero
+type BigInt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Magnitude []byte `protobuf:"bytes,1,opt,name=magnitude,proto3" json:"magnitude,omitempty"`
+	Negative  bool   `protobuf:"varint,2,opt,name=negative,proto3" json:"negative,omitempty"`
+}
+
+func (x *BigInt) Reset() {
+	*x = BigInt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BigInt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BigInt) ProtoMessage() {}
+
+func (x *BigInt) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BigInt.ProtoReflect.Descriptor instead.
+func (*BigInt) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BigInt) GetMagnitude() []byte {
+	if x != nil {
+		return x.Magnitude
+	}
+	return nil
+}
+
+func (x *BigInt) GetNegative() bool {
+	if x != nil {
+		return x.Negative
+	}
+	return false
+}
+
+type Payload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PersistenceVersion uint32           `protobuf:"varint,1,opt,name=persistence_version,json=persistenceVersion,proto3" json:"persistence_version,omitempty"`
+	NodeMoniker        string           `protobuf:"bytes,2,opt,name=node_moniker,json=nodeMoniker,proto3" json:"node_moniker,omitempty"`
+	NodeId             []byte           `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	NodeVersion        string           `protobuf:"bytes,4,opt,name=node_version,json=nodeVersion,proto3" json:"node_version,omitempty"`
+	Environment        string           `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
+	Network            string           `protobuf:"bytes,6,opt,name=network,proto3" json:"network,omitempty"`
+	BlockSynopsis      *BlockSynopsis   `protobuf:"bytes,7,opt,name=block_synopsis,json=blockSynopsis,proto3" json:"block_synopsis,omitempty"`
+	NewDexes           []*UniV2Metadata `protobuf:"bytes,8,rep,name=new_dexes,json=newDexes,proto3" json:"new_dexes,omitempty"`
+	Items              []*Item          `protobuf:"bytes,9,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Payload) Reset() {
+	*x = Payload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Payload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payload) ProtoMessage() {}
+
+func (x *Payload) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payload.ProtoReflect.Descriptor instead.
+func (*Payload) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Payload) GetPersistenceVersion() uint32 {
+	if x != nil {
+		return x.PersistenceVersion
+	}
+	return 0
+}
+
+func (x *Payload) GetNodeMoniker() string {
+	if x != nil {
+		return x.NodeMoniker
+	}
+	return ""
+}
+
+func (x *Payload) GetNodeId() []byte {
+	if x != nil {
+		return x.NodeId
+	}
+	return nil
+}
+
+func (x *Payload) GetNodeVersion() string {
+	if x != nil {
+		return x.NodeVersion
+	}
+	return ""
+}
+
+func (x *Payload) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+func (x *Payload) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Payload) GetBlockSynopsis() *BlockSynopsis {
+	if x != nil {
+		return x.BlockSynopsis
+	}
+	return nil
+}
+
+func (x *Payload) GetNewDexes() []*UniV2Metadata {
+	if x != nil {
+		return x.NewDexes
+	}
+	return nil
+}
+
+func (x *Payload) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type BlockSynopsis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height                  uint64            `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	BlockTime               uint64            `protobuf:"varint,2,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	IndexingTimeNanos       uint64            `protobuf:"varint,3,opt,name=indexing_time_nanos,json=indexingTimeNanos,proto3" json:"indexing_time_nanos,omitempty"`
+	ProcessingDurationNanos uint64            `protobuf:"varint,4,opt,name=processing_duration_nanos,json=processingDurationNanos,proto3" json:"processing_duration_nanos,omitempty"`
+	PricingDurationNanos    uint64            `protobuf:"varint,5,opt,name=pricing_duration_nanos,json=pricingDurationNanos,proto3" json:"pricing_duration_nanos,omitempty"`
+	EventsScanned           uint64            `protobuf:"varint,6,opt,name=events_scanned,json=eventsScanned,proto3" json:"events_scanned,omitempty"`
+	EventsPriced            uint64            `protobuf:"varint,7,opt,name=events_priced,json=eventsPriced,proto3" json:"events_priced,omitempty"`
+	EventsUserDistribution  map[string]uint64 `protobuf:"bytes,8,rep,name=events_user_distribution,json=eventsUserDistribution,proto3" json:"events_user_distribution,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	EventsIndexed           []string          `protobuf:"bytes,9,rep,name=events_indexed,json=eventsIndexed,proto3" json:"events_indexed,omitempty"`
+}
+
+func (x *BlockSynopsis) Reset() {
+	*x = BlockSynopsis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockSynopsis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockSynopsis) ProtoMessage() {}
+
+func (x *BlockSynopsis) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockSynopsis.ProtoReflect.Descriptor instead.
+func (*BlockSynopsis) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BlockSynopsis) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *BlockSynopsis) GetBlockTime() uint64 {
+	if x != nil {
+		return x.BlockTime
+	}
+	return 0
+}
+
+func (x *BlockSynopsis) GetIndexingTimeNanos() uint64 {
+	if x != nil {
+		return x.IndexingTimeNanos
+	}
+	return 0
+}
+
+func (x *BlockSynopsis) GetProcessingDurationNanos() uint64 {
+	if x != nil {
+		return x.ProcessingDurationNanos
+	}
+	return 0
+}
+
+func (x *BlockSynopsis) GetPricingDurationNanos() uint64 {
+	if x != nil {
+		return x.PricingDurationNanos
+	}
+	return 0
+}
+
+func (x *BlockSynopsis) GetEventsScanned() uint64 {
+	if x != nil {
+		return x.EventsScanned
+	}
+	return 0
+}
+
+func (x *BlockSynopsis) GetEventsPriced() uint64 {
+	if x != nil {
+		return x.EventsPriced
+	}
+	return 0
+}
+
+func (x *BlockSynopsis) GetEventsUserDistribution() map[string]uint64 {
+	if x != nil {
+		return x.EventsUserDistribution
+	}
+	return nil
+}
+
+func (x *BlockSynopsis) GetEventsIndexed() []string {
+	if x != nil {
+		return x.EventsIndexed
+	}
+	return nil
+}
+
+type Item struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Item:
+	//	*Item_Mint
+	//	*Item_Burn
+	//	*Item_Swap
+	//	*Item_Transfer
+	//	*Item_Rollback
+	//	*Item_GenericEvent
+	Item isItem_Item `protobuf_oneof:"item"`
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{4}
+}
+
+func (m *Item) GetItem() isItem_Item {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+func (x *Item) GetMint() *Mint {
+	if x, ok := x.GetItem().(*Item_Mint); ok {
+		return x.Mint
+	}
+	return nil
+}
+
+func (x *Item) GetBurn() *Burn {
+	if x, ok := x.GetItem().(*Item_Burn); ok {
+		return x.Burn
+	}
+	return nil
+}
+
+func (x *Item) GetSwap() *Swap {
+	if x, ok := x.GetItem().(*Item_Swap); ok {
+		return x.Swap
+	}
+	return nil
+}
+
+func (x *Item) GetTransfer() *Transfer {
+	if x, ok := x.GetItem().(*Item_Transfer); ok {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *Item) GetRollback() *Rollback {
+	if x, ok := x.GetItem().(*Item_Rollback); ok {
+		return x.Rollback
+	}
+	return nil
+}
+
+func (x *Item) GetGenericEvent() *GenericEvent {
+	if x, ok := x.GetItem().(*Item_GenericEvent); ok {
+		return x.GenericEvent
+	}
+	return nil
+}
+
+type isItem_Item interface {
+	isItem_Item()
+}
+
+type Item_Mint struct {
+	Mint *Mint `protobuf:"bytes,1,opt,name=mint,proto3,oneof"`
+}
+
+type Item_Burn struct {
+	Burn *Burn `protobuf:"bytes,2,opt,name=burn,proto3,oneof"`
+}
+
+type Item_Swap struct {
+	Swap *Swap `protobuf:"bytes,3,opt,name=swap,proto3,oneof"`
+}
+
+type Item_Transfer struct {
+	Transfer *Transfer `protobuf:"bytes,4,opt,name=transfer,proto3,oneof"`
+}
+
+type Item_Rollback struct {
+	Rollback *Rollback `protobuf:"bytes,5,opt,name=rollback,proto3,oneof"`
+}
+
+type Item_GenericEvent struct {
+	GenericEvent *GenericEvent `protobuf:"bytes,6,opt,name=generic_event,json=genericEvent,proto3,oneof"`
+}
+
+func (*Item_Mint) isItem_Item() {}
+
+func (*Item_Burn) isItem_Item() {}
+
+func (*Item_Swap) isItem_Item() {}
+
+func (*Item_Transfer) isItem_Item() {}
+
+func (*Item_Rollback) isItem_Item() {}
+
+func (*Item_GenericEvent) isItem_Item() {}
+
+type Transfer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type                string       `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	LogIdx              uint64       `protobuf:"varint,2,opt,name=log_idx,json=logIdx,proto3" json:"log_idx,omitempty"`
+	Transaction         []byte       `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Time                uint64       `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	Height              uint64       `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Token               []byte       `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	Sender              []byte       `protobuf:"bytes,7,opt,name=sender,proto3" json:"sender,omitempty"`
+	TxSender            []byte       `protobuf:"bytes,8,opt,name=tx_sender,json=txSender,proto3" json:"tx_sender,omitempty"`
+	Receiver            []byte       `protobuf:"bytes,9,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Amount              *BigFloat    `protobuf:"bytes,10,opt,name=amount,proto3" json:"amount,omitempty"`
+	AmountUsd           *BigFloat    `protobuf:"bytes,11,opt,name=amount_usd,json=amountUsd,proto3" json:"amount_usd,omitempty"`
+	PriceDerivationMeta *PriceResult `protobuf:"bytes,12,opt,name=price_derivation_meta,json=priceDerivationMeta,proto3" json:"price_derivation_meta,omitempty"`
+}
+
+func (x *Transfer) Reset() {
+	*x = Transfer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transfer) ProtoMessage() {}
+
+func (x *Transfer) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transfer.ProtoReflect.Descriptor instead.
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Transfer) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Transfer) GetLogIdx() uint64 {
+	if x != nil {
+		return x.LogIdx
+	}
+	return 0
+}
+
+func (x *Transfer) GetTransaction() []byte {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *Transfer) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Transfer) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Transfer) GetToken() []byte {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *Transfer) GetSender() []byte {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *Transfer) GetTxSender() []byte {
+	if x != nil {
+		return x.TxSender
+	}
+	return nil
+}
+
+func (x *Transfer) GetReceiver() []byte {
+	if x != nil {
+		return x.Receiver
+	}
+	return nil
+}
+
+func (x *Transfer) GetAmount() *BigFloat {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Transfer) GetAmountUsd() *BigFloat {
+	if x != nil {
+		return x.AmountUsd
+	}
+	return nil
+}
+
+func (x *Transfer) GetPriceDerivationMeta() *PriceResult {
+	if x != nil {
+		return x.PriceDerivationMeta
+	}
+	return nil
+}
+
+type Mint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type         string       `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	LogIdx       uint64       `protobuf:"varint,2,opt,name=log_idx,json=logIdx,proto3" json:"log_idx,omitempty"`
+	Transaction  []byte       `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Time         uint64       `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	Height       uint64       `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Sender       []byte       `protobuf:"bytes,6,opt,name=sender,proto3" json:"sender,omitempty"`
+	TxSender     []byte       `protobuf:"bytes,7,opt,name=tx_sender,json=txSender,proto3" json:"tx_sender,omitempty"`
+	PairContract []byte       `protobuf:"bytes,8,opt,name=pair_contract,json=pairContract,proto3" json:"pair_contract,omitempty"`
+	Token0       []byte       `protobuf:"bytes,9,opt,name=token0,proto3" json:"token0,omitempty"`
+	Token1       []byte       `protobuf:"bytes,10,opt,name=token1,proto3" json:"token1,omitempty"`
+	Amount0      *BigFloat    `protobuf:"bytes,11,opt,name=amount0,proto3" json:"amount0,omitempty"`
+	Amount1      *BigFloat    `protobuf:"bytes,12,opt,name=amount1,proto3" json:"amount1,omitempty"`
+	Reserve0     *BigFloat    `protobuf:"bytes,13,opt,name=reserve0,proto3" json:"reserve0,omitempty"`
+	Reserve1     *BigFloat    `protobuf:"bytes,14,opt,name=reserve1,proto3" json:"reserve1,omitempty"`
+	AmountUsd    *BigFloat    `protobuf:"bytes,15,opt,name=amount_usd,json=amountUsd,proto3" json:"amount_usd,omitempty"`
+	Price0       *PriceResult `protobuf:"bytes,16,opt,name=price0,proto3" json:"price0,omitempty"`
+	Price1       *PriceResult `protobuf:"bytes,17,opt,name=price1,proto3" json:"price1,omitempty"`
+}
+
+func (x *Mint) Reset() {
+	*x = Mint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Mint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mint) ProtoMessage() {}
+
+func (x *Mint) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mint.ProtoReflect.Descriptor instead.
+func (*Mint) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Mint) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Mint) GetLogIdx() uint64 {
+	if x != nil {
+		return x.LogIdx
+	}
+	return 0
+}
+
+func (x *Mint) GetTransaction() []byte {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *Mint) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Mint) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Mint) GetSender() []byte {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *Mint) GetTxSender() []byte {
+	if x != nil {
+		return x.TxSender
+	}
+	return nil
+}
+
+func (x *Mint) GetPairContract() []byte {
+	if x != nil {
+		return x.PairContract
+	}
+	return nil
+}
+
+func (x *Mint) GetToken0() []byte {
+	if x != nil {
+		return x.Token0
+	}
+	return nil
+}
+
+func (x *Mint) GetToken1() []byte {
+	if x != nil {
+		return x.Token1
+	}
+	return nil
+}
+
+func (x *Mint) GetAmount0() *BigFloat {
+	if x != nil {
+		return x.Amount0
+	}
+	return nil
+}
+
+func (x *Mint) GetAmount1() *BigFloat {
+	if x != nil {
+		return x.Amount1
+	}
+	return nil
+}
+
+func (x *Mint) GetReserve0() *BigFloat {
+	if x != nil {
+		return x.Reserve0
+	}
+	return nil
+}
+
+func (x *Mint) GetReserve1() *BigFloat {
+	if x != nil {
+		return x.Reserve1
+	}
+	return nil
+}
+
+func (x *Mint) GetAmountUsd() *BigFloat {
+	if x != nil {
+		return x.AmountUsd
+	}
+	return nil
+}
+
+func (x *Mint) GetPrice0() *PriceResult {
+	if x != nil {
+		return x.Price0
+	}
+	return nil
+}
+
+func (x *Mint) GetPrice1() *PriceResult {
+	if x != nil {
+		return x.Price1
+	}
+	return nil
+}
+
+type Burn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type         string       `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	LogIdx       uint64       `protobuf:"varint,2,opt,name=log_idx,json=logIdx,proto3" json:"log_idx,omitempty"`
+	Transaction  []byte       `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Time         uint64       `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	Height       uint64       `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Sender       []byte       `protobuf:"bytes,6,opt,name=sender,proto3" json:"sender,omitempty"`
+	TxSender     []byte       `protobuf:"bytes,7,opt,name=tx_sender,json=txSender,proto3" json:"tx_sender,omitempty"`
+	PairContract []byte       `protobuf:"bytes,8,opt,name=pair_contract,json=pairContract,proto3" json:"pair_contract,omitempty"`
+	Token0       []byte       `protobuf:"bytes,9,opt,name=token0,proto3" json:"token0,omitempty"`
+	Token1       []byte       `protobuf:"bytes,10,opt,name=token1,proto3" json:"token1,omitempty"`
+	Amount0      *BigFloat    `protobuf:"bytes,11,opt,name=amount0,proto3" json:"amount0,omitempty"`
+	Amount1      *BigFloat    `protobuf:"bytes,12,opt,name=amount1,proto3" json:"amount1,omitempty"`
+	Reserve0     *BigFloat    `protobuf:"bytes,13,opt,name=reserve0,proto3" json:"reserve0,omitempty"`
+	Reserve1     *BigFloat    `protobuf:"bytes,14,opt,name=reserve1,proto3" json:"reserve1,omitempty"`
+	AmountUsd    *BigFloat    `protobuf:"bytes,15,opt,name=amount_usd,json=amountUsd,proto3" json:"amount_usd,omitempty"`
+	Price0       *PriceResult `protobuf:"bytes,16,opt,name=price0,proto3" json:"price0,omitempty"`
+	Price1       *PriceResult `protobuf:"bytes,17,opt,name=price1,proto3" json:"price1,omitempty"`
+}
+
+func (x *Burn) Reset() {
+	*x = Burn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Burn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Burn) ProtoMessage() {}
+
+func (x *Burn) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Burn.ProtoReflect.Descriptor instead.
+func (*Burn) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Burn) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Burn) GetLogIdx() uint64 {
+	if x != nil {
+		return x.LogIdx
+	}
+	return 0
+}
+
+func (x *Burn) GetTransaction() []byte {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *Burn) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Burn) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Burn) GetSender() []byte {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *Burn) GetTxSender() []byte {
+	if x != nil {
+		return x.TxSender
+	}
+	return nil
+}
+
+func (x *Burn) GetPairContract() []byte {
+	if x != nil {
+		return x.PairContract
+	}
+	return nil
+}
+
+func (x *Burn) GetToken0() []byte {
+	if x != nil {
+		return x.Token0
+	}
+	return nil
+}
+
+func (x *Burn) GetToken1() []byte {
+	if x != nil {
+		return x.Token1
+	}
+	return nil
+}
+
+func (x *Burn) GetAmount0() *BigFloat {
+	if x != nil {
+		return x.Amount0
+	}
+	return nil
+}
+
+func (x *Burn) GetAmount1() *BigFloat {
+	if x != nil {
+		return x.Amount1
+	}
+	return nil
+}
+
+func (x *Burn) GetReserve0() *BigFloat {
+	if x != nil {
+		return x.Reserve0
+	}
+	return nil
+}
+
+func (x *Burn) GetReserve1() *BigFloat {
+	if x != nil {
+		return x.Reserve1
+	}
+	return nil
+}
+
+func (x *Burn) GetAmountUsd() *BigFloat {
+	if x != nil {
+		return x.AmountUsd
+	}
+	return nil
+}
+
+func (x *Burn) GetPrice0() *PriceResult {
+	if x != nil {
+		return x.Price0
+	}
+	return nil
+}
+
+func (x *Burn) GetPrice1() *PriceResult {
+	if x != nil {
+		return x.Price1
+	}
+	return nil
+}
+
+type Swap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type         string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	LogIdx       uint64          `protobuf:"varint,2,opt,name=log_idx,json=logIdx,proto3" json:"log_idx,omitempty"`
+	Transaction  []byte          `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Time         uint64          `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	Height       uint64          `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Sender       []byte          `protobuf:"bytes,6,opt,name=sender,proto3" json:"sender,omitempty"`
+	TxSender     []byte          `protobuf:"bytes,7,opt,name=tx_sender,json=txSender,proto3" json:"tx_sender,omitempty"`
+	Receiver     []byte          `protobuf:"bytes,8,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	PairContract []byte          `protobuf:"bytes,9,opt,name=pair_contract,json=pairContract,proto3" json:"pair_contract,omitempty"`
+	Token0       []byte          `protobuf:"bytes,10,opt,name=token0,proto3" json:"token0,omitempty"`
+	Token1       []byte          `protobuf:"bytes,11,opt,name=token1,proto3" json:"token1,omitempty"`
+	Amount0      *BigFloat       `protobuf:"bytes,12,opt,name=amount0,proto3" json:"amount0,omitempty"`
+	Amount1      *BigFloat       `protobuf:"bytes,13,opt,name=amount1,proto3" json:"amount1,omitempty"`
+	Reserve0     *BigFloat       `protobuf:"bytes,14,opt,name=reserve0,proto3" json:"reserve0,omitempty"`
+	Reserve1     *BigFloat       `protobuf:"bytes,15,opt,name=reserve1,proto3" json:"reserve1,omitempty"`
+	AmountUsd    *BigFloat       `protobuf:"bytes,16,opt,name=amount_usd,json=amountUsd,proto3" json:"amount_usd,omitempty"`
+	Price0       *PriceResult    `protobuf:"bytes,17,opt,name=price0,proto3" json:"price0,omitempty"`
+	Price1       *PriceResult    `protobuf:"bytes,18,opt,name=price1,proto3" json:"price1,omitempty"`
+	Univ3State   *UniV3SwapState `protobuf:"bytes,19,opt,name=univ3_state,json=univ3State,proto3" json:"univ3_state,omitempty"`
+}
+
+func (x *Swap) Reset() {
+	*x = Swap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Swap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Swap) ProtoMessage() {}
+
+func (x *Swap) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Swap.ProtoReflect.Descriptor instead.
+func (*Swap) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Swap) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Swap) GetLogIdx() uint64 {
+	if x != nil {
+		return x.LogIdx
+	}
+	return 0
+}
+
+func (x *Swap) GetTransaction() []byte {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *Swap) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Swap) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Swap) GetSender() []byte {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *Swap) GetTxSender() []byte {
+	if x != nil {
+		return x.TxSender
+	}
+	return nil
+}
+
+func (x *Swap) GetReceiver() []byte {
+	if x != nil {
+		return x.Receiver
+	}
+	return nil
+}
+
+func (x *Swap) GetPairContract() []byte {
+	if x != nil {
+		return x.PairContract
+	}
+	return nil
+}
+
+func (x *Swap) GetToken0() []byte {
+	if x != nil {
+		return x.Token0
+	}
+	return nil
+}
+
+func (x *Swap) GetToken1() []byte {
+	if x != nil {
+		return x.Token1
+	}
+	return nil
+}
+
+func (x *Swap) GetAmount0() *BigFloat {
+	if x != nil {
+		return x.Amount0
+	}
+	return nil
+}
+
+func (x *Swap) GetAmount1() *BigFloat {
+	if x != nil {
+		return x.Amount1
+	}
+	return nil
+}
+
+func (x *Swap) GetReserve0() *BigFloat {
+	if x != nil {
+		return x.Reserve0
+	}
+	return nil
+}
+
+func (x *Swap) GetReserve1() *BigFloat {
+	if x != nil {
+		return x.Reserve1
+	}
+	return nil
+}
+
+func (x *Swap) GetAmountUsd() *BigFloat {
+	if x != nil {
+		return x.AmountUsd
+	}
+	return nil
+}
+
+func (x *Swap) GetPrice0() *PriceResult {
+	if x != nil {
+		return x.Price0
+	}
+	return nil
+}
+
+func (x *Swap) GetPrice1() *PriceResult {
+	if x != nil {
+		return x.Price1
+	}
+	return nil
+}
+
+func (x *Swap) GetUniv3State() *UniV3SwapState {
+	if x != nil {
+		return x.Univ3State
+	}
+	return nil
+}
+
+// UniV3SwapState is pool state post swap of uniswap v3 swaps
+type UniV3SwapState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SqrtPriceX96 *BigInt `protobuf:"bytes,1,opt,name=sqrt_price_x96,json=sqrtPriceX96,proto3" json:"sqrt_price_x96,omitempty"`
+	Liquidity    *BigInt `protobuf:"bytes,2,opt,name=liquidity,proto3" json:"liquidity,omitempty"`
+	Tick         int64   `protobuf:"zigzag64,3,opt,name=tick,proto3" json:"tick,omitempty"`
+	Decimals0    uint32  `protobuf:"varint,4,opt,name=decimals0,proto3" json:"decimals0,omitempty"`
+	Decimals1    uint32  `protobuf:"varint,5,opt,name=decimals1,proto3" json:"decimals1,omitempty"`
+}
+
+func (x *UniV3SwapState) Reset() {
+	*x = UniV3SwapState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UniV3SwapState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UniV3SwapState) ProtoMessage() {}
+
+func (x *UniV3SwapState) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UniV3SwapState.ProtoReflect.Descriptor instead.
+func (*UniV3SwapState) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UniV3SwapState) GetSqrtPriceX96() *BigInt {
+	if x != nil {
+		return x.SqrtPriceX96
+	}
+	return nil
+}
+
+func (x *UniV3SwapState) GetLiquidity() *BigInt {
+	if x != nil {
+		return x.Liquidity
+	}
+	return nil
+}
+
+func (x *UniV3SwapState) GetTick() int64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *UniV3SwapState) GetDecimals0() uint32 {
+	if x != nil {
+		return x.Decimals0
+	}
+	return 0
+}
+
+func (x *UniV3SwapState) GetDecimals1() uint32 {
+	if x != nil {
+		return x.Decimals1
+	}
+	return 0
+}
+
+type Rollback struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type           string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	CommonAncestor uint64   `protobuf:"varint,2,opt,name=common_ancestor,json=commonAncestor,proto3" json:"common_ancestor,omitempty"`
+	OrphanedHeight uint64   `protobuf:"varint,3,opt,name=orphaned_height,json=orphanedHeight,proto3" json:"orphaned_height,omitempty"`
+	OrphanedHashes [][]byte `protobuf:"bytes,4,rep,name=orphaned_hashes,json=orphanedHashes,proto3" json:"orphaned_hashes,omitempty"`
+}
+
+func (x *Rollback) Reset() {
+	*x = Rollback{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rollback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rollback) ProtoMessage() {}
+
+func (x *Rollback) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rollback.ProtoReflect.Descriptor instead.
+func (*Rollback) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Rollback) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Rollback) GetCommonAncestor() uint64 {
+	if x != nil {
+		return x.CommonAncestor
+	}
+	return 0
+}
+
+func (x *Rollback) GetOrphanedHeight() uint64 {
+	if x != nil {
+		return x.OrphanedHeight
+	}
+	return 0
+}
+
+func (x *Rollback) GetOrphanedHashes() [][]byte {
+	if x != nil {
+		return x.OrphanedHashes
+	}
+	return nil
+}
+
+type GenericEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type        string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	LogIdx      uint64          `protobuf:"varint,2,opt,name=log_idx,json=logIdx,proto3" json:"log_idx,omitempty"`
+	Transaction []byte          `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Time        uint64          `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	Height      uint64          `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Contract    []byte          `protobuf:"bytes,6,opt,name=contract,proto3" json:"contract,omitempty"`
+	Event       string          `protobuf:"bytes,7,opt,name=event,proto3" json:"event,omitempty"`
+	Signature   string          `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	Fields      []*GenericField `protobuf:"bytes,9,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *GenericEvent) Reset() {
+	*x = GenericEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenericEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenericEvent) ProtoMessage() {}
+
+func (x *GenericEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenericEvent.ProtoReflect.Descriptor instead.
+func (*GenericEvent) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GenericEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GenericEvent) GetLogIdx() uint64 {
+	if x != nil {
+		return x.LogIdx
+	}
+	return 0
+}
+
+func (x *GenericEvent) GetTransaction() []byte {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *GenericEvent) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *GenericEvent) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GenericEvent) GetContract() []byte {
+	if x != nil {
+		return x.Contract
+	}
+	return nil
+}
+
+func (x *GenericEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *GenericEvent) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *GenericEvent) GetFields() []*GenericField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// GenericField value is JSON encoded as values are of arbitrary
+// solidity types
+type GenericField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type      string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Indexed   bool   `protobuf:"varint,3,opt,name=indexed,proto3" json:"indexed,omitempty"`
+	JsonValue []byte `protobuf:"bytes,4,opt,name=json_value,json=jsonValue,proto3" json:"json_value,omitempty"`
+}
+
+func (x *GenericField) Reset() {
+	*x = GenericField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenericField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenericField) ProtoMessage() {}
+
+func (x *GenericField) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenericField.ProtoReflect.Descriptor instead.
+func (*GenericField) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GenericField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GenericField) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GenericField) GetIndexed() bool {
+	if x != nil {
+		return x.Indexed
+	}
+	return false
+}
+
+func (x *GenericField) GetJsonValue() []byte {
+	if x != nil {
+		return x.JsonValue
+	}
+	return nil
+}
+
+type PriceResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price        *BigFloat   `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	Path         []*PriceHop `protobuf:"bytes,2,rep,name=path,proto3" json:"path,omitempty"`
+	Confidence   float64     `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"`                       // share of route liquidity agreeing with price, in [0, 1]
+	LiquidityUsd *BigFloat   `protobuf:"bytes,4,opt,name=liquidity_usd,json=liquidityUsd,proto3" json:"liquidity_usd,omitempty"` // liquidity of chosen route
+}
+
+func (x *PriceResult) Reset() {
+	*x = PriceResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceResult) ProtoMessage() {}
+
+func (x *PriceResult) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceResult.ProtoReflect.Descriptor instead.
+func (*PriceResult) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PriceResult) GetPrice() *BigFloat {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *PriceResult) GetPath() []*PriceHop {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *PriceResult) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *PriceResult) GetLiquidityUsd() *BigFloat {
+	if x != nil {
+		return x.LiquidityUsd
+	}
+	return nil
+}
+
+// PriceHop is a single edge used in deriving a price
+type PriceHop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Description string `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	// Types that are assignable to Hop:
+	//	*PriceHop_Dex
+	//	*PriceHop_Chainlink
+	//	*PriceHop_Counterparty
+	//	*PriceHop_RejectedOracle
+	//	*PriceHop_Cex
+	//	*PriceHop_RejectedCex
+	Hop isPriceHop_Hop `protobuf_oneof:"hop"`
+}
+
+func (x *PriceHop) Reset() {
+	*x = PriceHop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceHop) ProtoMessage() {}
+
+func (x *PriceHop) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceHop.ProtoReflect.Descriptor instead.
+func (*PriceHop) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PriceHop) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (m *PriceHop) GetHop() isPriceHop_Hop {
+	if m != nil {
+		return m.Hop
+	}
+	return nil
+}
+
+func (x *PriceHop) GetDex() *DexHop {
+	if x, ok := x.GetHop().(*PriceHop_Dex); ok {
+		return x.Dex
+	}
+	return nil
+}
+
+func (x *PriceHop) GetChainlink() *ChainlinkHop {
+	if x, ok := x.GetHop().(*PriceHop_Chainlink); ok {
+		return x.Chainlink
+	}
+	return nil
+}
+
+func (x *PriceHop) GetCounterparty() *CounterpartyHop {
+	if x, ok := x.GetHop().(*PriceHop_Counterparty); ok {
+		return x.Counterparty
+	}
+	return nil
+}
+
+func (x *PriceHop) GetRejectedOracle() *RejectedOracleHop {
+	if x, ok := x.GetHop().(*PriceHop_RejectedOracle); ok {
+		return x.RejectedOracle
+	}
+	return nil
+}
+
+func (x *PriceHop) GetCex() *CEXHop {
+	if x, ok := x.GetHop().(*PriceHop_Cex); ok {
+		return x.Cex
+	}
+	return nil
+}
+
+func (x *PriceHop) GetRejectedCex() *RejectedCEXHop {
+	if x, ok := x.GetHop().(*PriceHop_RejectedCex); ok {
+		return x.RejectedCex
+	}
+	return nil
+}
+
+type isPriceHop_Hop interface {
+	isPriceHop_Hop()
+}
+
+type PriceHop_Dex struct {
+	Dex *DexHop `protobuf:"bytes,2,opt,name=dex,proto3,oneof"`
+}
+
+type PriceHop_Chainlink struct {
+	Chainlink *ChainlinkHop `protobuf:"bytes,3,opt,name=chainlink,proto3,oneof"`
+}
+
+type PriceHop_Counterparty struct {
+	Counterparty *CounterpartyHop `protobuf:"bytes,4,opt,name=counterparty,proto3,oneof"`
+}
+
+type PriceHop_RejectedOracle struct {
+	RejectedOracle *RejectedOracleHop `protobuf:"bytes,5,opt,name=rejected_oracle,json=rejectedOracle,proto3,oneof"`
+}
+
+type PriceHop_Cex struct {
+	Cex *CEXHop `protobuf:"bytes,6,opt,name=cex,proto3,oneof"`
+}
+
+type PriceHop_RejectedCex struct {
+	RejectedCex *RejectedCEXHop `protobuf:"bytes,7,opt,name=rejected_cex,json=rejectedCex,proto3,oneof"`
+}
+
+func (*PriceHop_Dex) isPriceHop_Hop() {}
+
+func (*PriceHop_Chainlink) isPriceHop_Hop() {}
+
+func (*PriceHop_Counterparty) isPriceHop_Hop() {}
+
+func (*PriceHop_RejectedOracle) isPriceHop_Hop() {}
+
+func (*PriceHop_Cex) isPriceHop_Hop() {}
+
+func (*PriceHop_RejectedCex) isPriceHop_Hop() {}
+
+type DexHop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair         []byte    `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Token0       []byte    `protobuf:"bytes,2,opt,name=token0,proto3" json:"token0,omitempty"`
+	Token1       []byte    `protobuf:"bytes,3,opt,name=token1,proto3" json:"token1,omitempty"`
+	Res0         *BigFloat `protobuf:"bytes,4,opt,name=res0,proto3" json:"res0,omitempty"`
+	Res1         *BigFloat `protobuf:"bytes,5,opt,name=res1,proto3" json:"res1,omitempty"`
+	Concentrated bool      `protobuf:"varint,6,opt,name=concentrated,proto3" json:"concentrated,omitempty"` // uniswap v3 style pool, reserves are virtual
+}
+
+func (x *DexHop) Reset() {
+	*x = DexHop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DexHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DexHop) ProtoMessage() {}
+
+func (x *DexHop) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DexHop.ProtoReflect.Descriptor instead.
+func (*DexHop) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DexHop) GetPair() []byte {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+func (x *DexHop) GetToken0() []byte {
+	if x != nil {
+		return x.Token0
+	}
+	return nil
+}
+
+func (x *DexHop) GetToken1() []byte {
+	if x != nil {
+		return x.Token1
+	}
+	return nil
+}
+
+func (x *DexHop) GetRes0() *BigFloat {
+	if x != nil {
+		return x.Res0
+	}
+	return nil
+}
+
+func (x *DexHop) GetRes1() *BigFloat {
+	if x != nil {
+		return x.Res1
+	}
+	return nil
+}
+
+func (x *DexHop) GetConcentrated() bool {
+	if x != nil {
+		return x.Concentrated
+	}
+	return false
+}
+
+type ChainlinkHop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Oracle    []byte  `protobuf:"bytes,1,opt,name=oracle,proto3" json:"oracle,omitempty"`
+	From      []byte  `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To        []byte  `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Answer    *BigInt `protobuf:"bytes,4,opt,name=answer,proto3" json:"answer,omitempty"`
+	UpdatedAt *BigInt `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *ChainlinkHop) Reset() {
+	*x = ChainlinkHop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChainlinkHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainlinkHop) ProtoMessage() {}
+
+func (x *ChainlinkHop) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainlinkHop.ProtoReflect.Descriptor instead.
+func (*ChainlinkHop) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ChainlinkHop) GetOracle() []byte {
+	if x != nil {
+		return x.Oracle
+	}
+	return nil
+}
+
+func (x *ChainlinkHop) GetFrom() []byte {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ChainlinkHop) GetTo() []byte {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ChainlinkHop) GetAnswer() *BigInt {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+func (x *ChainlinkHop) GetUpdatedAt() *BigInt {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CounterpartyHop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price *BigFloat `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+}
+
+func (x *CounterpartyHop) Reset() {
+	*x = CounterpartyHop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CounterpartyHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CounterpartyHop) ProtoMessage() {}
+
+func (x *CounterpartyHop) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CounterpartyHop.ProtoReflect.Descriptor instead.
+func (*CounterpartyHop) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CounterpartyHop) GetPrice() *BigFloat {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+// RejectedOracleHop notes a chainlink feed not trusted while pricing
+type RejectedOracleHop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Oracle []byte `protobuf:"bytes,1,opt,name=oracle,proto3" json:"oracle,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RejectedOracleHop) Reset() {
+	*x = RejectedOracleHop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectedOracleHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedOracleHop) ProtoMessage() {}
+
+func (x *RejectedOracleHop) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedOracleHop.ProtoReflect.Descriptor instead.
+func (*RejectedOracleHop) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RejectedOracleHop) GetOracle() []byte {
+	if x != nil {
+		return x.Oracle
+	}
+	return nil
+}
+
+func (x *RejectedOracleHop) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// CEXHop is a CEX price of token to USD as of block at time
+type CEXHop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token  []byte    `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Symbol string    `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Price  *BigFloat `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Time   uint64    `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *CEXHop) Reset() {
+	*x = CEXHop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CEXHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CEXHop) ProtoMessage() {}
+
+func (x *CEXHop) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CEXHop.ProtoReflect.Descriptor instead.
+func (*CEXHop) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CEXHop) GetToken() []byte {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *CEXHop) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CEXHop) GetPrice() *BigFloat {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *CEXHop) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+// RejectedCEXHop notes a CEX price not used while pricing
+type RejectedCEXHop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RejectedCEXHop) Reset() {
+	*x = RejectedCEXHop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectedCEXHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedCEXHop) ProtoMessage() {}
+
+func (x *RejectedCEXHop) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedCEXHop.ProtoReflect.Descriptor instead.
+func (*RejectedCEXHop) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RejectedCEXHop) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *RejectedCEXHop) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UniV2Metadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Description string    `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Pair        []byte    `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Token0      []byte    `protobuf:"bytes,3,opt,name=token0,proto3" json:"token0,omitempty"`
+	Token1      []byte    `protobuf:"bytes,4,opt,name=token1,proto3" json:"token1,omitempty"`
+	Res0        *BigFloat `protobuf:"bytes,5,opt,name=res0,proto3" json:"res0,omitempty"`
+	Res1        *BigFloat `protobuf:"bytes,6,opt,name=res1,proto3" json:"res1,omitempty"`
+}
+
+func (x *UniV2Metadata) Reset() {
+	*x = UniV2Metadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UniV2Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UniV2Metadata) ProtoMessage() {}
+
+func (x *UniV2Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UniV2Metadata.ProtoReflect.Descriptor instead.
+func (*UniV2Metadata) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UniV2Metadata) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UniV2Metadata) GetPair() []byte {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+func (x *UniV2Metadata) GetToken0() []byte {
+	if x != nil {
+		return x.Token0
+	}
+	return nil
+}
+
+func (x *UniV2Metadata) GetToken1() []byte {
+	if x != nil {
+		return x.Token1
+	}
+	return nil
+}
+
+func (x *UniV2Metadata) GetRes0() *BigFloat {
+	if x != nil {
+		return x.Res0
+	}
+	return nil
+}
+
+func (x *UniV2Metadata) GetRes1() *BigFloat {
+	if x != nil {
+		return x.Res1
+	}
+	return nil
+}
+
+var File_payload_proto protoreflect.FileDescriptor
+
+var file_payload_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x11, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x76, 0x31, 0x22, 0x5e, 0x0a, 0x08, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x73, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x73, 0x73, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x11, 0x52, 0x08, 0x65, 0x78,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x76, 0x65, 0x22, 0x42, 0x0a, 0x06, 0x42, 0x69, 0x67, 0x49, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x6d, 0x61, 0x67, 0x6e, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x09, 0x6d, 0x61, 0x67, 0x6e, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0x8c, 0x03, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63,
+	0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x12, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6d, 0x6f, 0x6e, 0x69,
+	0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x4d,
+	0x6f, 0x6e, 0x69, 0x6b, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12,
+	0x21, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x47,
+	0x0a, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x79, 0x6e, 0x6f, 0x70, 0x73, 0x69, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x53, 0x79, 0x6e, 0x6f, 0x70, 0x73, 0x69, 0x73, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53,
+	0x79, 0x6e, 0x6f, 0x70, 0x73, 0x69, 0x73, 0x12, 0x3d, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x64,
+	0x65, 0x78, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x65, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x55,
+	0x6e, 0x69, 0x56, 0x32, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6e, 0x65,
+	0x77, 0x44, 0x65, 0x78, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05,
+	0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x9e, 0x04, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53,
+	0x79, 0x6e, 0x6f, 0x70, 0x73, 0x69, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2e,
+	0x0a, 0x13, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x6e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x12, 0x3a,
+	0x0a, 0x19, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x17, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x72,
+	0x69, 0x63, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e,
+	0x61, 0x6e, 0x6f, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x70, 0x72, 0x69, 0x63,
+	0x69, 0x6e, 0x67, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x73,
+	0x12, 0x25, 0x0a, 0x0e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x73, 0x63, 0x61, 0x6e, 0x6e,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x53, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x64, 0x12, 0x76, 0x0a, 0x18,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x69, 0x73, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c,
+	0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x79, 0x6e, 0x6f, 0x70, 0x73, 0x69, 0x73,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x55, 0x73, 0x65, 0x72, 0x44, 0x69, 0x73, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x16, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x55, 0x73, 0x65, 0x72, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x1a, 0x49, 0x0a, 0x1b, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x55, 0x73, 0x65, 0x72, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd9, 0x02, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12,
+	0x2d, 0x0a, 0x04, 0x6d, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x69, 0x6e, 0x74, 0x12, 0x2d,
+	0x0a, 0x04, 0x62, 0x75, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x75, 0x72, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x62, 0x75, 0x72, 0x6e, 0x12, 0x2d, 0x0a,
+	0x04, 0x73, 0x77, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x77, 0x61, 0x70, 0x48, 0x00, 0x52, 0x04, 0x73, 0x77, 0x61, 0x70, 0x12, 0x39, 0x0a, 0x08,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x48, 0x00, 0x52, 0x08, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x08, 0x72, 0x6f, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f,
+	0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f,
+	0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x6c, 0x62, 0x61,
+	0x63, 0x6b, 0x12, 0x46, 0x0a, 0x0d, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x5f, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x73, 0x63, 0x6f,
+	0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x69, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x69, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x69, 0x74,
+	0x65, 0x6d, 0x22, 0xb1, 0x03, 0x0a, 0x08, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x6f, 0x67, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x78, 0x12, 0x20, 0x0a, 0x0b,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x78, 0x5f, 0x73,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x78, 0x53,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x72, 0x12, 0x33, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x75, 0x73, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x09, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x55,
+	0x73, 0x64, 0x12, 0x52, 0x0a, 0x15, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x64, 0x65, 0x72, 0x69,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x13, 0x70, 0x72, 0x69, 0x63, 0x65, 0x44, 0x65, 0x72, 0x69, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x22, 0x97, 0x05, 0x0a, 0x04, 0x4d, 0x69, 0x6e, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x6f, 0x67, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x78, 0x12, 0x20, 0x0a, 0x0b,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x78, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x78, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x61, 0x69, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x61, 0x69, 0x72, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x30, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x30, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x31, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x31, 0x12, 0x35, 0x0a, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x30, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f,
+	0x61, 0x74, 0x52, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x30, 0x12, 0x35, 0x0a, 0x07, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x31, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x31, 0x12, 0x37, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x30, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61,
+	0x74, 0x52, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x30, 0x12, 0x37, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x31, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x08, 0x72, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x31, 0x12, 0x3a, 0x0a, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x75,
+	0x73, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67,
+	0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x09, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x73, 0x64,
+	0x12, 0x36, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x30, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x30, 0x12, 0x36, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x31, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x31,
+	0x22, 0x97, 0x05, 0x0a, 0x04, 0x42, 0x75, 0x72, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a,
+	0x07, 0x6c, 0x6f, 0x67, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x6c, 0x6f, 0x67, 0x49, 0x64, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x78, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x08, 0x74, 0x78, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x69,
+	0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0c, 0x70, 0x61, 0x69, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x30, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x30, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x31,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x31, 0x12, 0x35,
+	0x0a, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x30, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x07, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x30, 0x12, 0x35, 0x0a, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x31,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c,
+	0x6f, 0x61, 0x74, 0x52, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x31, 0x12, 0x37, 0x0a, 0x08,
+	0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x30, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x08, 0x72, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x30, 0x12, 0x37, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x31, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65,
+	0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46,
+	0x6c, 0x6f, 0x61, 0x74, 0x52, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x31, 0x12, 0x3a,
+	0x0a, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52,
+	0x09, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x73, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x30, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x30, 0x12, 0x36, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x31, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x31, 0x22, 0xf7, 0x05, 0x0a, 0x04, 0x53,
+	0x77, 0x61, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x6f, 0x67, 0x5f, 0x69,
+	0x64, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x78,
+	0x12, 0x20, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x78, 0x5f, 0x73, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x78, 0x53, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x61, 0x69, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x61, 0x69, 0x72, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x30, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x30, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x31, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x31, 0x12, 0x35, 0x0a, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x30, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f,
+	0x61, 0x74, 0x52, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x30, 0x12, 0x35, 0x0a, 0x07, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x31, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x31, 0x12, 0x37, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x30, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61,
+	0x74, 0x52, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x30, 0x12, 0x37, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x31, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x08, 0x72, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x31, 0x12, 0x3a, 0x0a, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x75,
+	0x73, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67,
+	0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x09, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x73, 0x64,
+	0x12, 0x36, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x30, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x30, 0x12, 0x36, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x31, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x31,
+	0x12, 0x42, 0x0a, 0x0b, 0x75, 0x6e, 0x69, 0x76, 0x33, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x56, 0x33, 0x53,
+	0x77, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x75, 0x6e, 0x69, 0x76, 0x33, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x22, 0xda, 0x01, 0x0a, 0x0e, 0x55, 0x6e, 0x69, 0x56, 0x33, 0x53, 0x77,
+	0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3f, 0x0a, 0x0e, 0x73, 0x71, 0x72, 0x74, 0x5f,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x78, 0x39, 0x36, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x49, 0x6e, 0x74, 0x52, 0x0c, 0x73, 0x71, 0x72, 0x74,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x58, 0x39, 0x36, 0x12, 0x37, 0x0a, 0x09, 0x6c, 0x69, 0x71, 0x75,
+	0x69, 0x64, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x69, 0x67, 0x49, 0x6e, 0x74, 0x52, 0x09, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x12, 0x52,
+	0x04, 0x74, 0x69, 0x63, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c,
+	0x73, 0x30, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61,
+	0x6c, 0x73, 0x30, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x31,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73,
+	0x31, 0x22, 0x99, 0x01, 0x0a, 0x08, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x61, 0x6e, 0x63,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x41, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x6f,
+	0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0e, 0x6f,
+	0x72, 0x70, 0x68, 0x61, 0x6e, 0x65, 0x64, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x92, 0x02,
+	0x0a, 0x0c, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x6f, 0x67, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x73, 0x63, 0x6f,
+	0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x69, 0x63, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x22, 0x6f, 0x0a, 0x0c, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6a, 0x73, 0x6f, 0x6e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0xd3, 0x01, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x48, 0x6f,
+	0x70, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x40, 0x0a, 0x0d, 0x6c, 0x69, 0x71, 0x75, 0x69,
+	0x64, 0x69, 0x74, 0x79, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x0c, 0x6c, 0x69, 0x71,
+	0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x55, 0x73, 0x64, 0x22, 0xb5, 0x03, 0x0a, 0x08, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x48, 0x6f, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x03, 0x64, 0x65, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x78, 0x48, 0x6f, 0x70,
+	0x48, 0x00, 0x52, 0x03, 0x64, 0x65, 0x78, 0x12, 0x3f, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x65, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x68, 0x61, 0x69, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x48, 0x6f, 0x70, 0x48, 0x00, 0x52, 0x09, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x48, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x65, 0x72, 0x70, 0x61, 0x72, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x61, 0x72, 0x74, 0x79, 0x48,
+	0x6f, 0x70, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x61, 0x72,
+	0x74, 0x79, 0x12, 0x4f, 0x0a, 0x0f, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x6f,
+	0x72, 0x61, 0x63, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x65, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x48, 0x6f,
+	0x70, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4f, 0x72, 0x61,
+	0x63, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x03, 0x63, 0x65, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x45, 0x58, 0x48, 0x6f, 0x70, 0x48, 0x00, 0x52, 0x03, 0x63,
+	0x65, 0x78, 0x12, 0x46, 0x0a, 0x0c, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x63,
+	0x65, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x45, 0x58, 0x48, 0x6f, 0x70, 0x48, 0x00, 0x52, 0x0b, 0x72,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x65, 0x78, 0x42, 0x05, 0x0a, 0x03, 0x68, 0x6f,
+	0x70, 0x22, 0xd2, 0x01, 0x0a, 0x06, 0x44, 0x65, 0x78, 0x48, 0x6f, 0x70, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x30, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x30, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x31, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x31,
+	0x12, 0x2f, 0x0a, 0x04, 0x72, 0x65, 0x73, 0x30, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x04, 0x72, 0x65, 0x73,
+	0x30, 0x12, 0x2f, 0x0a, 0x04, 0x72, 0x65, 0x73, 0x31, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52, 0x04, 0x72, 0x65,
+	0x73, 0x31, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x63, 0x65, 0x6e,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x64, 0x22, 0xb7, 0x01, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x6c, 0x69, 0x6e, 0x6b, 0x48, 0x6f, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x61, 0x63, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x66,
+	0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x02, 0x74, 0x6f, 0x12, 0x31, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x49, 0x6e, 0x74, 0x52, 0x06,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x69, 0x67, 0x49, 0x6e, 0x74, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x22, 0x44, 0x0a, 0x0f, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x70, 0x61, 0x72, 0x74, 0x79,
+	0x48, 0x6f, 0x70, 0x12, 0x31, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0x43, 0x0a, 0x11, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x48, 0x6f, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x72, 0x61, 0x63, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6f, 0x72, 0x61,
+	0x63, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x7d, 0x0a, 0x06, 0x43,
+	0x45, 0x58, 0x48, 0x6f, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x12, 0x31, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x40, 0x0a, 0x0e, 0x52, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x45, 0x58, 0x48, 0x6f, 0x70, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xd7, 0x01, 0x0a,
+	0x0d, 0x55, 0x6e, 0x69, 0x56, 0x32, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x70, 0x61, 0x69, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x30, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x30, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x31, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x31, 0x12, 0x2f, 0x0a, 0x04, 0x72, 0x65, 0x73, 0x30, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x52,
+	0x04, 0x72, 0x65, 0x73, 0x30, 0x12, 0x2f, 0x0a, 0x04, 0x72, 0x65, 0x73, 0x31, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x46, 0x6c, 0x6f, 0x61, 0x74,
+	0x52, 0x04, 0x72, 0x65, 0x73, 0x31, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x75, 0x70, 0x72, 0x61, 0x67, 0x79, 0x61, 0x2f, 0x45, 0x74,
+	0x68, 0x65, 0x72, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x2f, 0x6c, 0x69, 0x62, 0x73, 0x2f, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_payload_proto_rawDescOnce sync.Once
+	file_payload_proto_rawDescData = file_payload_proto_rawDesc
+)
+
+func file_payload_proto_rawDescGZIP() []byte {
+	file_payload_proto_rawDescOnce.Do(func() {
+		file_payload_proto_rawDescData = protoimpl.X.CompressGZIP(file_payload_proto_rawDescData)
+	})
+	return file_payload_proto_rawDescData
+}
+
+var file_payload_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_payload_proto_goTypes = []interface{}{
+	(*BigFloat)(nil),          // 0: escope.payload.v1.BigFloat
+	(*BigInt)(nil),            // 1: escope.payload.v1.BigInt
+	(*Payload)(nil),           // 2: escope.payload.v1.Payload
+	(*BlockSynopsis)(nil),     // 3: escope.payload.v1.BlockSynopsis
+	(*Item)(nil),              // 4: escope.payload.v1.Item
+	(*Transfer)(nil),          // 5: escope.payload.v1.Transfer
+	(*Mint)(nil),              // 6: escope.payload.v1.Mint
+	(*Burn)(nil),              // 7: escope.payload.v1.Burn
+	(*Swap)(nil),              // 8: escope.payload.v1.Swap
+	(*UniV3SwapState)(nil),    // 9: escope.payload.v1.UniV3SwapState
+	(*Rollback)(nil),          // 10: escope.payload.v1.Rollback
+	(*GenericEvent)(nil),      // 11: escope.payload.v1.GenericEvent
+	(*GenericField)(nil),      // 12: escope.payload.v1.GenericField
+	(*PriceResult)(nil),       // 13: escope.payload.v1.PriceResult
+	(*PriceHop)(nil),          // 14: escope.payload.v1.PriceHop
+	(*DexHop)(nil),            // 15: escope.payload.v1.DexHop
+	(*ChainlinkHop)(nil),      // 16: escope.payload.v1.ChainlinkHop
+	(*CounterpartyHop)(nil),   // 17: escope.payload.v1.CounterpartyHop
+	(*RejectedOracleHop)(nil), // 18: escope.payload.v1.RejectedOracleHop
+	(*CEXHop)(nil),            // 19: escope.payload.v1.CEXHop
+	(*RejectedCEXHop)(nil),    // 20: escope.payload.v1.RejectedCEXHop
+	(*UniV2Metadata)(nil),     // 21: escope.payload.v1.UniV2Metadata
+	nil,                       // 22: escope.payload.v1.BlockSynopsis.EventsUserDistributionEntry
+}
+var file_payload_proto_depIdxs = []int32{
+	3,  // 0: escope.payload.v1.Payload.block_synopsis:type_name -> escope.payload.v1.BlockSynopsis
+	21, // 1: escope.payload.v1.Payload.new_dexes:type_name -> escope.payload.v1.UniV2Metadata
+	4,  // 2: escope.payload.v1.Payload.items:type_name -> escope.payload.v1.Item
+	22, // 3: escope.payload.v1.BlockSynopsis.events_user_distribution:type_name -> escope.payload.v1.BlockSynopsis.EventsUserDistributionEntry
+	6,  // 4: escope.payload.v1.Item.mint:type_name -> escope.payload.v1.Mint
+	7,  // 5: escope.payload.v1.Item.burn:type_name -> escope.payload.v1.Burn
+	8,  // 6: escope.payload.v1.Item.swap:type_name -> escope.payload.v1.Swap
+	5,  // 7: escope.payload.v1.Item.transfer:type_name -> escope.payload.v1.Transfer
+	10, // 8: escope.payload.v1.Item.rollback:type_name -> escope.payload.v1.Rollback
+	11, // 9: escope.payload.v1.Item.generic_event:type_name -> escope.payload.v1.GenericEvent
+	0,  // 10: escope.payload.v1.Transfer.amount:type_name -> escope.payload.v1.BigFloat
+	0,  // 11: escope.payload.v1.Transfer.amount_usd:type_name -> escope.payload.v1.BigFloat
+	13, // 12: escope.payload.v1.Transfer.price_derivation_meta:type_name -> escope.payload.v1.PriceResult
+	0,  // 13: escope.payload.v1.Mint.amount0:type_name -> escope.payload.v1.BigFloat
+	0,  // 14: escope.payload.v1.Mint.amount1:type_name -> escope.payload.v1.BigFloat
+	0,  // 15: escope.payload.v1.Mint.reserve0:type_name -> escope.payload.v1.BigFloat
+	0,  // 16: escope.payload.v1.Mint.reserve1:type_name -> escope.payload.v1.BigFloat
+	0,  // 17: escope.payload.v1.Mint.amount_usd:type_name -> escope.payload.v1.BigFloat
+	13, // 18: escope.payload.v1.Mint.price0:type_name -> escope.payload.v1.PriceResult
+	13, // 19: escope.payload.v1.Mint.price1:type_name -> escope.payload.v1.PriceResult
+	0,  // 20: escope.payload.v1.Burn.amount0:type_name -> escope.payload.v1.BigFloat
+	0,  // 21: escope.payload.v1.Burn.amount1:type_name -> escope.payload.v1.BigFloat
+	0,  // 22: escope.payload.v1.Burn.reserve0:type_name -> escope.payload.v1.BigFloat
+	0,  // 23: escope.payload.v1.Burn.reserve1:type_name -> escope.payload.v1.BigFloat
+	0,  // 24: escope.payload.v1.Burn.amount_usd:type_name -> escope.payload.v1.BigFloat
+	13, // 25: escope.payload.v1.Burn.price0:type_name -> escope.payload.v1.PriceResult
+	13, // 26: escope.payload.v1.Burn.price1:type_name -> escope.payload.v1.PriceResult
+	0,  // 27: escope.payload.v1.Swap.amount0:type_name -> escope.payload.v1.BigFloat
+	0,  // 28: escope.payload.v1.Swap.amount1:type_name -> escope.payload.v1.BigFloat
+	0,  // 29: escope.payload.v1.Swap.reserve0:type_name -> escope.payload.v1.BigFloat
+	0,  // 30: escope.payload.v1.Swap.reserve1:type_name -> escope.payload.v1.BigFloat
+	0,  // 31: escope.payload.v1.Swap.amount_usd:type_name -> escope.payload.v1.BigFloat
+	13, // 32: escope.payload.v1.Swap.price0:type_name -> escope.payload.v1.PriceResult
+	13, // 33: escope.payload.v1.Swap.price1:type_name -> escope.payload.v1.PriceResult
+	9,  // 34: escope.payload.v1.Swap.univ3_state:type_name -> escope.payload.v1.UniV3SwapState
+	1,  // 35: escope.payload.v1.UniV3SwapState.sqrt_price_x96:type_name -> escope.payload.v1.BigInt
+	1,  // 36: escope.payload.v1.UniV3SwapState.liquidity:type_name -> escope.payload.v1.BigInt
+	12, // 37: escope.payload.v1.GenericEvent.fields:type_name -> escope.payload.v1.GenericField
+	0,  // 38: escope.payload.v1.PriceResult.price:type_name -> escope.payload.v1.BigFloat
+	14, // 39: escope.payload.v1.PriceResult.path:type_name -> escope.payload.v1.PriceHop
+	0,  // 40: escope.payload.v1.PriceResult.liquidity_usd:type_name -> escope.payload.v1.BigFloat
+	15, // 41: escope.payload.v1.PriceHop.dex:type_name -> escope.payload.v1.DexHop
+	16, // 42: escope.payload.v1.PriceHop.chainlink:type_name -> escope.payload.v1.ChainlinkHop
+	17, // 43: escope.payload.v1.PriceHop.counterparty:type_name -> escope.payload.v1.CounterpartyHop
+	18, // 44: escope.payload.v1.PriceHop.rejected_oracle:type_name -> escope.payload.v1.RejectedOracleHop
+	19, // 45: escope.payload.v1.PriceHop.cex:type_name -> escope.payload.v1.CEXHop
+	20, // 46: escope.payload.v1.PriceHop.rejected_cex:type_name -> escope.payload.v1.RejectedCEXHop
+	0,  // 47: escope.payload.v1.DexHop.res0:type_name -> escope.payload.v1.BigFloat
+	0,  // 48: escope.payload.v1.DexHop.res1:type_name -> escope.payload.v1.BigFloat
+	1,  // 49: escope.payload.v1.ChainlinkHop.answer:type_name -> escope.payload.v1.BigInt
+	1,  // 50: escope.payload.v1.ChainlinkHop.updated_at:type_name -> escope.payload.v1.BigInt
+	0,  // 51: escope.payload.v1.CounterpartyHop.price:type_name -> escope.payload.v1.BigFloat
+	0,  // 52: escope.payload.v1.CEXHop.price:type_name -> escope.payload.v1.BigFloat
+	0,  // 53: escope.payload.v1.UniV2Metadata.res0:type_name -> escope.payload.v1.BigFloat
+	0,  // 54: escope.payload.v1.UniV2Metadata.res1:type_name -> escope.payload.v1.BigFloat
+	55, // [55:55] is the sub-list for method output_type
+	55, // [55:55] is the sub-list for method input_type
+	55, // [55:55] is the sub-list for extension type_name
+	55, // [55:55] is the sub-list for extension extendee
+	0,  // [0:55] is the sub-list for field type_name
+}
+
+func init() { file_payload_proto_init() }
+func file_payload_proto_init() {
+	if File_payload_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_payload_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BigFloat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BigInt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Payload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockSynopsis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Item); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transfer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Mint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Burn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Swap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UniV3SwapState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rollback); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenericEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenericField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceHop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DexHop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChainlinkHop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CounterpartyHop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectedOracleHop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CEXHop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectedCEXHop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UniV2Metadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_payload_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*Item_Mint)(nil),
+		(*Item_Burn)(nil),
+		(*Item_Swap)(nil),
+		(*Item_Transfer)(nil),
+		(*Item_Rollback)(nil),
+		(*Item_GenericEvent)(nil),
+	}
+	file_payload_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*PriceHop_Dex)(nil),
+		(*PriceHop_Chainlink)(nil),
+		(*PriceHop_Counterparty)(nil),
+		(*PriceHop_RejectedOracle)(nil),
+		(*PriceHop_Cex)(nil),
+		(*PriceHop_RejectedCex)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_payload_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_payload_proto_goTypes,
+		DependencyIndexes: file_payload_proto_depIdxs,
+		MessageInfos:      file_payload_proto_msgTypes,
+	}.Build()
+	File_payload_proto = out.File
+	file_payload_proto_rawDesc = nil
+	file_payload_proto_goTypes = nil
+	file_payload_proto_depIdxs = nil
+}
@@ -1,25 +1,265 @@
 package priceresolver
 
 import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+
+	cfg "github.com/supragya/EtherScope/libs/config"
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/service"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+)
+
+var (
+	PricingCEXCFGSection   = "pricingCEX"
+	PricingCEXCFGNecessity = "needed if `node.pricingCEXType` != none"
+	PricingCEXCFGHeader    = cfg.SArr("centralized exchange prices used by pricing",
+		"engine for tokens without on-chain liquidity. prices come",
+		"from a local candle archive (`csv`) or from an http price",
+		"service (`http`)")
+	PricingCEXCFGFields = [...]cfg.Field{
+		{
+			Name:      "archiveFile",
+			Type:      "string",
+			Necessity: "needed if `node.pricingCEXType` == csv",
+			Info: cfg.SArr("csv candle archive with header symbol,time,open,high,",
+				"low,close. time is candle open time in unix seconds,",
+				"prices are in USD"),
+			Default: "cex_candles.csv",
+		},
+		{
+			Name:      "url",
+			Type:      "string",
+			Necessity: "needed if `node.pricingCEXType` == http",
+			Info: cfg.SArr("base url of price service. prices are requested as",
+				"GET <url>/price?symbol=<symbol>&time=<unix seconds>",
+				"answering {\"price\": <USD price>}"),
+			Default: "http://localhost:8080",
+		},
+		{
+			Name:      "tokens",
+			Type:      "[]string",
+			Necessity: "always needed",
+			Info: cfg.SArr("tokens to price using CEX, as <address>:<symbol>.",
+				"CEX prices are used only if a token cannot be priced",
+				"on-chain"),
+			Default: "[]",
+		},
+	}
 )
 
+// Candles older than this are not used for price at a time
+const maxCEXPriceAge = time.Hour
+
+var ErrNoCEXPrice = errors.New("NoCEXPriceError")
+
+// checkCEXPrice returns reason CEX price on edge is not to be used at
+// blockTime, nil if it can be
+func checkCEXPrice(i itypes.CEXMetadata, blockTime uint64) error {
+	if i.Price == nil || i.Price.Sign() <= 0 {
+		return fmt.Errorf("no price of %s", i.Symbol)
+	}
+	if blockTime > i.Time && time.Duration(blockTime-i.Time)*time.Second > maxCEXPriceAge {
+		return fmt.Errorf("stale price, fetched %ds before block", blockTime-i.Time)
+	}
+	return nil
+}
+
 type CEX interface {
 	Service
 	GetPrice(string, time.Time) (*big.Float, error)
 }
 
-// DefaultCEX is default form of eth-rpc access for enhanced pricing engine
-type DefaultCEX struct{}
+// CEXSymbolsWithViperFields returns CEX symbols of tokens to be priced
+// using CEX
+func CEXSymbolsWithViperFields() (map[common.Address]string, error) {
+	for _, mf := range PricingCEXCFGFields {
+		if err := cfg.EnsureFieldIntegrity(PricingCEXCFGSection, mf); err != nil {
+			return nil, err
+		}
+	}
+	return parseCEXSymbols(viper.GetStringSlice(PricingCEXCFGSection + ".tokens"))
+}
+
+func parseCEXSymbols(entries []string) (map[common.Address]string, error) {
+	symbols := make(map[common.Address]string, len(entries))
+	for _, entry := range entries {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 || !common.IsHexAddress(parts[0]) || parts[1] == "" {
+			return nil, errors.New("malformed cex token, expected <address>:<symbol>: " + entry)
+		}
+		symbols[common.HexToAddress(parts[0])] = parts[1]
+	}
+	return symbols, nil
+}
 
-func NewDefaultCEX() *DefaultCEX {
-	return &DefaultCEX{}
+type candle struct {
+	time  int64
+	close *big.Float
 }
 
-// Implements CEX
+// CSVCEX serves CEX prices from a local candle archive, read in
+// memory on start
+type CSVCEX struct {
+	service.BaseService
+
+	log     logger.Logger
+	file    string
+	candles map[string][]candle // sorted by time
+}
+
+func NewCSVCEX(log logger.Logger, file string) *CSVCEX {
+	c := &CSVCEX{log: log, file: file}
+	c.BaseService = *service.NewBaseService(log, "csvcex", c)
+	return c
+}
+
+func NewCSVCEXWithViperFields(log logger.Logger) (CEX, error) {
+	for _, mf := range PricingCEXCFGFields {
+		if err := cfg.EnsureFieldIntegrity(PricingCEXCFGSection, mf); err != nil {
+			return nil, err
+		}
+	}
+	return NewCSVCEX(log, viper.GetString(PricingCEXCFGSection+".archiveFile")), nil
+}
+
+// OnStart loads candle archive. It implements service.Service
+func (c *CSVCEX) OnStart(ctx context.Context) error {
+	f, err := os.Open(c.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return fmt.Errorf("unable to parse candle archive %s: %w", c.file, err)
+	}
+	if len(records) == 0 {
+		return errors.New("empty candle archive " + c.file)
+	}
+
+	candles := make(map[string][]candle)
+	for idx, rec := range records[1:] {
+		if len(rec) < 6 {
+			return fmt.Errorf("malformed candle at line %d of %s", idx+2, c.file)
+		}
+		openTime, err := strconv.ParseInt(rec[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("malformed candle time at line %d of %s: %w", idx+2, c.file, err)
+		}
+		closePrice, ok := new(big.Float).SetString(rec[5])
+		if !ok {
+			return fmt.Errorf("malformed candle close at line %d of %s", idx+2, c.file)
+		}
+		candles[rec[0]] = append(candles[rec[0]], candle{openTime, closePrice})
+	}
+	for _, symbolCandles := range candles {
+		sort.Slice(symbolCandles, func(i, j int) bool {
+			return symbolCandles[i].time < symbolCandles[j].time
+		})
+	}
+	c.candles = candles
+	c.log.Info("loaded candle archive", "symbols", len(candles), "candles", len(records)-1)
+	return nil
+}
+
+// OnStop stops CSVCEX. It implements service.Service
+func (c *CSVCEX) OnStop() {}
+
+// GetPrice returns close of last candle of symbol opened at or
+// before at. It implements CEX
+func (c *CSVCEX) GetPrice(symbol string, at time.Time) (*big.Float, error) {
+	symbolCandles := c.candles[symbol]
+	idx := sort.Search(len(symbolCandles), func(i int) bool {
+		return symbolCandles[i].time > at.Unix()
+	}) - 1
+	if idx < 0 || at.Sub(time.Unix(symbolCandles[idx].time, 0)) > maxCEXPriceAge {
+		return nil, fmt.Errorf("no candle of %s at %s: %w", symbol, at.UTC(), ErrNoCEXPrice)
+	}
+	return new(big.Float).Set(symbolCandles[idx].close), nil
+}
+
+// HTTPCEX requests CEX prices from a price service over http
+type HTTPCEX struct {
+	service.BaseService
+
+	log    logger.Logger
+	url    string
+	client *http.Client
+}
+
+func NewHTTPCEX(log logger.Logger, baseURL string) *HTTPCEX {
+	c := &HTTPCEX{
+		log:    log,
+		url:    strings.TrimSuffix(baseURL, "/"),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	c.BaseService = *service.NewBaseService(log, "httpcex", c)
+	return c
+}
+
+func NewHTTPCEXWithViperFields(log logger.Logger) (CEX, error) {
+	for _, mf := range PricingCEXCFGFields {
+		if err := cfg.EnsureFieldIntegrity(PricingCEXCFGSection, mf); err != nil {
+			return nil, err
+		}
+	}
+	return NewHTTPCEX(log, viper.GetString(PricingCEXCFGSection+".url")), nil
+}
+
+// OnStart starts HTTPCEX. It implements service.Service
+func (c *HTTPCEX) OnStart(ctx context.Context) error {
+	return nil
+}
+
+// OnStop stops HTTPCEX. It implements service.Service
+func (c *HTTPCEX) OnStop() {
+	c.client.CloseIdleConnections()
+}
+
+// GetPrice requests price of symbol at time at. Unknown symbols or
+// times are answered with 404 by price service. It implements CEX
+func (c *HTTPCEX) GetPrice(symbol string, at time.Time) (*big.Float, error) {
+	query := url.Values{}
+	query.Set("symbol", symbol)
+	query.Set("time", strconv.FormatInt(at.Unix(), 10))
+
+	resp, err := c.client.Get(c.url + "/price?" + query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("no price of %s at %s: %w", symbol, at.UTC(), ErrNoCEXPrice)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("price service answered %s for %s", resp.Status, symbol)
+	}
 
-// To be implemented
-func (e *DefaultCEX) GetPrice(string, time.Time) (*big.Float, error) {
-	panic("unimplemented")
+	var body struct {
+		Price json.Number `json:"price"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("malformed price of %s: %w", symbol, err)
+	}
+	price, ok := new(big.Float).SetString(body.Price.String())
+	if !ok {
+		return nil, fmt.Errorf("malformed price of %s: %q", symbol, body.Price)
+	}
+	return price, nil
 }
@@ -0,0 +1,182 @@
+package priceresolver
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/supragya/EtherScope/libs/gograph"
+	logger "github.com/supragya/EtherScope/libs/log"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestCSVCEX(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := filepath.Join(t.TempDir(), "candles.csv")
+	if err := os.WriteFile(archive, []byte("symbol,time,open,high,low,close\n"+
+		"ETHUSD,7200,1,1,1,1600.5\n"+
+		"ETHUSD,3600,1,1,1,1500\n"+
+		"BTCUSD,3600,1,1,1,20000\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cex := NewCSVCEX(log, archive)
+	if err := cex.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		symbol string
+		at     int64
+		price  float64
+	}{
+		{"ETHUSD", 3600, 1500},
+		{"ETHUSD", 7199, 1500},
+		{"ETHUSD", 8000, 1600.5},
+		{"BTCUSD", 4000, 20000},
+	} {
+		price, err := cex.GetPrice(tc.symbol, time.Unix(tc.at, 0))
+		if err != nil {
+			t.Fatalf("%s at %d: %s", tc.symbol, tc.at, err)
+		}
+		if p, _ := price.Float64(); p != tc.price {
+			t.Errorf("%s at %d: expected %v, got %v", tc.symbol, tc.at, tc.price, p)
+		}
+	}
+	for _, tc := range []struct {
+		symbol string
+		at     int64
+	}{
+		{"ETHUSD", 3599},  // before archive
+		{"ETHUSD", 11000}, // candle too old
+		{"DOGEUSD", 3600},
+	} {
+		if _, err := cex.GetPrice(tc.symbol, time.Unix(tc.at, 0)); !errors.Is(err, ErrNoCEXPrice) {
+			t.Errorf("%s at %d: expected ErrNoCEXPrice, got %v", tc.symbol, tc.at, err)
+		}
+	}
+}
+
+func TestHTTPCEX(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path != "/price":
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Query().Get("symbol") == "ETHUSD" && r.URL.Query().Get("time") == "3600":
+			w.Write([]byte(`{"price": "1500.25"}`))
+		case r.URL.Query().Get("symbol") == "BTCUSD":
+			w.Write([]byte(`{"price": 20000}`))
+		case r.URL.Query().Get("symbol") == "BADUSD":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cex := NewHTTPCEX(log, server.URL+"/")
+	if err := cex.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	price, err := cex.GetPrice("ETHUSD", time.Unix(3600, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := price.Float64(); p != 1500.25 {
+		t.Errorf("expected 1500.25, got %v", p)
+	}
+	price, err = cex.GetPrice("BTCUSD", time.Unix(3600, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := price.Float64(); p != 20000 {
+		t.Errorf("expected 20000, got %v", p)
+	}
+	if _, err := cex.GetPrice("ETHUSD", time.Unix(7200, 0)); !errors.Is(err, ErrNoCEXPrice) {
+		t.Errorf("expected ErrNoCEXPrice, got %v", err)
+	}
+	if _, err := cex.GetPrice("BADUSD", time.Unix(3600, 0)); err == nil || errors.Is(err, ErrNoCEXPrice) {
+		t.Errorf("expected service error, got %v", err)
+	}
+}
+
+type fixedCEX struct {
+	CEX
+	prices map[string]*big.Float
+}
+
+func (c fixedCEX) GetPrice(symbol string, at time.Time) (*big.Float, error) {
+	if price, ok := c.prices[symbol]; ok {
+		return price, nil
+	}
+	return nil, ErrNoCEXPrice
+}
+
+func TestCEXFallback(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listed := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	pooled := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	stable := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+
+	engine := &Engine{log: log, EthRPC: oracleRPC{blockTime: 10000}}
+	engine.UseCEX(fixedCEX{prices: map[string]*big.Float{
+		"LSTUSD": big.NewFloat(7),
+		"POOUSD": big.NewFloat(9),
+	}}, map[common.Address]string{listed: "LSTUSD", pooled: "POOUSD"})
+
+	// pooled is priced at 2 USD on-chain through stable
+	graph := gograph.NewGraph[common.Address, int64, string, interface{}](true)
+	graph.AddWeightedEdge(pooled, stable, 1, "dex", itypes.UniV2Metadata{
+		Token0: pooled,
+		Token1: stable,
+		Res0:   big.NewFloat(1e6),
+		Res1:   big.NewFloat(2e6),
+	})
+	graph.AddWeightedEdge(stable, USDTokenID, 1, "dex", itypes.UniV2Metadata{
+		Token0: stable,
+		Token1: USDTokenID,
+		Res0:   big.NewFloat(1e7),
+		Res1:   big.NewFloat(1e7),
+	})
+	engine.updateGraph(graph, map[addrTuple]itypes.UniV2Metadata{}, map[addrTuple]itypes.UniV3Metadata{}, 100)
+
+	for _, tc := range []struct {
+		token common.Address
+		price float64
+	}{
+		{listed, 7},
+		{pooled, 2},
+	} {
+		var result *itypes.PriceResult
+		if !engine.tryPricingUSD(tc.token, &result, graph, map[common.Address]*itypes.PriceResult{}, 100) {
+			t.Fatalf("expected %s to be priced", tc.token)
+		}
+		if price, _ := result.Price.Float64(); price != tc.price {
+			t.Errorf("%s: expected price %v, got %v", tc.token, tc.price, price)
+		}
+	}
+
+	// CEX price fetched too long before block is not used
+	engine.EthRPC = oracleRPC{blockTime: 20000}
+	engine.timeHeight, engine.blockTime = 0, 0
+	var result *itypes.PriceResult
+	if engine.tryPricingUSD(listed, &result, graph, map[common.Address]*itypes.PriceResult{}, 101) {
+		t.Errorf("expected stale CEX price not to be used, got %v", result.Price)
+	}
+}
@@ -2,11 +2,14 @@ package priceresolver
 
 import (
 	"encoding/csv"
+	"fmt"
 	"log"
 	"os"
 	"sort"
 	"strconv"
 
+	"github.com/supragya/EtherScope/libs/networks"
+	itypes "github.com/supragya/EtherScope/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -35,12 +38,50 @@ type ChainlinkRecord struct {
 	To         common.Address
 	Oracle     common.Address
 	StartBlock int64
+	Heartbeat  uint64
 }
 
 var (
+	// Sentinel token standing for USD in pricing graph, independent of
+	// network
 	USDTokenID = common.HexToAddress("0xffffffffffffffffffffffffffffffffffffffff")
 )
 
+// Max age in seconds of a chainlink round for feeds without heartbeat
+// in dump. Chainlink feeds update at least once a day
+const defaultChainlinkHeartbeat = 86400
+
+// checkRound returns reason round of a chainlink feed is not to be
+// trusted at blockTime, nil if it can be
+func checkRound(data itypes.ChainlinkLatestRoundData, heartbeat uint64, blockTime uint64) error {
+	if heartbeat == 0 {
+		heartbeat = defaultChainlinkHeartbeat
+	}
+	if data.Answer == nil || data.Answer.Sign() <= 0 {
+		return fmt.Errorf("non positive answer %v", data.Answer)
+	}
+	if data.UpdatedAt == nil || data.UpdatedAt.Sign() == 0 {
+		return fmt.Errorf("round %v not complete", data.RoundId)
+	}
+	if data.RoundId == nil || data.AnsweredInRound == nil || data.AnsweredInRound.Cmp(data.RoundId) == -1 {
+		return fmt.Errorf("answer of round %v carried over from round %v", data.RoundId, data.AnsweredInRound)
+	}
+	updatedAt := data.UpdatedAt.Uint64()
+	if blockTime > updatedAt && blockTime-updatedAt > heartbeat {
+		return fmt.Errorf("stale round, updated %ds before block with heartbeat %ds", blockTime-updatedAt, heartbeat)
+	}
+	return nil
+}
+
+// oracleRejection notes rejection of feed on edge for price path
+func oracleRejection(i itypes.WrappedCLMetadata, reason error) itypes.RejectedOracleMetadata {
+	return itypes.RejectedOracleMetadata{
+		Description: fmt.Sprintf("Chainlink oracle %v rejected", i.Oracle),
+		Oracle:      i.Oracle,
+		Reason:      reason.Error(),
+	}
+}
+
 func loadChainlinkCSV(filePath string) (ChainlinkRecords, error) {
 	recs, err := loadChainlinkCSVi(filePath)
 	if err != nil {
@@ -70,14 +111,25 @@ func loadChainlinkCSVi(filePath string) (ChainlinkRecords, error) {
 		if err != nil {
 			panic(err)
 		}
-		if rec[1] == "USD" {
-			rec[1] = "0xffffffffffffffffffffffffffffffffffffffff"
+		// USD may be given as is or as chainlink feed registry
+		// denomination (as in oraclenode feed files)
+		if rec[1] == "USD" || common.HexToAddress(rec[1]) == networks.ChainlinkUSDDenomination {
+			rec[1] = USDTokenID.Hex()
+		}
+		// Heartbeat of feed in seconds is optional
+		var heartbeat uint64
+		if len(rec) > 4 && rec[4] != "" {
+			heartbeat, err = strconv.ParseUint(rec[4], 10, 64)
+			if err != nil {
+				panic(err)
+			}
 		}
 		ChainlinkRecord := ChainlinkRecord{
 			From:       common.HexToAddress(rec[0]),
 			To:         common.HexToAddress(rec[1]),
 			Oracle:     common.HexToAddress(rec[2]),
 			StartBlock: int64(startBlock),
+			Heartbeat:  heartbeat,
 		}
 		resChainlinkRecords = append(resChainlinkRecords, ChainlinkRecord)
 	}
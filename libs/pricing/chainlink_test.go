@@ -0,0 +1,113 @@
+package priceresolver
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/supragya/EtherScope/libs/gograph"
+	logger "github.com/supragya/EtherScope/libs/log"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func round(id, answer, updatedAt, answeredIn int64) itypes.ChainlinkLatestRoundData {
+	return itypes.ChainlinkLatestRoundData{
+		RoundId:         big.NewInt(id),
+		Answer:          big.NewInt(answer),
+		StartedAt:       big.NewInt(updatedAt),
+		UpdatedAt:       big.NewInt(updatedAt),
+		AnsweredInRound: big.NewInt(answeredIn),
+	}
+}
+
+func TestCheckRound(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		data      itypes.ChainlinkLatestRoundData
+		heartbeat uint64
+		ok        bool
+	}{
+		{"fresh", round(5, 100, 9000, 5), 3600, true},
+		{"stale", round(5, 100, 5000, 5), 3600, false},
+		{"default heartbeat", round(5, 100, 5000, 5), 0, true},
+		{"carried over", round(5, 100, 9000, 4), 3600, false},
+		{"incomplete", round(5, 100, 0, 5), 3600, false},
+		{"non positive", round(5, 0, 9000, 5), 3600, false},
+	} {
+		err := checkRound(tc.data, tc.heartbeat, 10000)
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: expected ok %v, got %v", tc.name, tc.ok, err)
+		}
+	}
+}
+
+type oracleRPC struct {
+	namesRPC
+	blockTime uint64
+}
+
+func (r oracleRPC) GetBlockTimestamp(uint64) (uint64, error) {
+	return r.blockTime, nil
+}
+
+func (oracleRPC) GetERC20Decimals(common.Address, *bind.CallOpts) (uint8, error) {
+	return 8, nil
+}
+
+func TestTryPricingUSDRejectsOracles(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	stable := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	oracle := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+
+	for _, tc := range []struct {
+		name      string
+		answer    int64 // oracle price with 8 decimals, dex price is 2
+		updatedAt int64 // block time is 10000
+		price     float64
+		reason    bool
+	}{
+		{"trusted oracle", 210000000, 9000, 2.1, false},
+		{"stale oracle", 210000000, 1000, 2, true},
+		{"deviating oracle", 300000000, 9000, 2, true},
+	} {
+		data := round(5, tc.answer, tc.updatedAt, 5)
+		graph := gograph.NewGraph[common.Address, int64, string, interface{}](true)
+		graph.AddWeightedEdge(token, stable, 1, "dex", itypes.UniV2Metadata{
+			Token0: token,
+			Token1: stable,
+			Res0:   big.NewFloat(1e6),
+			Res1:   big.NewFloat(2e6),
+		})
+		graph.AddWeightedEdge(stable, USDTokenID, 1, "dex", itypes.UniV2Metadata{
+			Token0: stable,
+			Token1: USDTokenID,
+			Res0:   big.NewFloat(1e7),
+			Res1:   big.NewFloat(1e7),
+		})
+		graph.AddWeightedEdge(token, USDTokenID, 1, "chainlink", itypes.WrappedCLMetadata{
+			Data:      data,
+			Oracle:    oracle,
+			From:      token,
+			To:        USDTokenID,
+			Heartbeat: 3600,
+		})
+
+		engine := &Engine{log: log, EthRPC: oracleRPC{blockTime: 10000}}
+		var result *itypes.PriceResult
+		if !engine.tryPricingUSD(token, &result, graph, map[common.Address]*itypes.PriceResult{}, 100) {
+			t.Fatalf("%s: expected token to be priced", tc.name)
+		}
+		if price, _ := result.Price.Float64(); price != tc.price {
+			t.Errorf("%s: expected price %v, got %v", tc.name, tc.price, price)
+		}
+		rejected, ok := result.Path[len(result.Path)-1].(itypes.RejectedOracleMetadata)
+		if ok != tc.reason || (ok && (rejected.Oracle != oracle || rejected.Reason == "")) {
+			t.Errorf("%s: expected rejection noted %v, got path %v", tc.name, tc.reason, result.Path)
+		}
+	}
+}
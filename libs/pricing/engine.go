@@ -35,19 +35,30 @@ type Engine struct {
 	chainlinkOraclesDumpFile string
 	dexDumpFile              string
 
+	// Routes having a dex hop thinner than this are not used for pricing
+	minLiquidityUSD *big.Float
+
+	// CEX prices of tokens by symbol, used if not priceable on-chain
+	cex        CEX
+	cexSymbols map[common.Address]string
+
+	// Graph versions older than these many blocks behind latest are
+	// pruned, 0 keeps all
+	retention uint64
+
 	// Internal Data Structures
 	lastGraph  *gg
 	lastHeight uint64
+	snapshots  []snapshotRef
+	timeHeight uint64 // height blockTime is of
+	blockTime  uint64
 }
 
-var (
-	ErrorRequestedResolutionPresent = errors.New("RequestedResolutionPresentError")
-)
-
 // DefaultEngine is default form of enhanced pricing engine
 func NewDefaultEngine(log logger.Logger,
 	chainlinkOracledDumpFile string,
 	dexDumpFile string,
+	minLiquidityUSD uint64,
 	ethrpcBackend ethrpc.EthRPC,
 	localBackend lb.LocalBackend) *Engine {
 	return &Engine{
@@ -56,84 +67,116 @@ func NewDefaultEngine(log logger.Logger,
 		LocalBackend:             localBackend,
 		chainlinkOraclesDumpFile: chainlinkOracledDumpFile,
 		dexDumpFile:              dexDumpFile,
+		minLiquidityUSD:          new(big.Float).SetUint64(minLiquidityUSD),
 		lastGraph:                nil,
 		lastHeight:               0,
+		snapshots:                nil,
 	}
 }
 
-func (n *Engine) FetchLatestBlockHeightOrConstructTill(resHeight uint64) uint64 {
-	// fetch the graph in question
-PRICING_GRAPH_FETCH:
-	lbLatestHeight, ok, err := n.LocalBackend.Get(lb.KeyLatestHeight)
-	if err != nil {
-		n.log.Fatal(err.Error())
+// UseCEX lets engine price tokens in symbols using cex, for tokens
+// without on-chain liquidity
+func (n *Engine) UseCEX(cex CEX, symbols map[common.Address]string) {
+	n.cex = cex
+	n.cexSymbols = symbols
+}
+
+// RetainSnapshots makes engine prune graph versions not needed to
+// price blocks within last `blocks` blocks of latest stored version
+func (n *Engine) RetainSnapshots(blocks uint64) {
+	n.retention = blocks
+}
+
+// ensureSnapshots loads stored graph versions, constructing graph
+// for resHeight from dumps if localbackend has none
+func (n *Engine) ensureSnapshots(resHeight uint64) error {
+	if err := n.loadSnapshotIndex(); err != nil {
+		return err
 	}
-	if !ok {
-		// This means localbackend does not know any graph
-		// just yet. Go through dumps
-		n.log.Warn("no pricingGraph found in localbackend. reading dump files and syncing")
-		err := n.syncDump(resHeight)
-		if err != nil {
-			n.log.Fatal(err.Error())
-		}
-		goto PRICING_GRAPH_FETCH
+	if len(n.snapshots) > 0 {
+		return nil
 	}
+	// This means localbackend does not know any graph
+	// just yet. Go through dumps
+	n.log.Warn("no pricingGraph found in localbackend. reading dump files and syncing")
+	return n.syncDump(resHeight)
+}
 
-	// ensure lbLatestHeight is less than resHeight
-	var lbLatestHeightUint64 uint64
-	if err := util.GobDecode(lbLatestHeight, &lbLatestHeightUint64); err != nil {
-		n.log.Fatal(fmt.Sprintf("wrong latest height encoding: %s", err))
+// LowestHeight returns lowest height pricing graph is available for
+func (n *Engine) LowestHeight() (uint64, bool, error) {
+	if err := n.loadSnapshotIndex(); err != nil {
+		return 0, false, err
+	}
+	if len(n.snapshots) == 0 {
+		return 0, false, nil
 	}
-	return lbLatestHeightUint64
+	return n.snapshots[0].height, true, nil
 }
 
 func (n *Engine) GetLatestGraph() (*gg, error) {
-	graph := gograph.NewGraph[common.Address, int64, string, interface{}](true)
-	lbLatestGraph, ok, err := n.LocalBackend.Get(lb.KeyLatestPricingGraph)
+	latest, ok, err := n.getHeight(lb.KeyLatestHeight)
 	if err != nil {
 		return nil, err
 	}
 	if !ok {
 		return nil, errors.New("cannot get latest pricing graph")
 	}
-	if err := util.GobDecode(lbLatestGraph, graph); err != nil {
-		return nil, err
-	}
-	return graph, nil
+	return n.graphAt(latest)
 }
 
+// Resolve prices items of block at resHeight. Blocks may be resolved
+// in any order as long as resHeight is at or after lowest snapshot,
+// graph as of resHeight is stored for resolutions of later blocks
 func (n *Engine) Resolve(resHeight uint64, items []interface{}) ([]itypes.UniV2Metadata, error) {
-	// Ensure entries exist and we are resolving
-	// for heights >= height in localbackend
-	fetchedHeight := n.FetchLatestBlockHeightOrConstructTill(resHeight)
-	if resHeight < fetchedHeight {
-		return []itypes.UniV2Metadata{}, fmt.Errorf("requested resolution for %d while lb already has %d: %w",
-			resHeight,
-			fetchedHeight,
-			ErrorRequestedResolutionPresent)
-	}
-
-	// Get the graph from localbackend
-	graph, err := n.GetLatestGraph()
+	if err := n.ensureSnapshots(resHeight); err != nil {
+		return []itypes.UniV2Metadata{}, err
+	}
+
+	// Graph as of block before resHeight, unless resHeight is the
+	// lowest snapshot (graph constructed from dumps at resHeight)
+	baseHeight := resHeight
+	if resHeight > 0 && resHeight != n.snapshots[0].height {
+		baseHeight = resHeight - 1
+	}
+	graph, err := n.graphAt(baseHeight)
 	if err != nil {
 		return []itypes.UniV2Metadata{}, err
 	}
+	base := gograph.CopyGraph(graph)
 
 	// Get updates to be applied to graph
 	resUpdates := n.getReserveUpdates(items)
+	v3Updates := n.getUniV3Updates(items)
 
 	// update graph
-	newDexes := n.updateGraph(graph, resUpdates, resHeight)
+	newDexes := n.updateGraph(graph, resUpdates, v3Updates, resHeight)
 
 	// Resolve items: best attempt
 	n.resolveItems(graph, items, resHeight)
 
 	// Update localbackend
-	n.syncGraphToLB(graph, resHeight)
+	if err := n.storeSnapshot(graph, base, resHeight); err != nil {
+		return newDexes, err
+	}
 
 	return newDexes, nil
 }
 
+// PriceAt returns USD price of token as of height, using graph of
+// latest snapshot at or below height
+func (n *Engine) PriceAt(token common.Address, height uint64) (*itypes.PriceResult, error) {
+	graph, err := n.graphAt(height)
+	if err != nil {
+		return nil, err
+	}
+	var result *itypes.PriceResult
+	tc := make(map[common.Address]*itypes.PriceResult)
+	if !n.tryPricingUSD(token, &result, graph, tc, height) {
+		return nil, fmt.Errorf("cannot price %s at %d", token, height)
+	}
+	return result, nil
+}
+
 func (n *Engine) resolveItems(graph *gg, items []interface{}, resHeight uint64) {
 	// For each item that is UserRequested
 	// Do bfs to find the best 3 candidates
@@ -169,6 +212,7 @@ func (n *Engine) resolveItems(graph *gg, items []interface{}, resHeight uint64)
 								Price:       counterparty,
 							},
 						},
+						Confidence: i.Price1.Confidence,
 					}
 					cpriced++
 					priced++
@@ -187,6 +231,7 @@ func (n *Engine) resolveItems(graph *gg, items []interface{}, resHeight uint64)
 								Price:       counterparty,
 							},
 						},
+						Confidence: i.Price0.Confidence,
 					}
 					cpriced++
 					priced++
@@ -226,6 +271,7 @@ func (n *Engine) resolveItems(graph *gg, items []interface{}, resHeight uint64)
 								Price:       counterparty,
 							},
 						},
+						Confidence: i.Price1.Confidence,
 					}
 					cpriced++
 					priced++
@@ -244,6 +290,7 @@ func (n *Engine) resolveItems(graph *gg, items []interface{}, resHeight uint64)
 								Price:       counterparty,
 							},
 						},
+						Confidence: i.Price0.Confidence,
 					}
 					cpriced++
 					priced++
@@ -283,6 +330,7 @@ func (n *Engine) resolveItems(graph *gg, items []interface{}, resHeight uint64)
 								Price:       counterparty,
 							},
 						},
+						Confidence: i.Price1.Confidence,
 					}
 					cpriced++
 					priced++
@@ -301,6 +349,7 @@ func (n *Engine) resolveItems(graph *gg, items []interface{}, resHeight uint64)
 								Price:       counterparty,
 							},
 						},
+						Confidence: i.Price0.Confidence,
 					}
 					cpriced++
 					priced++
@@ -313,6 +362,19 @@ func (n *Engine) resolveItems(graph *gg, items []interface{}, resHeight uint64)
 				}
 			}
 			items[idx] = i
+		case *itypes.Transfer:
+			if i.ProcessingType != itypes.UserRequested {
+				continue
+			}
+			requested++
+			if n.tryPricingUSD(i.Token, &i.PriceDerivationMeta, graph, tc, resHeight) {
+				priced++
+				p := big.NewFloat(1.0).Set(i.PriceDerivationMeta.Price)
+				p = p.Mul(p, i.Amount)
+				p = p.Abs(p)
+				i.AmountUSD = p
+			}
+			items[idx] = i
 		}
 	}
 	n.log.Info("pricing engine resolution statistics",
@@ -328,10 +390,19 @@ func (n *Engine) getReserveUpdates(items []interface{}) map[addrTuple]itypes.Uni
 	for _, item := range items {
 		switch i := item.(type) {
 		case *itypes.Mint:
+			if isUniV3Item(i.Type) {
+				continue
+			}
 			reserveUpdates[addrTuple{i.Token0, i.Token1}] = itypes.UniV2Metadata{"", i.PairContract, i.Token0, i.Token1, i.Reserve0, i.Reserve1}
 		case *itypes.Burn:
+			if isUniV3Item(i.Type) {
+				continue
+			}
 			reserveUpdates[addrTuple{i.Token0, i.Token1}] = itypes.UniV2Metadata{"", i.PairContract, i.Token0, i.Token1, i.Reserve0, i.Reserve1}
 		case *itypes.Swap:
+			if isUniV3Item(i.Type) {
+				continue
+			}
 			reserveUpdates[addrTuple{i.Token0, i.Token1}] = itypes.UniV2Metadata{"", i.PairContract, i.Token0, i.Token1, i.Reserve0, i.Reserve1}
 		}
 	}
@@ -339,7 +410,10 @@ func (n *Engine) getReserveUpdates(items []interface{}) map[addrTuple]itypes.Uni
 	return reserveUpdates
 }
 
-func (n *Engine) updateGraph(graph *gg, updates map[addrTuple]itypes.UniV2Metadata, resHeight uint64) []itypes.UniV2Metadata {
+func (n *Engine) updateGraph(graph *gg,
+	updates map[addrTuple]itypes.UniV2Metadata,
+	v3Updates map[addrTuple]itypes.UniV3Metadata,
+	resHeight uint64) []itypes.UniV2Metadata {
 	wg := sync.WaitGroup{}
 	callopts := bind.CallOpts{BlockNumber: big.NewInt(int64(resHeight))}
 	newDexes := []itypes.UniV2Metadata{}
@@ -376,14 +450,50 @@ func (n *Engine) updateGraph(graph *gg, updates map[addrTuple]itypes.UniV2Metada
 		}
 	}
 
+	// Ensure v3 pools exist, a token pair already connected by
+	// some other pool keeps its edge
+	for addrs, val := range v3Updates {
+		if connections, ok := graph.Graph[addrs.First]; ok && connections.Exists(addrs.Second) {
+			continue
+		}
+		t0, t1, err := n.EthRPC.GetTokensUniV3(val.Pool, &callopts)
+		if err != nil || t0 != val.Token0 || t1 != val.Token1 {
+			n.log.Warn("not a univ3 pool, skipping",
+				"pool", val.Pool)
+			continue
+		}
+		n.log.Info("adding previously unseen univ3 pool to pricing graph", "pool", val.Pool)
+		graph.AddWeightedEdge(val.Token0,
+			val.Token1,
+			1, // fetch
+			"dex",
+			val)
+	}
+
+	// Ensure CEX edges exist, tokens already connected to USD some
+	// other way keep their edge
+	for token, symbol := range n.cexSymbols {
+		if connections, ok := graph.Graph[token]; ok && connections.Exists(USDTokenID) {
+			continue
+		}
+		graph.AddWeightedEdge(token,
+			USDTokenID,
+			1,
+			"cex",
+			itypes.CEXMetadata{Token: token, Symbol: symbol})
+	}
+
+	// Oracle and CEX edges are refreshed concurrently and set in graph
+	// once all are done, graph is not written while being iterated
 	mut := sync.Mutex{}
+	refreshed := []we{}
 	for from, connections := range graph.Graph {
 		for to, edge := range connections {
 			switch i := edge.Metadata.(type) {
 
 			case itypes.WrappedCLMetadata:
 				wg.Add(1)
-				go func(from common.Address, to common.Address, i itypes.WrappedCLMetadata, edge we) {
+				go func(i itypes.WrappedCLMetadata, edge we) {
 					defer wg.Done()
 					oracleMetadata, err := n.EthRPC.GetChainlinkRoundData(i.Oracle, &callopts)
 					if err != nil {
@@ -395,9 +505,9 @@ func (n *Engine) updateGraph(graph *gg, updates map[addrTuple]itypes.UniV2Metada
 					i.Data = oracleMetadata
 					edge.Metadata = i
 					mut.Lock()
-					graph.Graph[from][to] = edge
+					refreshed = append(refreshed, edge)
 					mut.Unlock()
-				}(from, to, i, edge)
+				}(i, edge)
 
 			case itypes.UniV2Metadata:
 				if update, ok := updates[addrTuple{from, to}]; ok {
@@ -405,9 +515,43 @@ func (n *Engine) updateGraph(graph *gg, updates map[addrTuple]itypes.UniV2Metada
 					i.Res0 = update.Res0
 					i.Res1 = update.Res1
 					edge.Metadata = i
-					mut.Lock()
 					graph.Graph[from][to] = edge
+				}
+
+			case itypes.CEXMetadata:
+				if n.cex == nil {
+					continue
+				}
+				blockTime, err := n.getBlockTime(resHeight)
+				if err != nil {
+					n.log.Warn("cannot refresh cex price, skipping", "error", err)
+					continue
+				}
+				wg.Add(1)
+				go func(i itypes.CEXMetadata, edge we) {
+					defer wg.Done()
+					price, err := n.cex.GetPrice(i.Symbol, time.Unix(int64(blockTime), 0))
+					if err != nil {
+						n.log.Warn("cannot retrieve cex price, skipping",
+							"symbol", i.Symbol,
+							"height", resHeight,
+							"error", err)
+						return
+					}
+					i.Price = price
+					i.Time = blockTime
+					edge.Metadata = i
+					mut.Lock()
+					refreshed = append(refreshed, edge)
 					mut.Unlock()
+				}(i, edge)
+
+			case itypes.UniV3Metadata:
+				// Forward and reverse edges both carry metadata
+				// in order of pool tokens
+				if update, ok := v3Updates[addrTuple{First: i.Token0, Second: i.Token1}]; ok && update.Pool == i.Pool {
+					edge.Metadata = update
+					graph.Graph[from][to] = edge
 				}
 
 			default:
@@ -416,6 +560,9 @@ func (n *Engine) updateGraph(graph *gg, updates map[addrTuple]itypes.UniV2Metada
 		}
 	}
 	wg.Wait()
+	for _, edge := range refreshed {
+		graph.Graph[edge.VertexFrom][edge.VertexTo] = edge
+	}
 
 	return newDexes
 }
@@ -435,84 +582,32 @@ func (n *Engine) tryPricingUSD(from common.Address,
 	// find cadidates
 	maxRoutes := 5
 	routes := graph.GetBFSCandidates(maxRoutes,
-		from, common.HexToAddress("0xffffffffffffffffffffffffffffffffffffffff"))
+		from, USDTokenID)
 
 	callopts := &bind.CallOpts{BlockNumber: big.NewInt(int64(resHeight))}
 
-	maxScore := big.NewFloat(0.0)
-	var calcResult *itypes.PriceResult = nil
-
+	quotes, rejections := []routeQuote{}, []interface{}{}
 	for _, route := range routes {
-		// n.log.Info("route for", "from", from, "route", route)
-
-		multiplier := big.NewFloat(1.0)
-		pr := itypes.PriceResult{}
-		minScore := big.NewFloat(math.MaxInt64)
-
-		for _, edge := range route {
-			switch i := edge.Metadata.(type) {
-			case itypes.WrappedCLMetadata:
-				name0, err := n.EthRPC.GetERC20Name(i.From, callopts)
-				if err != nil {
-					n.log.Warn("unable to get name for token", "error", err, "token", i.From)
-				}
-				name1, err := n.EthRPC.GetERC20Name(i.To, callopts)
-				if err != nil {
-					n.log.Warn("unable to get name for token", "error", err, "token", i.To)
-				}
-				i.Description = fmt.Sprintf("Chainlink (%v, %v), rev:%v", name0, name1, edge.IsReverseEdge)
-				pr.Path = append(pr.Path, i)
-				// TODO: error checks here
-				decimals, _ := n.EthRPC.GetERC20Decimals(i.Oracle, callopts)
-				if !edge.IsReverseEdge {
-					multiplier = multiplier.Mul(multiplier, util.DivideBy10pow(i.Data.Answer, decimals))
-				} else {
-					multiplier = multiplier.Quo(multiplier, util.DivideBy10pow(i.Data.Answer, decimals))
-				}
-			case itypes.UniV2Metadata:
-				name0, err := n.EthRPC.GetERC20Name(i.Token0, callopts)
-				if err != nil {
-					n.log.Warn("unable to get name for token", "error", err, "token", i.Token0)
-				}
-				name1, err := n.EthRPC.GetERC20Name(i.Token1, callopts)
-				if err != nil {
-					n.log.Warn("unable to get name for token", "error", err, "token", i.Token1)
-				}
-				i.Description = fmt.Sprintf("UniswapV2Dex (%v, %v), rev:%v", name0, name1, edge.IsReverseEdge)
-
-				pr.Path = append(pr.Path, i)
-				ratio := big.NewFloat(1.0).Quo(i.Res1, i.Res0)
-				if !edge.IsReverseEdge {
-					edgeImpact := big.NewFloat(1.0).Mul(i.Res0, multiplier)
-					// edgeImpact = edgeImpact * 2.0 // Implicit
-					multiplier = multiplier.Mul(multiplier, ratio)
-					if edgeImpact.Cmp(minScore) == -1 {
-						minScore.Set(edgeImpact)
-					}
-				} else {
-					edgeImpact := big.NewFloat(1.0).Mul(i.Res1, multiplier)
-					multiplier = multiplier.Quo(multiplier, ratio)
-					if edgeImpact.Cmp(minScore) == -1 {
-						minScore.Set(edgeImpact)
-					}
-				}
-			}
+		quote := n.quoteRoute(route, callopts, resHeight)
+		if quote.Rejection != nil {
+			rejections = append(rejections, quote.Rejection)
+			continue
 		}
-		pr.Price = multiplier
-
-		if maxScore.Cmp(minScore) == -1 {
-			maxScore.Set(minScore)
-			calcResult = &pr
+		if quote.Liquidity != nil && n.minLiquidityUSD != nil && quote.Liquidity.Cmp(n.minLiquidityUSD) == -1 {
+			continue
 		}
+		quotes = append(quotes, quote)
+	}
+	calcResult, deviating := aggregateQuotes(quotes)
+	rejections = append(rejections, deviating...)
 
-		// if from == common.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f") {
-		// 	n.log.Info("price found", "price", pr.Price, "path", pr.Path, "maxScore", maxScore, "minScore", minScore)
-		// }
+	// Rejected oracles are noted at end of path of price used instead
+	if calcResult != nil && len(rejections) > 0 {
+		calcResult.Path = append(append([]interface{}{}, calcResult.Path...), rejections...)
 	}
 
 	// Cache result
 	if calcResult != nil {
-		// n.log.Info("caching result", "token", from, "price", calcResult)
 		tc[from] = calcResult
 		*result = calcResult
 		return true
@@ -520,6 +615,134 @@ func (n *Engine) tryPricingUSD(from common.Address,
 	return false
 }
 
+// getBlockTime returns timestamp of block at height, remembering
+// last one fetched
+func (n *Engine) getBlockTime(height uint64) (uint64, error) {
+	if n.blockTime != 0 && n.timeHeight == height {
+		return n.blockTime, nil
+	}
+	blockTime, err := n.EthRPC.GetBlockTimestamp(height)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get time of block %d: %w", height, err)
+	}
+	n.timeHeight, n.blockTime = height, blockTime
+	return blockTime, nil
+}
+
+// quoteRoute prices first token of route in last token of route,
+// along with USD liquidity of thinnest dex hop on route. Routes through
+// chainlink feeds not to be trusted at resHeight are rejected
+func (n *Engine) quoteRoute(route []we, callopts *bind.CallOpts, resHeight uint64) routeQuote {
+	multiplier := big.NewFloat(1.0)
+	pr := itypes.PriceResult{}
+
+	// reserve of token each dex hop goes to, and multiplier after
+	// hop (price of route start in that token)
+	hopReserves, hopMultipliers := []*big.Float{}, []*big.Float{}
+	isCEX := false
+
+	for _, edge := range route {
+		switch i := edge.Metadata.(type) {
+		case itypes.WrappedCLMetadata:
+			name0, err := n.EthRPC.GetERC20Name(i.From, callopts)
+			if err != nil {
+				n.log.Warn("unable to get name for token", "error", err, "token", i.From)
+			}
+			name1, err := n.EthRPC.GetERC20Name(i.To, callopts)
+			if err != nil {
+				n.log.Warn("unable to get name for token", "error", err, "token", i.To)
+			}
+			i.Description = fmt.Sprintf("Chainlink (%v, %v), rev:%v", name0, name1, edge.IsReverseEdge)
+			blockTime, err := n.getBlockTime(resHeight)
+			if err == nil {
+				err = checkRound(i.Data, i.Heartbeat, blockTime)
+			}
+			if err != nil {
+				return routeQuote{Rejection: oracleRejection(i, err)}
+			}
+			pr.Path = append(pr.Path, i)
+			// TODO: error checks here
+			decimals, _ := n.EthRPC.GetERC20Decimals(i.Oracle, callopts)
+			if !edge.IsReverseEdge {
+				multiplier = multiplier.Mul(multiplier, util.DivideBy10pow(i.Data.Answer, decimals))
+			} else {
+				multiplier = multiplier.Quo(multiplier, util.DivideBy10pow(i.Data.Answer, decimals))
+			}
+		case itypes.CEXMetadata:
+			i.Description = fmt.Sprintf("CEX (%v), rev:%v", i.Symbol, edge.IsReverseEdge)
+			blockTime, err := n.getBlockTime(resHeight)
+			if err == nil {
+				err = checkCEXPrice(i, blockTime)
+			}
+			if err != nil {
+				return routeQuote{Rejection: itypes.RejectedCEXMetadata{
+					Description: fmt.Sprintf("CEX price of %v rejected", i.Symbol),
+					Symbol:      i.Symbol,
+					Reason:      err.Error(),
+				}}
+			}
+			pr.Path = append(pr.Path, i)
+			isCEX = true
+			if !edge.IsReverseEdge {
+				multiplier = multiplier.Mul(multiplier, i.Price)
+			} else {
+				multiplier = multiplier.Quo(multiplier, i.Price)
+			}
+		case itypes.UniV2Metadata:
+			name0, err := n.EthRPC.GetERC20Name(i.Token0, callopts)
+			if err != nil {
+				n.log.Warn("unable to get name for token", "error", err, "token", i.Token0)
+			}
+			name1, err := n.EthRPC.GetERC20Name(i.Token1, callopts)
+			if err != nil {
+				n.log.Warn("unable to get name for token", "error", err, "token", i.Token1)
+			}
+			i.Description = fmt.Sprintf("UniswapV2Dex (%v, %v), rev:%v", name0, name1, edge.IsReverseEdge)
+
+			pr.Path = append(pr.Path, i)
+			ratio := big.NewFloat(1.0).Quo(i.Res1, i.Res0)
+			if !edge.IsReverseEdge {
+				multiplier = multiplier.Mul(multiplier, ratio)
+				hopReserves = append(hopReserves, i.Res1)
+			} else {
+				multiplier = multiplier.Quo(multiplier, ratio)
+				hopReserves = append(hopReserves, i.Res0)
+			}
+			hopMultipliers = append(hopMultipliers, big.NewFloat(1.0).Set(multiplier))
+		case itypes.UniV3Metadata:
+			name0, err := n.EthRPC.GetERC20Name(i.Token0, callopts)
+			if err != nil {
+				n.log.Warn("unable to get name for token", "error", err, "token", i.Token0)
+			}
+			name1, err := n.EthRPC.GetERC20Name(i.Token1, callopts)
+			if err != nil {
+				n.log.Warn("unable to get name for token", "error", err, "token", i.Token1)
+			}
+			i.Description = fmt.Sprintf("UniswapV3Pool (%v, %v), rev:%v", name0, name1, edge.IsReverseEdge)
+
+			pr.Path = append(pr.Path, i)
+			// Spot price from sqrtPriceX96, liquidity limited
+			// to virtual reserves of in-range liquidity
+			ratio := big.NewFloat(1.0).Quo(i.Res1, i.Res0)
+			if !edge.IsReverseEdge {
+				multiplier = multiplier.Mul(multiplier, ratio)
+				hopReserves = append(hopReserves, i.Res1)
+			} else {
+				multiplier = multiplier.Quo(multiplier, ratio)
+				hopReserves = append(hopReserves, i.Res0)
+			}
+			hopMultipliers = append(hopMultipliers, big.NewFloat(1.0).Set(multiplier))
+		}
+	}
+	pr.Price = multiplier
+
+	return routeQuote{
+		Result:    pr,
+		Liquidity: routeLiquidity(multiplier, hopReserves, hopMultipliers),
+		CEX:       isCEX,
+	}
+}
+
 func (n *Engine) syncDump(resHeight uint64) error {
 	n.log.Info("undertaking sync dump")
 	chainlinkRecords, err := loadChainlinkCSV(n.chainlinkOraclesDumpFile)
@@ -535,6 +758,18 @@ func (n *Engine) syncDump(resHeight uint64) error {
 		"chainlinkrec", len(chainlinkRecords),
 		"dexrec", len(dexRecords))
 
+	// Heartbeats are not on chain, feed files written by oraclenode
+	// carry none and leave feeds on default max round age
+	for _, rec := range chainlinkRecords {
+		if rec.Heartbeat == 0 {
+			n.log.Warn("no heartbeat for chainlink feed in dump, rounds older than default are rejected",
+				"oracle", rec.Oracle,
+				"from", rec.From,
+				"to", rec.To,
+				"heartbeat", defaultChainlinkHeartbeat)
+		}
+	}
+
 	startTime := time.Now()
 	graph := n.genGraph(chainlinkRecords, dexRecords, resHeight)
 	genTime := time.Since(startTime)
@@ -544,7 +779,7 @@ func (n *Engine) syncDump(resHeight uint64) error {
 		"_time", genTime)
 
 	n.log.Info("syncing step to backfill in DB")
-	err = n.syncGraphToLB(graph, resHeight)
+	err = n.storeSnapshot(graph, nil, resHeight)
 	if err != nil {
 		return err
 	}
@@ -553,17 +788,6 @@ func (n *Engine) syncDump(resHeight uint64) error {
 	return nil
 }
 
-func (n *Engine) syncGraphToLB(graph *gg, height uint64) error {
-	if err := n.LocalBackend.Set(lb.KeyLatestHeight, util.GobEncode(height)); err != nil {
-		return err
-	}
-	if err := n.LocalBackend.Set(lb.KeyLatestPricingGraph, util.GobEncode(graph)); err != nil {
-		return err
-	}
-
-	return n.LocalBackend.Sync()
-}
-
 // generates graphs for blocks where
 // new chainlink oracles came alive or dex pools were
 // made. Ideally the steps should be sparse enough
@@ -600,10 +824,11 @@ func (n *Engine) genGraph(chainlinkRecords ChainlinkRecords,
 				math.MaxInt64,
 				"chainlink",
 				itypes.WrappedCLMetadata{
-					Data:   oracleMetadata,
-					Oracle: rec.Oracle,
-					From:   rec.From,
-					To:     rec.To,
+					Data:      oracleMetadata,
+					Oracle:    rec.Oracle,
+					From:      rec.From,
+					To:        rec.To,
+					Heartbeat: rec.Heartbeat,
 				})
 		}(_rec)
 	}
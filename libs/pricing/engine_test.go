@@ -0,0 +1,56 @@
+package priceresolver
+
+import (
+	"math/big"
+	"testing"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/gograph"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type namesRPC struct{ ethrpc.EthRPC }
+
+func (namesRPC) GetERC20Name(common.Address, *bind.CallOpts) (string, error) {
+	return "token", nil
+}
+
+func TestResolveTransfer(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := &Engine{log: log, EthRPC: namesRPC{}}
+
+	// 1 token = 2 USD
+	token := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	graph := gograph.NewGraph[common.Address, int64, string, interface{}](true)
+	graph.AddWeightedEdge(token, USDTokenID, 1, "dex", itypes.UniV2Metadata{
+		Token0: token,
+		Token1: USDTokenID,
+		Res0:   big.NewFloat(1000),
+		Res1:   big.NewFloat(2000),
+	})
+
+	priced := &itypes.Transfer{ProcessingType: itypes.UserRequested, Token: token, Amount: big.NewFloat(-3)}
+	unknown := &itypes.Transfer{ProcessingType: itypes.UserRequested,
+		Token: common.HexToAddress("0x00000000000000000000000000000000000000bb"), Amount: big.NewFloat(1)}
+	pricing := &itypes.Transfer{ProcessingType: itypes.PricingEngineRequest, Token: token, Amount: big.NewFloat(1)}
+	engine.resolveItems(graph, []interface{}{priced, unknown, pricing}, 100)
+
+	if priced.PriceDerivationMeta == nil || len(priced.PriceDerivationMeta.Path) != 1 {
+		t.Fatalf("expected transfer priced through one edge, got %v", priced.PriceDerivationMeta)
+	}
+	if usd, _ := priced.AmountUSD.Float64(); usd != 6 {
+		t.Errorf("expected 6 USD, got %v", usd)
+	}
+	if unknown.AmountUSD != nil || unknown.PriceDerivationMeta != nil {
+		t.Error("expected transfer of token without route to stay unpriced")
+	}
+	if pricing.AmountUSD != nil {
+		t.Error("expected transfer not requested by user to stay unpriced")
+	}
+}
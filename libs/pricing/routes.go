@@ -0,0 +1,165 @@
+package priceresolver
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	itypes "github.com/supragya/EtherScope/types"
+)
+
+// Routes priced within this fraction of chosen price agree with it
+const priceAgreementTolerance = 0.02
+
+// routeQuote is price found along one candidate route. Liquidity is
+// USD liquidity of thinnest dex hop, nil if route has no dex hops.
+// CEX is set for routes using CEX prices. Rejection is set instead of
+// price for routes through untrusted oracles or CEX prices
+type routeQuote struct {
+	Result    itypes.PriceResult
+	Liquidity *big.Float
+	CEX       bool
+	Rejection interface{}
+}
+
+// routeLiquidity returns USD liquidity of thinnest dex hop on route.
+// price is price of route start in USD, hopReserves are reserves of
+// token each hop goes to and hopMultipliers price of route start in
+// that token. A pool holds about as much value on both sides, so hop
+// liquidity is twice value of reserve
+func routeLiquidity(price *big.Float, hopReserves, hopMultipliers []*big.Float) *big.Float {
+	var liquidity *big.Float
+	for idx, reserve := range hopReserves {
+		if hopMultipliers[idx].Sign() == 0 {
+			return big.NewFloat(0.0)
+		}
+		// USD price of token hop goes to
+		tokenPrice := big.NewFloat(1.0).Quo(price, hopMultipliers[idx])
+		hop := big.NewFloat(1.0).Mul(big.NewFloat(2.0), reserve)
+		hop = hop.Mul(hop, tokenPrice)
+		hop = hop.Abs(hop)
+		if liquidity == nil || hop.Cmp(liquidity) == -1 {
+			liquidity = hop
+		}
+	}
+	return liquidity
+}
+
+// Oracle only routes priced this far off dex routes are not trusted
+const maxOracleDeviation = 0.2
+
+// aggregateQuotes returns liquidity weighted median of quotes, so that
+// thin pools cannot move price unless they hold most of liquidity
+// across candidate routes. Oracle only routes are not bound by
+// liquidity and are preferred over dex routes when present, unless
+// they deviate from dex routes by more than maxOracleDeviation.
+// Rejections of such oracles are returned along with price. Routes
+// using CEX prices are used only if no other route is found
+func aggregateQuotes(quotes []routeQuote) (*itypes.PriceResult, []interface{}) {
+	oracles, dexes, cexes := []routeQuote{}, []routeQuote{}, []routeQuote{}
+	for _, quote := range quotes {
+		if quote.Result.Price == nil || quote.Result.Price.IsInf() || weight(quote) <= 0 {
+			continue
+		}
+		if quote.CEX {
+			cexes = append(cexes, quote)
+		} else if quote.Liquidity == nil {
+			oracles = append(oracles, quote)
+		} else {
+			dexes = append(dexes, quote)
+		}
+	}
+
+	rejections := []interface{}{}
+	if len(oracles) > 0 && len(dexes) > 0 {
+		dexPrice, _ := weightedMedian(dexes).Result.Price.Float64()
+		trusted := []routeQuote{}
+		for _, quote := range oracles {
+			price, _ := quote.Result.Price.Float64()
+			if dexPrice == 0 || withinTolerance(price, dexPrice, maxOracleDeviation) {
+				trusted = append(trusted, quote)
+				continue
+			}
+			for _, hop := range quote.Result.Path {
+				if i, ok := hop.(itypes.WrappedCLMetadata); ok {
+					rejections = append(rejections, oracleRejection(i,
+						fmt.Errorf("route price %v deviates from dex price %v", price, dexPrice)))
+					break
+				}
+			}
+		}
+		oracles = trusted
+	}
+
+	valid := dexes
+	if len(oracles) > 0 {
+		valid = oracles
+	} else if len(dexes) == 0 {
+		valid = cexes
+	}
+	if len(valid) == 0 {
+		return nil, rejections
+	}
+	median := weightedMedian(valid)
+
+	// Confidence is share of liquidity agreeing with median, halved
+	// when only a single route could be found
+	medianPrice, _ := median.Result.Price.Float64()
+	agreeing, total := 0.0, 0.0
+	for _, quote := range valid {
+		price, _ := quote.Result.Price.Float64()
+		total += weight(quote)
+		if medianPrice == 0 {
+			if price == 0 {
+				agreeing += weight(quote)
+			}
+			continue
+		}
+		if withinTolerance(price, medianPrice, priceAgreementTolerance) {
+			agreeing += weight(quote)
+		}
+	}
+	confidence := agreeing / total
+	if len(valid) == 1 {
+		confidence /= 2
+	}
+
+	result := median.Result
+	result.LiquidityUSD = median.Liquidity
+	result.Confidence = confidence
+	return &result, rejections
+}
+
+// weight of quote in median, oracle only routes weigh equally
+func weight(quote routeQuote) float64 {
+	if quote.Liquidity == nil {
+		return 1.0
+	}
+	w, _ := quote.Liquidity.Float64()
+	return w
+}
+
+// weightedMedian returns quote at weighted median of quotes by price
+func weightedMedian(quotes []routeQuote) routeQuote {
+	sorted := append([]routeQuote{}, quotes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Result.Price.Cmp(sorted[j].Result.Price) == -1
+	})
+	total := 0.0
+	for _, quote := range sorted {
+		total += weight(quote)
+	}
+	cumulative := 0.0
+	for _, quote := range sorted {
+		cumulative += weight(quote)
+		if cumulative >= total/2 {
+			return quote
+		}
+	}
+	return sorted[len(sorted)-1]
+}
+
+func withinTolerance(price, reference, tolerance float64) bool {
+	deviation := (price - reference) / reference
+	return deviation <= tolerance && deviation >= -tolerance
+}
@@ -0,0 +1,81 @@
+package priceresolver
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/supragya/EtherScope/libs/gograph"
+	logger "github.com/supragya/EtherScope/libs/log"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTryPricingUSDWeighsLiquidity(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	wrapped := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	thin := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	graph := gograph.NewGraph[common.Address, int64, string, interface{}](true)
+	pool := func(token0, token1 common.Address, res0, res1 float64) {
+		graph.AddWeightedEdge(token0, token1, 1, "dex", itypes.UniV2Metadata{
+			Token0: token0,
+			Token1: token1,
+			Res0:   big.NewFloat(res0),
+			Res1:   big.NewFloat(res1),
+		})
+	}
+	// 1 token = 2 USD directly and through wrapped, while a thin
+	// pool pair prices it at 100 USD
+	pool(token, USDTokenID, 1e6, 2e6)
+	pool(token, wrapped, 1000, 500)
+	pool(wrapped, USDTokenID, 1e5, 4e5)
+	pool(token, thin, 1, 100)
+	pool(thin, USDTokenID, 10, 10)
+
+	for _, tc := range []struct {
+		minLiquidityUSD *big.Float
+		confidence      float64
+	}{
+		{nil, 0.99},
+		{big.NewFloat(1000), 1},
+	} {
+		engine := &Engine{log: log, EthRPC: namesRPC{}, minLiquidityUSD: tc.minLiquidityUSD}
+		var result *itypes.PriceResult
+		if !engine.tryPricingUSD(token, &result, graph, map[common.Address]*itypes.PriceResult{}, 100) {
+			t.Fatal("expected token to be priced")
+		}
+		if price, _ := result.Price.Float64(); price != 2 {
+			t.Errorf("expected price 2, got %v", price)
+		}
+		if result.Confidence < tc.confidence || result.Confidence > 1 {
+			t.Errorf("expected confidence at least %v, got %v", tc.confidence, result.Confidence)
+		}
+		if result.LiquidityUSD == nil {
+			t.Error("expected liquidity of route")
+		}
+	}
+
+	// Every route of thin token passes through thin pool
+	engine := &Engine{log: log, EthRPC: namesRPC{}, minLiquidityUSD: big.NewFloat(1000)}
+	var result *itypes.PriceResult
+	if engine.tryPricingUSD(thin, &result, graph, map[common.Address]*itypes.PriceResult{}, 100) {
+		t.Errorf("expected token of thin pool to stay unpriced, got %v", result.Price)
+	}
+}
+
+func TestAggregateQuotesSingleRoute(t *testing.T) {
+	result, _ := aggregateQuotes([]routeQuote{{
+		Result:    itypes.PriceResult{Price: big.NewFloat(3)},
+		Liquidity: big.NewFloat(5000),
+	}})
+	if result == nil || result.Confidence != 0.5 {
+		t.Errorf("expected single route priced with confidence 0.5, got %v", result)
+	}
+	if result, _ := aggregateQuotes(nil); result != nil {
+		t.Error("expected no price without routes")
+	}
+}
@@ -0,0 +1,329 @@
+package priceresolver
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/supragya/EtherScope/libs/gograph"
+	"github.com/supragya/EtherScope/libs/util"
+	lb "github.com/supragya/EtherScope/services/local_backend"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A full graph snapshot is stored after these many deltas, bounding
+// deltas to be applied while loading graph for any height
+const fullSnapshotEvery = 100
+
+var (
+	ErrorResolutionBeforeLowestSnapshot = errors.New("ResolutionBeforeLowestSnapshotError")
+)
+
+// snapshotRef locates a stored pricing graph version. Full snapshots
+// hold the whole graph, deltas hold edges changed or removed since
+// graph as of previous stored height
+type snapshotRef struct {
+	height uint64
+	full   bool
+}
+
+func snapshotKey(ref snapshotRef) string {
+	kind := "d"
+	if ref.full {
+		kind = "f"
+	}
+	return fmt.Sprintf("%s:%020d:%s", lb.KeyGraphPrefix, ref.height, kind)
+}
+
+func parseSnapshotKey(key string) (snapshotRef, error) {
+	parts := strings.Split(key, ":")
+	if len(parts) != 3 || parts[0] != lb.KeyGraphPrefix || (parts[2] != "f" && parts[2] != "d") {
+		return snapshotRef{}, errors.New("malformed pricing graph key: " + key)
+	}
+	height, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return snapshotRef{}, errors.New("malformed pricing graph key: " + key)
+	}
+	return snapshotRef{height: height, full: parts[2] == "f"}, nil
+}
+
+// loadSnapshotIndex lists stored graph versions in order of height.
+// Graphs stored by versions before snapshots were introduced are
+// taken over as a full snapshot
+func (n *Engine) loadSnapshotIndex() error {
+	if n.snapshots != nil {
+		return nil
+	}
+	snapshots := []snapshotRef{}
+	err := n.LocalBackend.Iterate(lb.KeyGraphPrefix+":", func(key string, val []byte) error {
+		ref, err := parseSnapshotKey(key)
+		if err != nil {
+			return err
+		}
+		snapshots = append(snapshots, ref)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].height < snapshots[j].height
+	})
+	n.snapshots = snapshots
+
+	if len(snapshots) > 0 {
+		return nil
+	}
+	legacy, ok, err := n.LocalBackend.Get(lb.KeyLatestPricingGraph)
+	if err != nil || !ok {
+		return err
+	}
+	height, ok, err := n.getHeight(lb.KeyLatestHeight)
+	if err != nil || !ok {
+		return err
+	}
+	n.log.Info("migrating latest pricing graph to snapshot", "height", height)
+	graph := gograph.NewGraph[common.Address, int64, string, interface{}](true)
+	if err := util.GobDecode(legacy, graph); err != nil {
+		return err
+	}
+	return n.storeSnapshot(graph, nil, height)
+}
+
+func (n *Engine) getHeight(key string) (uint64, bool, error) {
+	val, ok, err := n.LocalBackend.Get(key)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+	var height uint64
+	if err := util.GobDecode(val, &height); err != nil {
+		return 0, false, fmt.Errorf("wrong height encoding for %s: %w", key, err)
+	}
+	return height, true, nil
+}
+
+// floorSnapshots returns index of last full snapshot at or below
+// height and index of last version at or below height, -1 if none
+func (n *Engine) floorSnapshots(height uint64) (int, int) {
+	last := sort.Search(len(n.snapshots), func(i int) bool {
+		return n.snapshots[i].height > height
+	}) - 1
+	full := last
+	for full >= 0 && !n.snapshots[full].full {
+		full--
+	}
+	return full, last
+}
+
+// graphAt returns pricing graph as of height, built from last full
+// snapshot at or below height and deltas stored after it
+func (n *Engine) graphAt(height uint64) (*gg, error) {
+	if err := n.loadSnapshotIndex(); err != nil {
+		return nil, err
+	}
+	full, last := n.floorSnapshots(height)
+	if full < 0 {
+		return nil, fmt.Errorf("no pricing graph snapshot at or below %d: %w",
+			height, ErrorResolutionBeforeLowestSnapshot)
+	}
+
+	// Consecutive resolutions reuse graph of previous one
+	if n.lastGraph != nil && n.lastHeight == n.snapshots[last].height {
+		return gograph.CopyGraph(n.lastGraph), nil
+	}
+
+	graph := gograph.NewGraph[common.Address, int64, string, interface{}](true)
+	if err := n.loadSnapshot(n.snapshots[full], graph); err != nil {
+		return nil, err
+	}
+	for _, ref := range n.snapshots[full+1 : last+1] {
+		var delta graphDelta
+		if err := n.loadSnapshot(ref, &delta); err != nil {
+			return nil, err
+		}
+		applyDelta(graph, delta)
+	}
+	return graph, nil
+}
+
+func (n *Engine) loadSnapshot(ref snapshotRef, val interface{}) error {
+	data, ok, err := n.LocalBackend.Get(snapshotKey(ref))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("pricing graph snapshot missing at %d", ref.height)
+	}
+	return util.GobDecode(data, val)
+}
+
+// storeSnapshot stores graph as of height, as a delta against base
+// (graph as of previous stored height) unless a full snapshot is due.
+// Version already present at height is replaced by one of same kind
+func (n *Engine) storeSnapshot(graph *gg, base *gg, height uint64) error {
+	full, last := n.floorSnapshots(height)
+	replace := last >= 0 && n.snapshots[last].height == height
+
+	ref := snapshotRef{height: height, full: base == nil || full < 0 || last-full >= fullSnapshotEvery}
+	if replace {
+		ref.full = n.snapshots[last].full
+	}
+	var val []byte
+	if ref.full {
+		val = util.GobEncode(graph)
+	} else {
+		val = util.GobEncode(diffGraph(base, graph))
+	}
+	if err := n.LocalBackend.Set(snapshotKey(ref), val); err != nil {
+		return err
+	}
+
+	if !replace {
+		n.snapshots = append(n.snapshots, snapshotRef{})
+		copy(n.snapshots[last+2:], n.snapshots[last+1:])
+		n.snapshots[last+1] = ref
+	}
+	n.lastGraph = gograph.CopyGraph(graph)
+	n.lastHeight = height
+
+	latest, ok, err := n.getHeight(lb.KeyLatestHeight)
+	if err != nil {
+		return err
+	}
+	if !ok || height > latest {
+		if err := n.LocalBackend.Set(lb.KeyLatestHeight, util.GobEncode(height)); err != nil {
+			return err
+		}
+	}
+	if n.snapshots[0].height == height {
+		if err := n.LocalBackend.Set(lb.KeyLowestPricingHeight, util.GobEncode(height)); err != nil {
+			return err
+		}
+	}
+	if err := n.prune(); err != nil {
+		return err
+	}
+	return n.LocalBackend.Sync()
+}
+
+// prune drops graph versions outside of retention. Last full snapshot
+// at or below retention cutoff is kept along with all versions after
+// it, so that graph stays available for every height from cutoff on
+func (n *Engine) prune() error {
+	latest := n.snapshots[len(n.snapshots)-1].height
+	if n.retention == 0 || latest <= n.retention {
+		return nil
+	}
+	full, _ := n.floorSnapshots(latest - n.retention)
+	if full <= 0 {
+		return nil
+	}
+	for _, ref := range n.snapshots[:full] {
+		if err := n.LocalBackend.Delete(snapshotKey(ref)); err != nil {
+			return err
+		}
+	}
+	n.log.Info("pruned pricing graph versions outside of retention",
+		"below", n.snapshots[full].height,
+		"versions", full)
+	n.snapshots = n.snapshots[full:]
+	return n.LocalBackend.Set(lb.KeyLowestPricingHeight, util.GobEncode(n.snapshots[0].height))
+}
+
+// Rollback drops pricing graph versions above height, stored while
+// resolving blocks orphaned by a chain reorganisation. Graph is built
+// from dumps again if no version at or below height is left
+func (n *Engine) Rollback(height uint64) error {
+	if err := n.loadSnapshotIndex(); err != nil {
+		return err
+	}
+	keep := sort.Search(len(n.snapshots), func(i int) bool {
+		return n.snapshots[i].height > height
+	})
+	if keep == len(n.snapshots) {
+		return nil
+	}
+	for _, ref := range n.snapshots[keep:] {
+		if err := n.LocalBackend.Delete(snapshotKey(ref)); err != nil {
+			return err
+		}
+	}
+	n.log.Info("dropped pricing graph versions of orphaned blocks",
+		"above", height,
+		"versions", len(n.snapshots)-keep)
+	n.snapshots = n.snapshots[:keep]
+	n.lastGraph = nil
+	n.blockTime = 0
+
+	if keep == 0 {
+		if err := n.LocalBackend.Delete(lb.KeyLatestHeight); err != nil {
+			return err
+		}
+		if err := n.LocalBackend.Delete(lb.KeyLowestPricingHeight); err != nil {
+			return err
+		}
+	} else {
+		latest := n.snapshots[keep-1].height
+		if err := n.LocalBackend.Set(lb.KeyLatestHeight, util.GobEncode(latest)); err != nil {
+			return err
+		}
+	}
+	return n.LocalBackend.Sync()
+}
+
+// graphDelta holds edges added or changed and edges removed since
+// graph as of previous stored height
+type graphDelta struct {
+	Edges   []we
+	Removed []addrTuple
+}
+
+// diffGraph returns changes to edges of graph since base
+func diffGraph(base *gg, graph *gg) graphDelta {
+	delta := graphDelta{Edges: []we{}, Removed: []addrTuple{}}
+	for from, connections := range graph.Graph {
+		baseConnections := base.Graph[from]
+		for to, edge := range connections {
+			if baseEdge, ok := baseConnections[to]; ok && reflect.DeepEqual(baseEdge, edge) {
+				continue
+			}
+			delta.Edges = append(delta.Edges, edge)
+		}
+	}
+	for from, baseConnections := range base.Graph {
+		for to := range baseConnections {
+			if _, ok := graph.Graph[from][to]; !ok {
+				delta.Removed = append(delta.Removed, addrTuple{First: from, Second: to})
+			}
+		}
+	}
+	return delta
+}
+
+// applyDelta makes changes of delta to graph
+func applyDelta(graph *gg, delta graphDelta) {
+	for _, edge := range delta.Removed {
+		if _, ok := graph.Graph[edge.First][edge.Second]; ok {
+			delete(graph.Graph[edge.First], edge.Second)
+			graph.EdgeCount--
+		}
+	}
+	for _, edge := range delta.Edges {
+		connections, ok := graph.Graph[edge.VertexFrom]
+		if !ok {
+			connections = make(gograph.Connections[common.Address, int64, string, interface{}])
+			graph.Graph[edge.VertexFrom] = connections
+		}
+		if _, ok := connections[edge.VertexTo]; !ok {
+			graph.EdgeCount++
+		}
+		connections[edge.VertexTo] = edge
+		if _, ok := graph.Graph[edge.VertexTo]; !ok {
+			graph.Graph[edge.VertexTo] = make(gograph.Connections[common.Address, int64, string, interface{}])
+		}
+	}
+	graph.VertexCount = len(graph.Graph)
+}
@@ -0,0 +1,309 @@
+package priceresolver
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/supragya/EtherScope/libs/gograph"
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/util"
+	lb "github.com/supragya/EtherScope/services/local_backend"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type memLB struct {
+	lb.LocalBackend
+	kv map[string][]byte
+}
+
+func (m *memLB) Get(key string) ([]byte, bool, error) {
+	val, ok := m.kv[key]
+	return val, ok, nil
+}
+
+func (m *memLB) Set(key string, val []byte) error {
+	m.kv[key] = val
+	return nil
+}
+
+func (m *memLB) Delete(key string) error {
+	delete(m.kv, key)
+	return nil
+}
+
+func (m *memLB) Sync() error { return nil }
+
+func (m *memLB) Iterate(prefix string, fn func(key string, val []byte) error) error {
+	keys := []string{}
+	for key := range m.kv {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := fn(key, m.kv[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGraphSnapshots(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &memLB{kv: map[string][]byte{}}
+	engine := &Engine{log: log, EthRPC: namesRPC{}, LocalBackend: backend}
+
+	tokenA := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	tokenB := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	withPool := func(graph *gg, token common.Address, usdRes float64) *gg {
+		graph = gograph.CopyGraph(graph)
+		meta := itypes.UniV2Metadata{
+			Token0: token,
+			Token1: USDTokenID,
+			Res0:   big.NewFloat(1000),
+			Res1:   big.NewFloat(usdRes),
+		}
+		if graph.AddWeightedEdge(token, USDTokenID, 1, "dex", meta) == gograph.ErrEdgeExists {
+			for _, edge := range []struct{ from, to common.Address }{{token, USDTokenID}, {USDTokenID, token}} {
+				e := graph.Graph[edge.from][edge.to]
+				e.Metadata = meta
+				graph.Graph[edge.from][edge.to] = e
+			}
+		}
+		return graph
+	}
+
+	// Full snapshot at 100, deltas at 101 and 105, then 103 out of order
+	g100 := withPool(gograph.NewGraph[common.Address, int64, string, interface{}](true), tokenA, 2000)
+	g101 := withPool(g100, tokenA, 3000)
+	g105 := withPool(g101, tokenB, 500)
+	g103 := withPool(g101, tokenA, 4000)
+	for _, step := range []struct {
+		graph, base *gg
+		height      uint64
+	}{{g100, nil, 100}, {g101, g100, 101}, {g105, g101, 105}, {g103, g101, 103}} {
+		if err := engine.storeSnapshot(step.graph, step.base, step.height); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := backend.kv[snapshotKey(snapshotRef{height: 103})]; !ok {
+		t.Fatal("expected delta stored at 103")
+	}
+
+	// Fresh engine rebuilds graphs from localbackend alone
+	reloaded := &Engine{log: log, EthRPC: namesRPC{}, LocalBackend: backend}
+	for _, tc := range []struct {
+		token  common.Address
+		height uint64
+		price  float64
+	}{
+		{tokenA, 100, 2},
+		{tokenA, 102, 3},
+		{tokenA, 103, 4},
+		{tokenA, 110, 4},
+		{tokenB, 105, 0.5},
+	} {
+		result, err := reloaded.PriceAt(tc.token, tc.height)
+		if err != nil {
+			t.Fatalf("price of %s at %d: %s", tc.token, tc.height, err)
+		}
+		if price, _ := result.Price.Float64(); price != tc.price {
+			t.Errorf("price of %s at %d: expected %v, got %v", tc.token, tc.height, tc.price, price)
+		}
+	}
+	if _, err := reloaded.PriceAt(tokenB, 104); err == nil {
+		t.Error("expected token without pool at 104 to stay unpriced")
+	}
+	if _, err := reloaded.graphAt(99); !errors.Is(err, ErrorResolutionBeforeLowestSnapshot) {
+		t.Errorf("expected ErrorResolutionBeforeLowestSnapshot, got %v", err)
+	}
+
+	var latest, lowest uint64
+	util.GobDecode(backend.kv[lb.KeyLatestHeight], &latest)
+	util.GobDecode(backend.kv[lb.KeyLowestPricingHeight], &lowest)
+	if latest != 105 || lowest != 100 {
+		t.Errorf("expected latest 105 and lowest 100, got %d and %d", latest, lowest)
+	}
+}
+
+func TestSnapshotDeltaRemovesEdges(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &memLB{kv: map[string][]byte{}}
+	engine := &Engine{log: log, EthRPC: namesRPC{}, LocalBackend: backend}
+
+	tokenA := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	tokenB := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	withPools := func(tokens ...common.Address) *gg {
+		graph := gograph.NewGraph[common.Address, int64, string, interface{}](true)
+		for _, token := range tokens {
+			graph.AddWeightedEdge(token, USDTokenID, 1, "dex", itypes.UniV2Metadata{
+				Token0: token,
+				Token1: USDTokenID,
+				Res0:   big.NewFloat(1000),
+				Res1:   big.NewFloat(2000),
+			})
+		}
+		return graph
+	}
+
+	// Pool of tokenB is gone by 101
+	g100 := withPools(tokenA, tokenB)
+	g101 := withPools(tokenA)
+	if err := engine.storeSnapshot(g100, nil, 100); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.storeSnapshot(g101, g100, 101); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := &Engine{log: log, EthRPC: namesRPC{}, LocalBackend: backend}
+	if _, err := reloaded.PriceAt(tokenB, 100); err != nil {
+		t.Fatalf("expected tokenB priced at 100, got %s", err)
+	}
+	graph, err := reloaded.graphAt(101)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := graph.Graph[tokenB][USDTokenID]; ok {
+		t.Error("expected removed edge to stay removed at 101")
+	}
+	if graph.EdgeCount != g101.EdgeCount {
+		t.Errorf("expected %d edges at 101, got %d", g101.EdgeCount, graph.EdgeCount)
+	}
+	if _, err := reloaded.PriceAt(tokenB, 101); err == nil {
+		t.Error("expected tokenB unpriced at 101")
+	}
+	if _, err := reloaded.PriceAt(tokenA, 101); err != nil {
+		t.Errorf("expected tokenA priced at 101, got %s", err)
+	}
+}
+
+func TestRollbackSnapshots(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &memLB{kv: map[string][]byte{}}
+	engine := &Engine{log: log, EthRPC: namesRPC{}, LocalBackend: backend}
+
+	token := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	var base *gg
+	for _, step := range []struct {
+		height uint64
+		usdRes float64
+	}{{100, 2000}, {101, 3000}, {102, 4000}, {103, 5000}} {
+		graph := gograph.NewGraph[common.Address, int64, string, interface{}](true)
+		graph.AddWeightedEdge(token, USDTokenID, 1, "dex", itypes.UniV2Metadata{
+			Token0: token,
+			Token1: USDTokenID,
+			Res0:   big.NewFloat(1000),
+			Res1:   big.NewFloat(step.usdRes),
+		})
+		if err := engine.storeSnapshot(graph, base, step.height); err != nil {
+			t.Fatal(err)
+		}
+		base = graph
+	}
+
+	// Versions of orphaned 102 and 103 are dropped
+	if err := engine.Rollback(101); err != nil {
+		t.Fatal(err)
+	}
+	for _, height := range []uint64{102, 103} {
+		if _, ok := backend.kv[snapshotKey(snapshotRef{height: height})]; ok {
+			t.Errorf("expected version at %d to be dropped", height)
+		}
+	}
+	result, err := engine.PriceAt(token, 103)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price, _ := result.Price.Float64(); price != 3 {
+		t.Errorf("expected price as of 101 after rollback, got %v", price)
+	}
+	var latest uint64
+	util.GobDecode(backend.kv[lb.KeyLatestHeight], &latest)
+	if latest != 101 {
+		t.Errorf("expected latest height 101, got %d", latest)
+	}
+
+	// Rolling back below lowest version leaves no pricing state
+	if err := engine.Rollback(99); err != nil {
+		t.Fatal(err)
+	}
+	if len(backend.kv) != 0 {
+		t.Errorf("expected no pricing state, got %d keys", len(backend.kv))
+	}
+	if _, ok, _ := engine.LowestHeight(); ok {
+		t.Error("expected no lowest height after rollback")
+	}
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &memLB{kv: map[string][]byte{}}
+	engine := &Engine{log: log, EthRPC: namesRPC{}, LocalBackend: backend}
+	engine.RetainSnapshots(120)
+
+	token := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	var base *gg
+	for height := uint64(1); height <= 250; height++ {
+		graph := gograph.NewGraph[common.Address, int64, string, interface{}](true)
+		graph.AddWeightedEdge(token, USDTokenID, 1, "dex", itypes.UniV2Metadata{
+			Token0: token,
+			Token1: USDTokenID,
+			Res0:   big.NewFloat(1000),
+			Res1:   big.NewFloat(float64(height * 1000)),
+		})
+		if err := engine.storeSnapshot(graph, base, height); err != nil {
+			t.Fatal(err)
+		}
+		base = graph
+	}
+
+	// Full snapshots are at 1, 102 and 203. Cutoff 130 needs the one
+	// at 102 and versions after it
+	for height := uint64(1); height < 102; height++ {
+		if _, ok := backend.kv[snapshotKey(snapshotRef{height: height, full: height == 1})]; ok {
+			t.Fatalf("expected version at %d to be pruned", height)
+		}
+	}
+	if _, ok := backend.kv[snapshotKey(snapshotRef{height: 102, full: true})]; !ok {
+		t.Fatal("expected full snapshot at 102 to be kept")
+	}
+	if lowest, ok, _ := engine.LowestHeight(); !ok || lowest != 102 {
+		t.Errorf("expected lowest height 102, got %d", lowest)
+	}
+	var stored uint64
+	util.GobDecode(backend.kv[lb.KeyLowestPricingHeight], &stored)
+	if stored != 102 {
+		t.Errorf("expected stored lowest height 102, got %d", stored)
+	}
+
+	// Fresh engine prices every height within retention
+	reloaded := &Engine{log: log, EthRPC: namesRPC{}, LocalBackend: backend}
+	result, err := reloaded.PriceAt(token, 130)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price, _ := result.Price.Float64(); price != 130 {
+		t.Errorf("expected price as of 130, got %v", price)
+	}
+	if _, err := reloaded.graphAt(101); !errors.Is(err, ErrorResolutionBeforeLowestSnapshot) {
+		t.Errorf("expected ErrorResolutionBeforeLowestSnapshot, got %v", err)
+	}
+}
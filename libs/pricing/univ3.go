@@ -0,0 +1,74 @@
+package priceresolver
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/supragya/EtherScope/libs/util"
+	itypes "github.com/supragya/EtherScope/types"
+)
+
+// q96 is 2**96, the fixed point scale of sqrtPriceX96
+var q96 = new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 96))
+
+// isUniV3Item tells if an item came from a uniswap v3 pool. Such
+// items are priced via sqrtPriceX96 and not via balance ratios
+func isUniV3Item(itemType string) bool {
+	return strings.HasPrefix(itemType, "uniswapv3")
+}
+
+// univ3VirtualReserves returns decimal adjusted virtual reserves of a
+// uniswap v3 pool backed by in-range liquidity L at sqrtPriceX96:
+// x = L / sqrtP, y = L * sqrtP where sqrtP = sqrtPriceX96 / 2**96.
+// Ratio y / x is the spot price of token0 in token1
+func univ3VirtualReserves(sqrtPriceX96 *big.Int,
+	liquidity *big.Int,
+	decimals0 uint8,
+	decimals1 uint8) (*big.Float, *big.Float) {
+	sqrtP := new(big.Float).Quo(new(big.Float).SetInt(sqrtPriceX96), q96)
+	l := new(big.Float).SetInt(liquidity)
+
+	x := new(big.Float).Quo(l, sqrtP)
+	y := new(big.Float).Mul(l, sqrtP)
+
+	x0, _ := x.Int(nil)
+	y1, _ := y.Int(nil)
+	return util.DivideBy10pow(x0, decimals0), util.DivideBy10pow(y1, decimals1)
+}
+
+// getUniV3Updates returns latest pool state for every token pair
+// seen in uniswap v3 swaps of given items
+func (n *Engine) getUniV3Updates(items []interface{}) map[addrTuple]itypes.UniV3Metadata {
+	updates := make(map[addrTuple]itypes.UniV3Metadata)
+
+	for _, item := range items {
+		swap, ok := item.(*itypes.Swap)
+		if !ok || !isUniV3Item(swap.Type) {
+			continue
+		}
+		state, ok := swap.ExtraData.(itypes.UniV3SwapExtraData)
+		if !ok {
+			continue
+		}
+		// Pools without in-range liquidity cannot provide a price
+		if state.SqrtPriceX96.Sign() <= 0 || state.Liquidity.Sign() <= 0 {
+			continue
+		}
+		res0, res1 := univ3VirtualReserves(state.SqrtPriceX96, state.Liquidity,
+			state.Decimals0, state.Decimals1)
+		if res0.Sign() == 0 || res1.Sign() == 0 {
+			continue
+		}
+		updates[addrTuple{First: swap.Token0, Second: swap.Token1}] = itypes.UniV3Metadata{
+			Pool:         swap.PairContract,
+			Token0:       swap.Token0,
+			Token1:       swap.Token1,
+			SqrtPriceX96: state.SqrtPriceX96,
+			Liquidity:    state.Liquidity,
+			Res0:         res0,
+			Res1:         res1,
+		}
+	}
+
+	return updates
+}
@@ -0,0 +1,34 @@
+package priceresolver
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestUniV3VirtualReserves(t *testing.T) {
+	// sqrtP = 2, raw price 4; liquidity 1e20
+	sqrtPriceX96 := new(big.Int).Lsh(big.NewInt(2), 96)
+	liquidity, _ := new(big.Int).SetString("100000000000000000000", 10)
+
+	res0, res1 := univ3VirtualReserves(sqrtPriceX96, liquidity, 6, 18)
+
+	// x = L / sqrtP = 5e19 raw, y = L * sqrtP = 2e20 raw
+	if v, _ := res0.Float64(); v != 5e13 {
+		t.Errorf("unexpected res0: %v", v)
+	}
+	if v, _ := res1.Float64(); v != 200 {
+		t.Errorf("unexpected res1: %v", v)
+	}
+
+	// spot price adjusted by decimals: 4 * 10^(6-18)
+	price, _ := new(big.Float).Quo(res1, res0).Float64()
+	if price < 3.9999e-12 || price > 4.0001e-12 {
+		t.Errorf("unexpected spot price: %v", price)
+	}
+}
+
+func TestIsUniV3Item(t *testing.T) {
+	if !isUniV3Item("uniswapv3swap") || isUniV3Item("uniswapv2swap") {
+		t.Error("wrong univ3 item detection")
+	}
+}
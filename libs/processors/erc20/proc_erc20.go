@@ -60,8 +60,7 @@ func (n *ERC20Processor) ProcessERC20Transfer(
 		TxSender: txSender,
 		Receiver: recv,
 		Amount:   formattedAmount,
-		// AmountUSD:           tokenPrice.Price,
-		// PriceDerivationMeta: tokenPrice,
+		// AmountUSD and PriceDerivationMeta are set by pricing engine
 	}
 
 	items[idx] = &transfer
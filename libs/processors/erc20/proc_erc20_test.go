@@ -0,0 +1,55 @@
+package erc20
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestProcessTransferReplayed(t *testing.T) {
+	log, err := logger.NewDefaultLogger("error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpc := ethrpc.NewFixtureReplayer(log, "../../../testdata/erc20TransferFixture.json")
+	if err := rpc.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile("../../../testdata/erc20TransferExample.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := types.Log{}
+	if err := json.Unmarshal(data, &l); err != nil {
+		t.Fatal(err)
+	}
+
+	topics := map[common.Hash]itypes.ProcessingType{itypes.ERC20TransferTopic: itypes.UserRequested}
+	proc, _ := New(topics, rpc)
+	items := make([]interface{}, 1)
+	if err := proc.Process(l, items, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	transfer, ok := items[0].(*itypes.Transfer)
+	if !ok {
+		t.Fatalf("expected transfer, got %v", items[0])
+	}
+	if transfer.Amount.Text('f', 18) != "234.816977978804519221" {
+		t.Errorf("unexpected amount %s", transfer.Amount.Text('f', 18))
+	}
+	if transfer.TxSender != common.HexToAddress("0x56178a0d5f301baf6cf3e1cd53d9863437345bf9") {
+		t.Errorf("unexpected tx sender %s", transfer.TxSender)
+	}
+	if transfer.Height != 15231029 {
+		t.Errorf("unexpected height %d", transfer.Height)
+	}
+}
@@ -0,0 +1,40 @@
+package erc20
+
+import (
+	"github.com/supragya/EtherScope/libs/processors"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	"github.com/supragya/EtherScope/services/instrumentation"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func init() {
+	processors.Register("erc20", New)
+}
+
+// New creates a processor for erc20 token transfers
+func New(topics map[common.Hash]itypes.ProcessingType, rpc ethrpc.EthRPC) (processors.Processor, error) {
+	return &ERC20Processor{topics, rpc}, nil
+}
+
+func (n *ERC20Processor) HandledTopics() []common.Hash {
+	return []common.Hash{itypes.ERC20TransferTopic}
+}
+
+func (n *ERC20Processor) PricingTopics() []common.Hash {
+	return []common.Hash{}
+}
+
+func (n *ERC20Processor) Process(l types.Log,
+	items []interface{},
+	idx int,
+	blockTime uint64,
+) error {
+	switch l.Topics[0] {
+	case itypes.ERC20TransferTopic:
+		instrumentation.TfrFound.Inc()
+		return n.ProcessERC20Transfer(l, items, idx, blockTime)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package generic
+
+import (
+	cfg "github.com/supragya/EtherScope/libs/config"
+)
+
+var (
+	GenericCFGSection   = "genericevents"
+	GenericCFGNecessity = "needed if `node.processors` contains `generic`"
+	GenericCFGHeader    = cfg.SArr("genericevents decodes events of arbitrary contracts",
+		"using user provided ABIs. decoded events are presented to",
+		"output sink as generic events with named, typed fields.",
+		"events listed here are to be requested in",
+		"`node.eventsToIndex` as `Generic<EventName>`,",
+		"e.g. `GenericDeposit`")
+	GenericCFGFields = [...]cfg.Field{
+		{
+			Name:      "contracts",
+			Type:      "[]contract",
+			Necessity: "always needed",
+			Info: cfg.SArr("contracts to decode events for. each entry needs",
+				"`address` of the contract, `abi` path to ABI json",
+				"(plain array as emitted by solc) and `events`, names",
+				"of ABI events to decode. a topic can be handled by",
+				"one processor only, disable native processors",
+				"(e.g. `erc20`) if decoding their events generically"),
+			Default: "\n    - address: \"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2\"" +
+				"\n      abi: /etc/abis/weth.json" +
+				"\n      events:\n      - Deposit\n      - Withdrawal",
+		},
+	}
+)
@@ -0,0 +1,132 @@
+package generic
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/supragya/EtherScope/services/ethrpc"
+	"github.com/supragya/EtherScope/services/instrumentation"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrIndexedMismatch = errors.New("IndexedTopicsMismatchError")
+)
+
+type GenericProcessor struct {
+	Topics map[common.Hash]itypes.ProcessingType
+	EthRPC ethrpc.EthRPC
+
+	decoders      map[contractEvent]*eventDecoder
+	handledTopics []common.Hash
+}
+
+// contractEvent identifies an event of a particular contract. Same
+// event signature may be decoded differently across contracts as
+// indexed inputs are not part of the signature
+type contractEvent struct {
+	contract common.Address
+	topic    common.Hash
+}
+
+type eventDecoder struct {
+	event   abi.Event
+	indexed abi.Arguments
+}
+
+func newEventDecoder(event abi.Event) *eventDecoder {
+	indexed := abi.Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	return &eventDecoder{event, indexed}
+}
+
+func (n *GenericProcessor) Process(l types.Log,
+	items []interface{},
+	idx int,
+	blockTime uint64,
+) error {
+	prcType, ok := n.Topics[l.Topics[0]]
+	if !ok {
+		return nil
+	}
+	decoder, ok := n.decoders[contractEvent{l.Address, l.Topics[0]}]
+	if !ok {
+		// Same signature emitted by some other contract
+		return nil
+	}
+	instrumentation.GenericEventFound.Inc()
+
+	fields, err := decoder.decode(l)
+	if err != nil {
+		// Log does not conform to the ABI, retrying won't help
+		instrumentation.GenericEventUndecodable.Inc()
+		return nil
+	}
+
+	items[idx] = &itypes.GenericEvent{
+		Type:           "generic",
+		ProcessingType: prcType,
+		LogIdx:         l.Index,
+		Transaction:    l.TxHash,
+		Time:           blockTime,
+		Height:         l.BlockNumber,
+		Contract:       l.Address,
+		Event:          decoder.event.Name,
+		Signature:      decoder.event.Sig,
+		Fields:         fields,
+	}
+	instrumentation.GenericEventProcessed.Inc()
+	return nil
+}
+
+// decode unpacks indexed inputs from topics and rest from data of l,
+// returning fields in order of ABI inputs
+func (d *eventDecoder) decode(l types.Log) ([]itypes.GenericField, error) {
+	if len(l.Topics)-1 != len(d.indexed) {
+		return nil, ErrIndexedMismatch
+	}
+	values := make(map[string]interface{}, len(d.event.Inputs))
+	if err := d.event.Inputs.UnpackIntoMap(values, l.Data); err != nil {
+		return nil, err
+	}
+	if err := abi.ParseTopicsIntoMap(values, d.indexed, l.Topics[1:]); err != nil {
+		return nil, err
+	}
+
+	fields := make([]itypes.GenericField, len(d.event.Inputs))
+	for i, input := range d.event.Inputs {
+		fields[i] = itypes.GenericField{
+			Name:    input.Name,
+			Type:    input.Type.String(),
+			Indexed: input.Indexed,
+			Value:   normalizeValue(values[input.Name]),
+		}
+	}
+	return fields, nil
+}
+
+// normalizeValue converts byte arrays and slices to hex for sane
+// serialization, leaving other values untouched
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return hexutil.Bytes(v)
+	case common.Hash, common.Address:
+		return v
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.Bytes(b)
+	}
+	return value
+}
@@ -0,0 +1,104 @@
+package generic
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/viper"
+)
+
+const wethABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"dst","type":"address"},{"indexed":false,"name":"wad","type":"uint256"}],"name":"Deposit","type":"event"}]`
+
+var weth = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+
+func setupProcessor(t *testing.T) *GenericProcessor {
+	abiFile := filepath.Join(t.TempDir(), "weth.json")
+	if err := os.WriteFile(abiFile, []byte(wethABI), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set(GenericCFGSection+".contracts", []interface{}{
+		map[string]interface{}{
+			"address": weth.Hex(),
+			"abi":     abiFile,
+			"events":  []interface{}{"Deposit"},
+		},
+	})
+
+	topics := make(map[common.Hash]itypes.ProcessingType)
+	proc, err := New(topics, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic, ok := itypes.GetTopicForString("GenericDeposit")
+	if !ok {
+		t.Fatal("GenericDeposit topic not registered")
+	}
+	topics[topic] = itypes.UserRequested
+	return proc.(*GenericProcessor)
+}
+
+func TestProcessDeposit(t *testing.T) {
+	proc := setupProcessor(t)
+	topic := proc.HandledTopics()[0]
+	dst := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	items := make([]interface{}, 1)
+	err := proc.Process(types.Log{
+		Address:     weth,
+		Topics:      []common.Hash{topic, common.BytesToHash(dst.Bytes())},
+		Data:        common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+		BlockNumber: 10,
+		Index:       3,
+	}, items, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event, ok := items[0].(*itypes.GenericEvent)
+	if !ok {
+		t.Fatalf("expected generic event, got %v", items[0])
+	}
+	if event.Event != "Deposit" || event.Signature != "Deposit(address,uint256)" || event.Height != 10 {
+		t.Errorf("unexpected event metadata: %+v", event)
+	}
+	if len(event.Fields) != 2 {
+		t.Fatalf("expected 2 fields, got %d", len(event.Fields))
+	}
+	if f := event.Fields[0]; f.Name != "dst" || !f.Indexed || f.Value != dst {
+		t.Errorf("unexpected dst field: %+v", f)
+	}
+	if f := event.Fields[1]; f.Name != "wad" || f.Type != "uint256" || f.Value.(*big.Int).Int64() != 1000 {
+		t.Errorf("unexpected wad field: %+v", f)
+	}
+}
+
+func TestProcessIgnoresOtherContracts(t *testing.T) {
+	proc := setupProcessor(t)
+	topic := proc.HandledTopics()[0]
+
+	items := make([]interface{}, 1)
+	err := proc.Process(types.Log{
+		Address: common.HexToAddress("0x01"),
+		Topics:  []common.Hash{topic, {}},
+		Data:    common.LeftPadBytes(big.NewInt(1).Bytes(), 32),
+	}, items, 0, 100)
+	if err != nil || items[0] != nil {
+		t.Errorf("expected log to be ignored, got %v, %v", items[0], err)
+	}
+
+	// Malformed log from configured contract is dropped
+	err = proc.Process(types.Log{
+		Address: weth,
+		Topics:  []common.Hash{topic},
+	}, items, 0, 100)
+	if err != nil || items[0] != nil {
+		t.Errorf("expected malformed log to be dropped, got %v, %v", items[0], err)
+	}
+}
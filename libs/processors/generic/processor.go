@@ -0,0 +1,114 @@
+package generic
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	cfg "github.com/supragya/EtherScope/libs/config"
+	"github.com/supragya/EtherScope/libs/processors"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	processors.Register("generic", New)
+}
+
+// contractSpec is a single entry of `genericevents.contracts`
+type contractSpec struct {
+	Address string   `mapstructure:"address"`
+	ABI     string   `mapstructure:"abi"`
+	Events  []string `mapstructure:"events"`
+}
+
+// New creates a processor for events of contracts configured in
+// `genericevents` section. Absent section yields a processor
+// handling no topics
+func New(topics map[common.Hash]itypes.ProcessingType, rpc ethrpc.EthRPC) (processors.Processor, error) {
+	proc := &GenericProcessor{
+		Topics:   topics,
+		EthRPC:   rpc,
+		decoders: make(map[contractEvent]*eventDecoder),
+	}
+	if !viper.IsSet(GenericCFGSection) {
+		return proc, nil
+	}
+	for _, mf := range GenericCFGFields {
+		if err := cfg.EnsureFieldIntegrity(GenericCFGSection, mf); err != nil {
+			return nil, err
+		}
+	}
+
+	var specs []contractSpec
+	if err := viper.UnmarshalKey(GenericCFGSection+".contracts", &specs); err != nil {
+		return nil, fmt.Errorf("invalid %s.contracts: %w", GenericCFGSection, err)
+	}
+	for _, spec := range specs {
+		if err := proc.addContract(spec); err != nil {
+			return nil, err
+		}
+	}
+	return proc, nil
+}
+
+// addContract loads ABI of spec and sets up decoders for its events
+func (n *GenericProcessor) addContract(spec contractSpec) error {
+	if !common.IsHexAddress(spec.Address) {
+		return fmt.Errorf("invalid contract address: %s", spec.Address)
+	}
+	address := common.HexToAddress(spec.Address)
+
+	content, err := os.ReadFile(spec.ABI)
+	if err != nil {
+		return fmt.Errorf("error reading abi for %s: %w", spec.Address, err)
+	}
+	contractABI, err := abi.JSON(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("error parsing abi %s: %w", spec.ABI, err)
+	}
+
+	for _, name := range spec.Events {
+		event, ok := contractABI.Events[name]
+		if !ok {
+			return fmt.Errorf("event %s not found in abi %s", name, spec.ABI)
+		}
+		if event.Anonymous {
+			return fmt.Errorf("anonymous event %s in abi %s cannot be indexed", name, spec.ABI)
+		}
+		topicName := "Generic" + event.Name
+		if topic, ok := itypes.GetTopicForString(topicName); ok && topic != event.ID {
+			return fmt.Errorf("conflicting signatures for %s: %s differs from one seen earlier",
+				topicName, event.Sig)
+		}
+		itypes.RegisterTopic(event.Sig, topicName)
+
+		key := contractEvent{address, event.ID}
+		if _, ok := n.decoders[key]; ok {
+			return fmt.Errorf("event %s listed twice for %s", name, spec.Address)
+		}
+		n.decoders[key] = newEventDecoder(event)
+		n.addHandledTopic(event.ID)
+	}
+	return nil
+}
+
+func (n *GenericProcessor) addHandledTopic(topic common.Hash) {
+	for _, t := range n.handledTopics {
+		if t == topic {
+			return
+		}
+	}
+	n.handledTopics = append(n.handledTopics, topic)
+}
+
+func (n *GenericProcessor) HandledTopics() []common.Hash {
+	return n.handledTopics
+}
+
+func (n *GenericProcessor) PricingTopics() []common.Hash {
+	return []common.Hash{}
+}
@@ -0,0 +1,65 @@
+package processors
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/supragya/EtherScope/services/ethrpc"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Processor decodes ethereum logs of a protocol into items
+// presented to output sink
+type Processor interface {
+	// HandledTopics returns all log topics handled by this processor
+	HandledTopics() []common.Hash
+
+	// PricingTopics returns subset of HandledTopics which are needed by
+	// pricing engine irrespective of user requested events
+	PricingTopics() []common.Hash
+
+	// Process decodes log l and places resulting item (if any)
+	// at items[idx]
+	Process(l types.Log, items []interface{}, idx int, blockTime uint64) error
+}
+
+// Constructor creates a processor. topics is shared with the node and
+// tells the processing type for every enabled topic; topics absent
+// from it are to be ignored by the processor. Processors needing
+// config should not fail if their config section is absent
+type Constructor func(topics map[common.Hash]itypes.ProcessingType, rpc ethrpc.EthRPC) (Processor, error)
+
+var registry = make(map[string]Constructor)
+
+// Register makes a processor available by name. Meant to be called
+// from init() of the package implementing the processor
+func Register(name string, ctor Constructor) {
+	if _, ok := registry[name]; ok {
+		panic("processor registered twice: " + name)
+	}
+	registry[name] = ctor
+}
+
+// New creates processor registered as name
+func New(name string,
+	topics map[common.Hash]itypes.ProcessingType,
+	rpc ethrpc.EthRPC) (Processor, error) {
+	ctor, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown processor: %s", name)
+	}
+	return ctor(topics, rpc)
+}
+
+// Registered returns names of all registered processors in
+// sorted order
+func Registered() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
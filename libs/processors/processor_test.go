@@ -0,0 +1,44 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/supragya/EtherScope/services/ethrpc"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type nopProcessor struct{}
+
+func (nopProcessor) HandledTopics() []common.Hash { return nil }
+func (nopProcessor) PricingTopics() []common.Hash { return nil }
+func (nopProcessor) Process(types.Log, []interface{}, int, uint64) error {
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	ctor := func(map[common.Hash]itypes.ProcessingType, ethrpc.EthRPC) (Processor, error) {
+		return nopProcessor{}, nil
+	}
+	Register("zz-nop", ctor)
+	Register("aa-nop", ctor)
+
+	names := Registered()
+	if len(names) != 2 || names[0] != "aa-nop" || names[1] != "zz-nop" {
+		t.Errorf("unexpected registered processors: %v", names)
+	}
+	if _, err := New("aa-nop", nil, nil); err != nil {
+		t.Error(err)
+	}
+	if _, err := New("unknown", nil, nil); err == nil {
+		t.Error("expected error for unknown processor")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on duplicate registration")
+		}
+	}()
+	Register("aa-nop", ctor)
+}
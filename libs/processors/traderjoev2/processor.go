@@ -0,0 +1,40 @@
+package traderjoev2
+
+import (
+	"github.com/supragya/EtherScope/libs/processors"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	"github.com/supragya/EtherScope/services/instrumentation"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func init() {
+	processors.Register("traderjoev2", New)
+}
+
+// New creates a processor for trader joe v2 pools
+func New(topics map[common.Hash]itypes.ProcessingType, rpc ethrpc.EthRPC) (processors.Processor, error) {
+	return &TraderJoeProcessor{topics, rpc}, nil
+}
+
+func (n *TraderJoeProcessor) HandledTopics() []common.Hash {
+	return []common.Hash{itypes.TraderJoeV2SwapTopic}
+}
+
+func (n *TraderJoeProcessor) PricingTopics() []common.Hash {
+	return []common.Hash{}
+}
+
+func (n *TraderJoeProcessor) Process(l types.Log,
+	items []interface{},
+	idx int,
+	blockTime uint64,
+) error {
+	switch l.Topics[0] {
+	case itypes.TraderJoeV2SwapTopic:
+		instrumentation.TraderJoeV2SwapFound.Inc()
+		return n.ProcessTokenExchange(l, items, idx, blockTime)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package uniswapv2
+
+import (
+	"github.com/supragya/EtherScope/libs/processors"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	"github.com/supragya/EtherScope/services/instrumentation"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func init() {
+	processors.Register("uniswapv2", New)
+}
+
+// New creates a processor for uniswap v2 pairs
+func New(topics map[common.Hash]itypes.ProcessingType, rpc ethrpc.EthRPC) (processors.Processor, error) {
+	return &UniswapV2Processor{topics, rpc}, nil
+}
+
+func (n *UniswapV2Processor) HandledTopics() []common.Hash {
+	return []common.Hash{itypes.UniV2MintTopic,
+		itypes.UniV2BurnTopic,
+		itypes.UniV2SwapTopic}
+}
+
+func (n *UniswapV2Processor) PricingTopics() []common.Hash {
+	return []common.Hash{itypes.UniV2MintTopic,
+		itypes.UniV2BurnTopic,
+		itypes.UniV2SwapTopic}
+}
+
+func (n *UniswapV2Processor) Process(l types.Log,
+	items []interface{},
+	idx int,
+	blockTime uint64,
+) error {
+	switch l.Topics[0] {
+	case itypes.UniV2MintTopic:
+		instrumentation.MintV2Found.Inc()
+		return n.ProcessUniV2Mint(l, items, idx, blockTime)
+	case itypes.UniV2BurnTopic:
+		instrumentation.BurnV2Found.Inc()
+		return n.ProcessUniV2Burn(l, items, idx, blockTime)
+	case itypes.UniV2SwapTopic:
+		instrumentation.SwapV2Found.Inc()
+		return n.ProcessUniV2Swap(l, items, idx, blockTime)
+	}
+	return nil
+}
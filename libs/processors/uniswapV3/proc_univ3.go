@@ -326,6 +326,17 @@ func (n *UniswapV3Processor) ProcessUniV3Swap(
 		swap.Amount1 = util.DivideBy10pow(am1, token1decimals)
 	}
 
+	// Fill up pool state needed by pricing engine
+	if ok, sqrtPriceX96, liquidity, tick := InfoUniV3SwapState(l); ok {
+		swap.ExtraData = itypes.UniV3SwapExtraData{
+			SqrtPriceX96: sqrtPriceX96,
+			Liquidity:    liquidity,
+			Tick:         tick,
+			Decimals0:    token0decimals,
+			Decimals1:    token1decimals,
+		}
+	}
+
 	items[idx] = &swap
 
 	instrumentation.SwapV3Processed.Inc()
@@ -404,3 +415,19 @@ func InfoUniV3Swap(l types.Log) (hasSufficientData bool,
 		util.ExtractIntFromBytes(l.Data[:32]),
 		util.ExtractIntFromBytes(l.Data[32:64])
 }
+
+func InfoUniV3SwapState(l types.Log) (hasSufficientData bool,
+	sqrtPriceX96 *big.Int,
+	liquidity *big.Int,
+	tick *big.Int) {
+	if !util.HasSufficientData(l, 3, 160) {
+		return false,
+			big.NewInt(0),
+			big.NewInt(0),
+			big.NewInt(0)
+	}
+	return true,
+		new(big.Int).SetBytes(l.Data[64:96]),
+		new(big.Int).SetBytes(l.Data[96:128]),
+		util.ExtractIntFromBytes(l.Data[128:160])
+}
@@ -0,0 +1,48 @@
+package uniswapv2
+
+import (
+	"github.com/supragya/EtherScope/libs/processors"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	"github.com/supragya/EtherScope/services/instrumentation"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func init() {
+	processors.Register("uniswapv3", New)
+}
+
+// New creates a processor for uniswap v3 pools
+func New(topics map[common.Hash]itypes.ProcessingType, rpc ethrpc.EthRPC) (processors.Processor, error) {
+	return &UniswapV3Processor{topics, rpc}, nil
+}
+
+func (n *UniswapV3Processor) HandledTopics() []common.Hash {
+	return []common.Hash{itypes.UniV3MintTopic,
+		itypes.UniV3BurnTopic,
+		itypes.UniV3SwapTopic}
+}
+
+func (n *UniswapV3Processor) PricingTopics() []common.Hash {
+	return []common.Hash{itypes.UniV3SwapTopic}
+}
+
+func (n *UniswapV3Processor) Process(l types.Log,
+	items []interface{},
+	idx int,
+	blockTime uint64,
+) error {
+	switch l.Topics[0] {
+	case itypes.UniV3MintTopic:
+		instrumentation.MintV3Found.Inc()
+		return n.ProcessUniV3Mint(l, items, idx, blockTime)
+	case itypes.UniV3BurnTopic:
+		instrumentation.BurnV3Found.Inc()
+		return n.ProcessUniV3Burn(l, items, idx, blockTime)
+	case itypes.UniV3SwapTopic:
+		instrumentation.SwapV3Found.Inc()
+		return n.ProcessUniV3Swap(l, items, idx, blockTime)
+	}
+	return nil
+}
@@ -15,14 +15,20 @@ type EthRPC interface {
 	service.Service
 
 	GetTxSender(txHash, blockHash common.Hash, txIdx uint) (common.Address, error)
+	GetChainID() (uint64, error)
 	GetCurrentBlockHeight() (uint64, error)
 	GetBlockTimestamp(height uint64) (uint64, error)
+	GetBlockHeader(height uint64) (*types.Header, error)
+	SubscribeNewHeads(ch chan<- *types.Header) (ethereum.Subscription, error)
 	GetFilteredLogs(ethereum.FilterQuery) ([]types.Log, error)
+	GetBlockReceipts(height uint64) ([]*types.Receipt, error)
+	GetBlockWithSenders(height uint64) (*types.Block, []common.Address, error)
 	GetTokensUniV2(common.Address, *bind.CallOpts) (common.Address, common.Address, error)
 	GetERC20Decimals(common.Address, *bind.CallOpts) (uint8, error)
 	GetERC20Balances(requests []itypes.Tuple2[common.Address, common.Address],
 		callopts *bind.CallOpts) ([]*big.Int, error)
 	GetERC20Name(common.Address, *bind.CallOpts) (string, error)
+	GetERC20Symbol(common.Address, *bind.CallOpts) (string, error)
 	GetTokensUniV3(pairContract common.Address,
 		callopts *bind.CallOpts) (common.Address, common.Address, error)
 	GetTokensUniV3NFT(nftContract common.Address, tokenID *big.Int, callopts *bind.CallOpts) (common.Address, common.Address, error)
@@ -0,0 +1,492 @@
+package ethrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	cfg "github.com/supragya/EtherScope/libs/config"
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/service"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/viper"
+)
+
+var (
+	EthRPCFixtureCFGSection   = "ethRPCFixture"
+	EthRPCFixtureCFGNecessity = "needed if `node.ethRPCType` == record or replay"
+	EthRPCFixtureCFGHeader    = cfg.SArr("fixture ethrpc records requests made to",
+		"mspool ethrpc along with responses into a fixture file",
+		"(`record`), or serves requests from such a file without",
+		"any network access (`replay`). meant for deterministic",
+		"offline runs of indexer, e.g. in tests")
+	EthRPCFixtureCFGFields = [...]cfg.Field{
+		{
+			Name:      "file",
+			Type:      "string",
+			Necessity: "always needed",
+			Info: cfg.SArr("fixture file to record into or replay from. on",
+				"record, file is written when ethrpc is stopped"),
+			Default: "ethrpc.fixture.json",
+		},
+	}
+)
+
+const EthRPCFixtureVersion = 1
+
+// EthRPCFixture is the format of fixture files. Calls are kept in the
+// order they were made
+type EthRPCFixture struct {
+	Version int           `json:"version"`
+	Calls   []FixtureCall `json:"calls"`
+}
+
+// FixtureCall is a single EthRPC call. Args are JSON encoded arguments
+// of the call, with callopts reduced to block number queried at
+type FixtureCall struct {
+	Method string          `json:"method"`
+	Args   json.RawMessage `json:"args"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// fixtureBlock is JSON encodable form of types.Block
+type fixtureBlock struct {
+	Header       *types.Header        `json:"header"`
+	Transactions []*types.Transaction `json:"transactions"`
+	Uncles       []*types.Header      `json:"uncles"`
+}
+
+func fixtureArgs(args ...interface{}) (json.RawMessage, error) {
+	if args == nil {
+		args = []interface{}{}
+	}
+	return json.Marshal(args)
+}
+
+// fixtureKey identifies calls with same method and args. Hex encodings
+// are compared case insensitively so that fixtures can be hand written
+func fixtureKey(method string, args json.RawMessage) string {
+	compact := bytes.Buffer{}
+	if err := json.Compact(&compact, args); err != nil {
+		return method + string(args)
+	}
+	return method + strings.ToLower(compact.String())
+}
+
+func blockOf(callopts *bind.CallOpts) *big.Int {
+	if callopts == nil {
+		return nil
+	}
+	return callopts.BlockNumber
+}
+
+// FixtureRecorder is an EthRPC passing calls through to an underlying
+// EthRPC and recording them into a fixture file
+type FixtureRecorder struct {
+	service.BaseService
+
+	log  logger.Logger
+	rpc  EthRPC
+	file string
+
+	mu    sync.Mutex
+	calls []FixtureCall
+}
+
+func NewFixtureRecorder(log logger.Logger, rpc EthRPC, file string) *FixtureRecorder {
+	r := &FixtureRecorder{log: log, rpc: rpc, file: file}
+	r.BaseService = *service.NewBaseService(log, "ethrpc", r)
+	return r
+}
+
+func NewFixtureRecorderWithViperFields(log logger.Logger, rpc EthRPC) (EthRPC, error) {
+	for _, mf := range EthRPCFixtureCFGFields {
+		if err := cfg.EnsureFieldIntegrity(EthRPCFixtureCFGSection, mf); err != nil {
+			return nil, err
+		}
+	}
+	return NewFixtureRecorder(log, rpc, viper.GetString(EthRPCFixtureCFGSection+".file")), nil
+}
+
+// OnStart starts the underlying EthRPC. It implements service.Service
+func (r *FixtureRecorder) OnStart(ctx context.Context) error {
+	return r.rpc.Start(ctx)
+}
+
+// OnStop stops the underlying EthRPC and writes fixture file. It
+// implements service.Service
+func (r *FixtureRecorder) OnStop() {
+	r.rpc.Stop()
+	if err := r.Save(); err != nil {
+		r.log.Error("could not write ethrpc fixture", "fixture", r.file, "error", err)
+		return
+	}
+	r.log.Info("wrote ethrpc fixture", "fixture", r.file, "calls", len(r.calls))
+}
+
+// Save writes calls recorded so far into fixture file
+func (r *FixtureRecorder) Save() error {
+	r.mu.Lock()
+	fixture := EthRPCFixture{Version: EthRPCFixtureVersion, Calls: r.calls}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.file, data, 0644)
+}
+
+// record remembers call, returning err as is. Calls whose response
+// cannot be encoded are not recorded
+func (r *FixtureRecorder) record(method string, result interface{}, err error, args ...interface{}) error {
+	call := FixtureCall{Method: method}
+	var encErr error
+	if call.Args, encErr = fixtureArgs(args...); encErr == nil {
+		if err != nil {
+			call.Error = err.Error()
+		} else {
+			call.Result, encErr = json.Marshal(result)
+		}
+	}
+	if encErr != nil {
+		r.log.Warn("could not record ethrpc call", "method", method, "error", encErr)
+		return err
+	}
+	r.mu.Lock()
+	r.calls = append(r.calls, call)
+	r.mu.Unlock()
+	return err
+}
+
+func (r *FixtureRecorder) GetTxSender(txHash, blockHash common.Hash, txIdx uint) (common.Address, error) {
+	out, err := r.rpc.GetTxSender(txHash, blockHash, txIdx)
+	return out, r.record("GetTxSender", out, err, txHash, blockHash, txIdx)
+}
+
+func (r *FixtureRecorder) GetChainID() (uint64, error) {
+	out, err := r.rpc.GetChainID()
+	return out, r.record("GetChainID", out, err)
+}
+
+func (r *FixtureRecorder) GetCurrentBlockHeight() (uint64, error) {
+	out, err := r.rpc.GetCurrentBlockHeight()
+	return out, r.record("GetCurrentBlockHeight", out, err)
+}
+
+func (r *FixtureRecorder) GetBlockTimestamp(height uint64) (uint64, error) {
+	out, err := r.rpc.GetBlockTimestamp(height)
+	return out, r.record("GetBlockTimestamp", out, err, height)
+}
+
+func (r *FixtureRecorder) GetBlockHeader(height uint64) (*types.Header, error) {
+	out, err := r.rpc.GetBlockHeader(height)
+	return out, r.record("GetBlockHeader", out, err, height)
+}
+
+// SubscribeNewHeads is passed through as is, headers are not recorded
+func (r *FixtureRecorder) SubscribeNewHeads(ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return r.rpc.SubscribeNewHeads(ch)
+}
+
+func (r *FixtureRecorder) GetFilteredLogs(fq ethereum.FilterQuery) ([]types.Log, error) {
+	out, err := r.rpc.GetFilteredLogs(fq)
+	return out, r.record("GetFilteredLogs", out, err, fq)
+}
+
+func (r *FixtureRecorder) GetBlockReceipts(height uint64) ([]*types.Receipt, error) {
+	out, err := r.rpc.GetBlockReceipts(height)
+	return out, r.record("GetBlockReceipts", out, err, height)
+}
+
+func (r *FixtureRecorder) GetBlockWithSenders(height uint64) (*types.Block, []common.Address, error) {
+	block, senders, err := r.rpc.GetBlockWithSenders(height)
+	var out itypes.Tuple2[fixtureBlock, []common.Address]
+	if err == nil {
+		out = itypes.Tuple2[fixtureBlock, []common.Address]{
+			First: fixtureBlock{
+				Header:       block.Header(),
+				Transactions: block.Transactions(),
+				Uncles:       block.Uncles(),
+			},
+			Second: senders,
+		}
+	}
+	return block, senders, r.record("GetBlockWithSenders", out, err, height)
+}
+
+func (r *FixtureRecorder) GetTokensUniV2(pairContract common.Address, callopts *bind.CallOpts) (common.Address, common.Address, error) {
+	t0, t1, err := r.rpc.GetTokensUniV2(pairContract, callopts)
+	out := itypes.Tuple2[common.Address, common.Address]{First: t0, Second: t1}
+	return t0, t1, r.record("GetTokensUniV2", out, err, pairContract, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) GetERC20Decimals(erc20Address common.Address, callopts *bind.CallOpts) (uint8, error) {
+	out, err := r.rpc.GetERC20Decimals(erc20Address, callopts)
+	return out, r.record("GetERC20Decimals", out, err, erc20Address, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) GetERC20Balances(requests []itypes.Tuple2[common.Address, common.Address],
+	callopts *bind.CallOpts) ([]*big.Int, error) {
+	out, err := r.rpc.GetERC20Balances(requests, callopts)
+	return out, r.record("GetERC20Balances", out, err, requests, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) GetERC20Name(erc20Address common.Address, callopts *bind.CallOpts) (string, error) {
+	out, err := r.rpc.GetERC20Name(erc20Address, callopts)
+	return out, r.record("GetERC20Name", out, err, erc20Address, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) GetERC20Symbol(erc20Address common.Address, callopts *bind.CallOpts) (string, error) {
+	out, err := r.rpc.GetERC20Symbol(erc20Address, callopts)
+	return out, r.record("GetERC20Symbol", out, err, erc20Address, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) GetTokensUniV3(pairContract common.Address, callopts *bind.CallOpts) (common.Address, common.Address, error) {
+	t0, t1, err := r.rpc.GetTokensUniV3(pairContract, callopts)
+	out := itypes.Tuple2[common.Address, common.Address]{First: t0, Second: t1}
+	return t0, t1, r.record("GetTokensUniV3", out, err, pairContract, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) GetTokensUniV3NFT(nftContract common.Address, tokenID *big.Int, callopts *bind.CallOpts) (common.Address, common.Address, error) {
+	t0, t1, err := r.rpc.GetTokensUniV3NFT(nftContract, tokenID, callopts)
+	out := itypes.Tuple2[common.Address, common.Address]{First: t0, Second: t1}
+	return t0, t1, r.record("GetTokensUniV3NFT", out, err, nftContract, tokenID, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) GetChainlinkRoundData(contractAddress common.Address, callopts *bind.CallOpts) (itypes.ChainlinkLatestRoundData, error) {
+	out, err := r.rpc.GetChainlinkRoundData(contractAddress, callopts)
+	return out, r.record("GetChainlinkRoundData", out, err, contractAddress, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) IsContract(address common.Address, callopts *bind.CallOpts) (bool, error) {
+	out, err := r.rpc.IsContract(address, callopts)
+	return out, r.record("IsContract", out, err, address, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) GetChainlinkDecimals(contractAddress common.Address, callopts *bind.CallOpts) (uint8, error) {
+	out, err := r.rpc.GetChainlinkDecimals(contractAddress, callopts)
+	return out, r.record("GetChainlinkDecimals", out, err, contractAddress, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) GetTraderJoeTokenX(contractAddress common.Address, callopts *bind.CallOpts) (common.Address, error) {
+	out, err := r.rpc.GetTraderJoeTokenX(contractAddress, callopts)
+	return out, r.record("GetTraderJoeTokenX", out, err, contractAddress, blockOf(callopts))
+}
+
+func (r *FixtureRecorder) GetTraderJoeTokenY(contractAddress common.Address, callopts *bind.CallOpts) (common.Address, error) {
+	out, err := r.rpc.GetTraderJoeTokenY(contractAddress, callopts)
+	return out, r.record("GetTraderJoeTokenY", out, err, contractAddress, blockOf(callopts))
+}
+
+// FixtureReplayer is an EthRPC serving calls from a fixture file,
+// without any network access. Calls recorded multiple times are
+// served in recorded order, last response repeating once exhausted.
+// Calls not in fixture fail.
+type FixtureReplayer struct {
+	service.BaseService
+
+	log  logger.Logger
+	file string
+
+	mu     sync.Mutex
+	calls  map[string][]FixtureCall
+	served map[string]int
+}
+
+func NewFixtureReplayer(log logger.Logger, file string) *FixtureReplayer {
+	r := &FixtureReplayer{log: log, file: file}
+	r.BaseService = *service.NewBaseService(log, "ethrpc", r)
+	return r
+}
+
+func NewFixtureReplayerWithViperFields(log logger.Logger) (EthRPC, error) {
+	for _, mf := range EthRPCFixtureCFGFields {
+		if err := cfg.EnsureFieldIntegrity(EthRPCFixtureCFGSection, mf); err != nil {
+			return nil, err
+		}
+	}
+	return NewFixtureReplayer(log, viper.GetString(EthRPCFixtureCFGSection+".file")), nil
+}
+
+// OnStart loads fixture file. It implements service.Service
+func (r *FixtureReplayer) OnStart(ctx context.Context) error {
+	data, err := os.ReadFile(r.file)
+	if err != nil {
+		return err
+	}
+	fixture := EthRPCFixture{}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return err
+	}
+	if fixture.Version != EthRPCFixtureVersion {
+		return fmt.Errorf("unsupported ethrpc fixture version %v, expected %v",
+			fixture.Version, EthRPCFixtureVersion)
+	}
+	r.calls = make(map[string][]FixtureCall)
+	r.served = make(map[string]int)
+	for _, call := range fixture.Calls {
+		key := fixtureKey(call.Method, call.Args)
+		r.calls[key] = append(r.calls[key], call)
+	}
+	r.log.Info("loaded ethrpc fixture", "fixture", r.file, "calls", len(fixture.Calls))
+	return nil
+}
+
+// OnStop stops the FixtureReplayer. It implements service.Service
+func (r *FixtureReplayer) OnStop() {
+}
+
+// replay decodes recorded response of call into result
+func (r *FixtureReplayer) replay(method string, result interface{}, args ...interface{}) error {
+	encoded, err := fixtureArgs(args...)
+	if err != nil {
+		return err
+	}
+	key := fixtureKey(method, encoded)
+
+	r.mu.Lock()
+	calls, ok := r.calls[key]
+	if !ok {
+		r.mu.Unlock()
+		return fmt.Errorf("no recorded response in fixture for %s%s", method, encoded)
+	}
+	idx := r.served[key]
+	if idx < len(calls)-1 {
+		r.served[key]++
+	}
+	r.mu.Unlock()
+
+	call := calls[idx]
+	if call.Error != "" {
+		if call.Error == ethereum.NotFound.Error() {
+			return ethereum.NotFound
+		}
+		return errors.New(call.Error)
+	}
+	return json.Unmarshal(call.Result, result)
+}
+
+func (r *FixtureReplayer) GetTxSender(txHash, blockHash common.Hash, txIdx uint) (common.Address, error) {
+	var out common.Address
+	return out, r.replay("GetTxSender", &out, txHash, blockHash, txIdx)
+}
+
+func (r *FixtureReplayer) GetChainID() (uint64, error) {
+	var out uint64
+	return out, r.replay("GetChainID", &out)
+}
+
+func (r *FixtureReplayer) GetCurrentBlockHeight() (uint64, error) {
+	var out uint64
+	return out, r.replay("GetCurrentBlockHeight", &out)
+}
+
+func (r *FixtureReplayer) GetBlockTimestamp(height uint64) (uint64, error) {
+	var out uint64
+	return out, r.replay("GetBlockTimestamp", &out, height)
+}
+
+func (r *FixtureReplayer) GetBlockHeader(height uint64) (*types.Header, error) {
+	var out *types.Header
+	return out, r.replay("GetBlockHeader", &out, height)
+}
+
+// SubscribeNewHeads is not supported on replay, node falls back to
+// polling
+func (r *FixtureReplayer) SubscribeNewHeads(ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return nil, errors.New("newHeads subscription not supported on fixture replay")
+}
+
+func (r *FixtureReplayer) GetFilteredLogs(fq ethereum.FilterQuery) ([]types.Log, error) {
+	var out []types.Log
+	return out, r.replay("GetFilteredLogs", &out, fq)
+}
+
+func (r *FixtureReplayer) GetBlockReceipts(height uint64) ([]*types.Receipt, error) {
+	var out []*types.Receipt
+	return out, r.replay("GetBlockReceipts", &out, height)
+}
+
+func (r *FixtureReplayer) GetBlockWithSenders(height uint64) (*types.Block, []common.Address, error) {
+	var out itypes.Tuple2[fixtureBlock, []common.Address]
+	if err := r.replay("GetBlockWithSenders", &out, height); err != nil {
+		return nil, nil, err
+	}
+	block := types.NewBlockWithHeader(out.First.Header).WithBody(out.First.Transactions, out.First.Uncles)
+	return block, out.Second, nil
+}
+
+func (r *FixtureReplayer) GetTokensUniV2(pairContract common.Address, callopts *bind.CallOpts) (common.Address, common.Address, error) {
+	var out itypes.Tuple2[common.Address, common.Address]
+	err := r.replay("GetTokensUniV2", &out, pairContract, blockOf(callopts))
+	return out.First, out.Second, err
+}
+
+func (r *FixtureReplayer) GetERC20Decimals(erc20Address common.Address, callopts *bind.CallOpts) (uint8, error) {
+	var out uint8
+	return out, r.replay("GetERC20Decimals", &out, erc20Address, blockOf(callopts))
+}
+
+func (r *FixtureReplayer) GetERC20Balances(requests []itypes.Tuple2[common.Address, common.Address],
+	callopts *bind.CallOpts) ([]*big.Int, error) {
+	var out []*big.Int
+	return out, r.replay("GetERC20Balances", &out, requests, blockOf(callopts))
+}
+
+func (r *FixtureReplayer) GetERC20Name(erc20Address common.Address, callopts *bind.CallOpts) (string, error) {
+	var out string
+	return out, r.replay("GetERC20Name", &out, erc20Address, blockOf(callopts))
+}
+
+func (r *FixtureReplayer) GetERC20Symbol(erc20Address common.Address, callopts *bind.CallOpts) (string, error) {
+	var out string
+	return out, r.replay("GetERC20Symbol", &out, erc20Address, blockOf(callopts))
+}
+
+func (r *FixtureReplayer) GetTokensUniV3(pairContract common.Address, callopts *bind.CallOpts) (common.Address, common.Address, error) {
+	var out itypes.Tuple2[common.Address, common.Address]
+	err := r.replay("GetTokensUniV3", &out, pairContract, blockOf(callopts))
+	return out.First, out.Second, err
+}
+
+func (r *FixtureReplayer) GetTokensUniV3NFT(nftContract common.Address, tokenID *big.Int, callopts *bind.CallOpts) (common.Address, common.Address, error) {
+	var out itypes.Tuple2[common.Address, common.Address]
+	err := r.replay("GetTokensUniV3NFT", &out, nftContract, tokenID, blockOf(callopts))
+	return out.First, out.Second, err
+}
+
+func (r *FixtureReplayer) GetChainlinkRoundData(contractAddress common.Address, callopts *bind.CallOpts) (itypes.ChainlinkLatestRoundData, error) {
+	var out itypes.ChainlinkLatestRoundData
+	return out, r.replay("GetChainlinkRoundData", &out, contractAddress, blockOf(callopts))
+}
+
+func (r *FixtureReplayer) IsContract(address common.Address, callopts *bind.CallOpts) (bool, error) {
+	var out bool
+	return out, r.replay("IsContract", &out, address, blockOf(callopts))
+}
+
+func (r *FixtureReplayer) GetChainlinkDecimals(contractAddress common.Address, callopts *bind.CallOpts) (uint8, error) {
+	var out uint8
+	return out, r.replay("GetChainlinkDecimals", &out, contractAddress, blockOf(callopts))
+}
+
+func (r *FixtureReplayer) GetTraderJoeTokenX(contractAddress common.Address, callopts *bind.CallOpts) (common.Address, error) {
+	var out common.Address
+	return out, r.replay("GetTraderJoeTokenX", &out, contractAddress, blockOf(callopts))
+}
+
+func (r *FixtureReplayer) GetTraderJoeTokenY(contractAddress common.Address, callopts *bind.CallOpts) (common.Address, error) {
+	var out common.Address
+	return out, r.replay("GetTraderJoeTokenY", &out, contractAddress, blockOf(callopts))
+}
@@ -2,7 +2,13 @@ package ethrpc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
+	"os"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/supragya/EtherScope/assets/abi/ERC20"
@@ -14,12 +20,16 @@ import (
 	cfg "github.com/supragya/EtherScope/libs/config"
 	logger "github.com/supragya/EtherScope/libs/log"
 	"github.com/supragya/EtherScope/libs/service"
+	"github.com/supragya/EtherScope/libs/util"
+	lb "github.com/supragya/EtherScope/services/local_backend"
 	itypes "github.com/supragya/EtherScope/types"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/spf13/viper"
 	"golang.org/x/sync/semaphore"
@@ -31,7 +41,8 @@ var (
 	EthRPCMSPoolCFGHeader    = cfg.SArr("mspool is master slave arch based",
 		"ethrpc provider for indexer which switches between ",
 		"master node and multiple slave nodes for high",
-		"availability. expects nodes to be in sync")
+		"availability. nodes are expected to be in sync, ones",
+		"lagging behind are detected by polling their heads")
 	EthRPCMSPoolCFGFields = [...]cfg.Field{
 		{
 			Name:      "master",
@@ -95,6 +106,51 @@ var (
 				"rpc a second chance"),
 			Default: 300,
 		},
+		{
+			Name:      "strategy",
+			Type:      "string",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("upstream selection strategy. `failover` uses master",
+				"and switches to slaves on failure, `roundrobin` rotates",
+				"through alive upstreams, `leastinflight` picks upstream",
+				"with least calls in flight and `ewma` picks upstream with",
+				"lowest moving average of latency"),
+			Default: "failover",
+		},
+		{
+			Name:      "upstreamLimits",
+			Type:      "[]limit",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("per upstream request limits. each entry needs",
+				"`upstream` identity (`master`, `slave0`, `slave1` ...) and",
+				"optionally `rateLimit` (requests per second) with `burst`",
+				"and `quota` (requests per `quotaPeriod`). Upstreams over",
+				"their limit are skipped, calls block if all are. e.g.",
+				"- upstream: slave0",
+				"  rateLimit: 25",
+				"  burst: 5",
+				"  quota: 1000000",
+				"  quotaPeriod: 24h"),
+			Default: []interface{}{},
+		},
+		{
+			Name:      "headPollInterval",
+			Type:      "time.Duration",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("interval to poll head of every upstream at. upstreams",
+				"are never queried for logs beyond their head. Setting",
+				"this to 0ms turns polling off"),
+			Default: "2s",
+		},
+		{
+			Name:      "maxHeadLag",
+			Type:      "uint64",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("number of blocks an upstream may be behind best",
+				"head among upstreams. upstreams lagging further are",
+				"not used while any other upstream is alive"),
+			Default: 5,
+		},
 		{
 			Name:      "periodicRecording",
 			Type:      "time.Duration",
@@ -104,6 +160,52 @@ var (
 				"service. Setting this to 0ms will turn periodic display off"),
 			Default: "10s",
 		},
+		{
+			Name:      "batching",
+			Type:      "string",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("batching of contract calls. `off` sends every call",
+				"on its own, `jsonrpc` sends calls made within",
+				"batchWindow as one JSON-RPC batch, `multicall3` also",
+				"aggregates eth_calls for the same block into one",
+				"Multicall3 aggregate3 call"),
+			Default: "off",
+		},
+		{
+			Name:      "batchWindow",
+			Type:      "time.Duration",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("time to collect calls for before sending a batch.",
+				"only used if batching is not `off`"),
+			Default: "5ms",
+		},
+		{
+			Name:      "maxBatchSize",
+			Type:      "uint",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("maximum calls in a batch, batch is sent right away",
+				"once full. only used if batching is not `off`"),
+			Default: 100,
+		},
+		{
+			Name:      "multicall3Address",
+			Type:      "string",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("address of Multicall3 contract. only used if batching",
+				"is `multicall3`. calls for blocks before Multicall3 was",
+				"deployed are sent as JSON-RPC batch"),
+			Default: DefaultMulticall3Address.Hex(),
+		},
+		{
+			Name:      "tokenMetadataSeedFile",
+			Type:      "string",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("token metadata seed file (see `escope tokenmeta`)",
+				"imported into localbackend on start. token metadata",
+				"is persisted only if localbackend is badgerdb. leave",
+				"empty to not import any seed"),
+			Default: "",
+		},
 		{
 			Name:      "cacheSizeContractTokens",
 			Type:      "uint32",
@@ -123,6 +225,15 @@ var (
 	}
 )
 
+// upstreamLimitSpec is a single entry of `ethRPCMSPool.upstreamLimits`
+type upstreamLimitSpec struct {
+	Upstream    string        `mapstructure:"upstream"`
+	RateLimit   float64       `mapstructure:"rateLimit"`
+	Burst       int           `mapstructure:"burst"`
+	Quota       uint64        `mapstructure:"quota"`
+	QuotaPeriod time.Duration `mapstructure:"quotaPeriod"`
+}
+
 type MSPoolEthRPCImpl struct {
 	service.BaseService
 
@@ -133,27 +244,71 @@ type MSPoolEthRPCImpl struct {
 	timeout           time.Duration
 	mspoolcfg         MSPoolConfig
 	periodicRecording time.Duration
+	headPollInterval  time.Duration
 	maxParallels      uint
+	batching          string
+	batchWindow       time.Duration
+	maxBatchSize      int
+	multicall3Address common.Address
+	seedFile          string
 
 	// Internal Data Structures
-	pool *MasterSlavePool[ethclient.Client]
-	sem  *semaphore.Weighted
+	pool    *MasterSlavePool[ethclient.Client]
+	sem     *semaphore.Weighted
+	batcher *callBatcher // nil if batching is off
+
+	// Stops head polling, nil if not polling
+	stopHeadPoll context.CancelFunc
+
+	// Underlying rpc clients of pool upstreams, for calls not
+	// supported by ethclient
+	rpcClients map[*ethclient.Client]*rpc.Client
+
+	// Persistent token metadata, nil if localbackend is not persistent
+	metadata *TokenMetadataStore
 
 	// In-memory caches
 	cacheContractTokens *lru.ARCCache
 	cacheERC20          *lru.ARCCache
 	cacheERC20Name      *lru.ARCCache
+	cacheERC20Symbol    *lru.ARCCache
+	cacheIsContract     *lru.ARCCache
 }
 
 // OnStart starts the badgerdb LocalBackend. It implements service.Service.
 func (n *MSPoolEthRPCImpl) OnStart(ctx context.Context) error {
-	pool, err := NewEthClientMasterSlavePool(n.master, n.slaves, n.mspoolcfg, n.timeout, n.log)
+	pool, rpcClients, err := NewEthClientMasterSlavePool(n.master, n.slaves, n.mspoolcfg, n.timeout, n.log)
 	if err != nil {
 		return err
 	}
 	n.pool = pool
+	n.rpcClients = rpcClients
 	n.sem = semaphore.NewWeighted(int64(n.maxParallels))
 
+	if n.batching != BatchingOff {
+		n.batcher, err = newCallBatcher(n.log, n.pool, rpcClients, n.sem,
+			n.batching, n.batchWindow, n.maxBatchSize, n.multicall3Address)
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.metadata != nil {
+		if err := n.warmCaches(); err != nil {
+			return err
+		}
+	}
+
+	if n.headPollInterval.Nanoseconds() == 0 {
+		n.log.Info("mspool ethrpc head polling turned off since headPollInterval is zero")
+	} else {
+		ctx, cancel := context.WithCancel(context.Background())
+		n.stopHeadPoll = cancel
+		go n.pool.PollHeads(ctx, n.headPollInterval, func(ctx context.Context, c *ethclient.Client) (uint64, error) {
+			return c.BlockNumber(ctx)
+		})
+	}
+
 	if n.periodicRecording.Nanoseconds() == 0 {
 		n.log.Info("mspool ethrpc reporting turned off since periodicRecording is zero")
 		return nil
@@ -166,9 +321,66 @@ func (n *MSPoolEthRPCImpl) OnStart(ctx context.Context) error {
 
 // OnStop stops the badgerdb LocalBackend. It implements service.Service
 func (n *MSPoolEthRPCImpl) OnStop() {
+	if n.stopHeadPoll != nil {
+		n.stopHeadPoll()
+	}
 }
 
-func NewMSPoolEthRPCWithViperFields(log logger.Logger) (EthRPC, error) {
+// warmCaches imports seed file if any and loads persisted token
+// metadata into in-memory caches
+func (n *MSPoolEthRPCImpl) warmCaches() error {
+	if n.seedFile != "" {
+		file, err := os.Open(n.seedFile)
+		if err != nil {
+			return err
+		}
+		imported, err := n.metadata.Import(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+		n.log.Info("imported token metadata seed", "seed", n.seedFile, "newEntries", imported)
+	}
+
+	caches := map[string]*lru.ARCCache{
+		TokenMetadataPairTokens: n.cacheContractTokens,
+		TokenMetadataDecimals:   n.cacheERC20,
+		TokenMetadataName:       n.cacheERC20Name,
+		TokenMetadataSymbol:     n.cacheERC20Symbol,
+		TokenMetadataContract:   n.cacheIsContract,
+	}
+	warmed := 0
+	err := n.metadata.Iterate(func(kind string, address common.Address, data json.RawMessage) error {
+		var val interface{}
+		switch kind {
+		case TokenMetadataPairTokens:
+			val = &itypes.Tuple2[common.Address, common.Address]{}
+		case TokenMetadataDecimals:
+			val = new(uint8)
+		case TokenMetadataName, TokenMetadataSymbol:
+			val = new(string)
+		case TokenMetadataContract:
+			val = new(bool)
+		default:
+			return nil
+		}
+		if err := json.Unmarshal(data, val); err != nil {
+			return err
+		}
+		caches[kind].Add(address, reflect.ValueOf(val).Elem().Interface())
+		warmed++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	n.log.Info("warmed token metadata caches from localbackend", "entries", warmed)
+	return nil
+}
+
+// NewMSPoolEthRPCWithViperFields sets up mspool ethrpc. Token metadata
+// is persisted in localBackend if not nil
+func NewMSPoolEthRPCWithViperFields(log logger.Logger, localBackend lb.LocalBackend) (EthRPC, error) {
 	// ensure field integrity for viper
 	for _, mf := range EthRPCMSPoolCFGFields {
 		err := cfg.EnsureFieldIntegrity(EthRPCMSPoolCFGSection, mf)
@@ -177,6 +389,37 @@ func NewMSPoolEthRPCWithViperFields(log logger.Logger) (EthRPC, error) {
 		}
 	}
 
+	var specs []upstreamLimitSpec
+	if err := viper.UnmarshalKey(EthRPCMSPoolCFGSection+".upstreamLimits", &specs); err != nil {
+		return nil, err
+	}
+	limits := make(map[string]UpstreamLimit, len(specs))
+	for _, spec := range specs {
+		if _, ok := limits[spec.Upstream]; ok {
+			return nil, errors.New("duplicate upstreamLimits entry for " + spec.Upstream)
+		}
+		if spec.Quota > 0 && spec.QuotaPeriod <= 0 {
+			return nil, errors.New("upstreamLimits entry for " + spec.Upstream + " has quota but no quotaPeriod")
+		}
+		limits[spec.Upstream] = UpstreamLimit{
+			RateLimit:   spec.RateLimit,
+			Burst:       spec.Burst,
+			Quota:       spec.Quota,
+			QuotaPeriod: spec.QuotaPeriod,
+		}
+	}
+
+	batching := viper.GetString(EthRPCMSPoolCFGSection + ".batching")
+	switch batching {
+	case BatchingOff, BatchingJSONRPC, BatchingMulticall3:
+	default:
+		return nil, errors.New("unknown batching mode: " + batching)
+	}
+	multicall3Address := viper.GetString(EthRPCMSPoolCFGSection + ".multicall3Address")
+	if !common.IsHexAddress(multicall3Address) {
+		return nil, errors.New("invalid multicall3Address: " + multicall3Address)
+	}
+
 	cacheContractTokens, err := lru.NewARC(viper.GetInt(EthRPCMSPoolCFGSection + ".cacheSizeContractTokens"))
 	if err != nil {
 		return nil, err
@@ -189,6 +432,18 @@ func NewMSPoolEthRPCWithViperFields(log logger.Logger) (EthRPC, error) {
 	if err != nil {
 		return nil, err
 	}
+	cacheERC20Symbol, err := lru.NewARC(viper.GetInt(EthRPCMSPoolCFGSection + ".cacheSizeERC20"))
+	if err != nil {
+		return nil, err
+	}
+	cacheIsContract, err := lru.NewARC(viper.GetInt(EthRPCMSPoolCFGSection + ".cacheSizeContractTokens"))
+	if err != nil {
+		return nil, err
+	}
+	var metadata *TokenMetadataStore
+	if localBackend != nil {
+		metadata = NewTokenMetadataStore(localBackend)
+	}
 	lb := &MSPoolEthRPCImpl{
 		log:     log,
 		master:  viper.GetString(EthRPCMSPoolCFGSection + ".master"),
@@ -199,12 +454,24 @@ func NewMSPoolEthRPCWithViperFields(log logger.Logger) (EthRPC, error) {
 			ToleranceCount: viper.GetUint32(EthRPCMSPoolCFGSection + ".tolerance"),
 			TimeStep:       viper.GetDuration(EthRPCMSPoolCFGSection + ".timeStep"),
 			RetryTimesteps: viper.GetUint32(EthRPCMSPoolCFGSection + ".retryTimesteps"),
+			Strategy:       viper.GetString(EthRPCMSPoolCFGSection + ".strategy"),
+			Limits:         limits,
+			MaxHeadLag:     viper.GetUint64(EthRPCMSPoolCFGSection + ".maxHeadLag"),
 		},
 		maxParallels:        viper.GetUint(EthRPCMSPoolCFGSection + ".maxParallels"),
 		periodicRecording:   viper.GetDuration(EthRPCMSPoolCFGSection + ".periodicRecording"),
+		headPollInterval:    viper.GetDuration(EthRPCMSPoolCFGSection + ".headPollInterval"),
+		batching:            batching,
+		batchWindow:         viper.GetDuration(EthRPCMSPoolCFGSection + ".batchWindow"),
+		maxBatchSize:        viper.GetInt(EthRPCMSPoolCFGSection + ".maxBatchSize"),
+		multicall3Address:   common.HexToAddress(multicall3Address),
 		cacheContractTokens: cacheContractTokens,
 		cacheERC20:          cacheERC20,
 		cacheERC20Name:      cacheERC20Name,
+		cacheERC20Symbol:    cacheERC20Symbol,
+		cacheIsContract:     cacheIsContract,
+		metadata:            metadata,
+		seedFile:            viper.GetString(EthRPCMSPoolCFGSection + ".tokenMetadataSeedFile"),
 	}
 	lb.BaseService = *service.NewBaseService(log, "ethrpc", lb)
 	return lb, nil
@@ -214,6 +481,10 @@ func NewMSPoolEthRPCWithViperFields(log logger.Logger) (EthRPC, error) {
 func (n *MSPoolEthRPCImpl) GetTxSender(txHash common.Hash,
 	blockHash common.Hash,
 	txIdx uint) (common.Address, error) {
+	if n.batcher != nil {
+		return n.batcher.TransactionSender(context.Background(), txHash)
+	}
+
 	tx, err := Do(n.pool,
 		n.sem,
 		func(ctx context.Context, c *ethclient.Client) (*types.Transaction, error) {
@@ -241,6 +512,19 @@ func (n *MSPoolEthRPCImpl) GetCurrentBlockHeight() (uint64, error) {
 		}, 0)
 }
 
+// Non-cached RPC access to get chain ID of network upstreams are on
+func (n *MSPoolEthRPCImpl) GetChainID() (uint64, error) {
+	chainID, err := Do(n.pool,
+		n.sem,
+		func(ctx context.Context, c *ethclient.Client) (*big.Int, error) {
+			return c.ChainID(ctx)
+		}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return chainID.Uint64(), nil
+}
+
 // Non-cached RPC access to get block timestamp
 func (n *MSPoolEthRPCImpl) GetBlockTimestamp(height uint64) (uint64, error) {
 	header, err := Do(n.pool,
@@ -255,103 +539,169 @@ func (n *MSPoolEthRPCImpl) GetBlockTimestamp(height uint64) (uint64, error) {
 	return header.Time, err
 }
 
-// Non-cached RPC access to get filtered logs
-func (n *MSPoolEthRPCImpl) GetFilteredLogs(fq ethereum.FilterQuery) ([]types.Log, error) {
+// Non-cached RPC access to get block header
+func (n *MSPoolEthRPCImpl) GetBlockHeader(height uint64) (*types.Header, error) {
 	return Do(n.pool,
 		n.sem,
+		func(ctx context.Context, c *ethclient.Client) (*types.Header, error) {
+			return c.HeaderByNumber(ctx, big.NewInt(int64(height)))
+		}, nil)
+}
+
+// Non-cached RPC access to get filtered logs. Queries are only sent to
+// upstreams which have seen fq.ToBlock, lagging upstreams may otherwise
+// return no logs for blocks they are yet to see
+func (n *MSPoolEthRPCImpl) GetFilteredLogs(fq ethereum.FilterQuery) ([]types.Log, error) {
+	var height uint64
+	if fq.BlockHash == nil && fq.ToBlock != nil && fq.ToBlock.Sign() > 0 {
+		height = fq.ToBlock.Uint64()
+	}
+	return DoAtHeight(n.pool,
+		n.sem,
+		height,
 		func(ctx context.Context, c *ethclient.Client) ([]types.Log, error) {
 			return c.FilterLogs(ctx, fq)
 		}, []types.Log{})
 }
 
-// Cached RPC access to get token sides for uniswap v2
-func (n *MSPoolEthRPCImpl) GetTokensUniV2(pairContract common.Address, callopts *bind.CallOpts) (common.Address, common.Address, error) {
-	// Cache checkup
-	lookupKey := itypes.Tuple2[common.Address, bind.CallOpts]{pairContract, *callopts}
-	if ret, ok := n.cacheContractTokens.Get(lookupKey); ok {
-		retI := ret.(itypes.Tuple2[common.Address, common.Address])
-		return retI.First, retI.Second, nil
+// Subscription to newHeads on an upstream picked from pool. Needs
+// upstream to be connected over websockets (or ipc). Subscription is
+// not moved to another upstream on failure, callers are expected to
+// resubscribe once its error channel fires
+func (n *MSPoolEthRPCImpl) SubscribeNewHeads(ch chan<- *types.Header) (ethereum.Subscription, error) {
+	client, meta := n.pool.GetItem()
+	if client == nil {
+		return nil, errors.New("no upstream available for newHeads subscription")
 	}
-
-	token0, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (common.Address, error) {
-			pc, err := univ2pair.NewUniv2pair(pairContract, c)
-			if err != nil {
-				return common.Address{}, err
-			}
-			callopts.Context = ctx
-			return pc.Token0(callopts)
-		}, common.Address{})
+	start := time.Now()
+	sub, err := client.SubscribeNewHead(util.NewCtx(n.pool.RPCTimeout), ch)
+	n.pool.Observe(client, time.Since(start))
 	if err != nil {
-		return common.Address{}, common.Address{}, err
+		return nil, fmt.Errorf("newHeads subscription on %s failed: %w", meta.Identity, err)
 	}
+	n.log.Info("subscribed to newHeads", "upstream", meta.Identity)
+	return sub, nil
+}
 
-	token1, err := Do(n.pool,
+// Non-cached RPC access to get all receipts of a block. Needs upstreams
+// supporting eth_getBlockReceipts
+func (n *MSPoolEthRPCImpl) GetBlockReceipts(height uint64) ([]*types.Receipt, error) {
+	return DoAtHeight(n.pool,
 		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (common.Address, error) {
-			pc, err := univ2pair.NewUniv2pair(pairContract, c)
-			if err != nil {
-				return common.Address{}, err
+		height,
+		func(ctx context.Context, c *ethclient.Client) ([]*types.Receipt, error) {
+			rc, ok := n.rpcClients[c]
+			if !ok {
+				return nil, errors.New("no rpc client for upstream")
 			}
-			callopts.Context = ctx
-			return pc.Token1(callopts)
-		}, common.Address{})
+			var receipts []*types.Receipt
+			err := rc.CallContext(ctx, &receipts, "eth_getBlockReceipts", hexutil.EncodeUint64(height))
+			if err == nil && receipts == nil {
+				return nil, ethereum.NotFound
+			}
+			return receipts, err
+		}, nil)
+}
+
+// Non-cached RPC access to get block along with senders of all its
+// transactions. Senders are as reported by upstream, no extra calls
+// are made for them
+func (n *MSPoolEthRPCImpl) GetBlockWithSenders(height uint64) (*types.Block, []common.Address, error) {
+	block, err := DoAtHeight(n.pool,
+		n.sem,
+		height,
+		func(ctx context.Context, c *ethclient.Client) (*types.Block, error) {
+			return c.BlockByNumber(ctx, new(big.Int).SetUint64(height))
+		}, nil)
 	if err != nil {
-		return common.Address{}, common.Address{}, err
+		return nil, nil, err
 	}
 
-	n.cacheContractTokens.Add(lookupKey, itypes.Tuple2[common.Address, common.Address]{token0, token1})
-	return token0, token1, nil
+	senders := make([]common.Address, len(block.Transactions()))
+	for idx, tx := range block.Transactions() {
+		// Recovered locally, upstream is asked only for txs of types
+		// not known to signer
+		sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err == nil {
+			senders[idx] = sender
+			continue
+		}
+		sender, err = Do(n.pool,
+			n.sem,
+			func(ctx context.Context, c *ethclient.Client) (common.Address, error) {
+				return c.TransactionSender(ctx, tx, block.Hash(), uint(idx))
+			}, common.Address{})
+		if err != nil {
+			return nil, nil, err
+		}
+		senders[idx] = sender
+	}
+	return block, senders, nil
+}
+
+// Cached RPC access to get token sides for uniswap v2
+func (n *MSPoolEthRPCImpl) GetTokensUniV2(pairContract common.Address, callopts *bind.CallOpts) (common.Address, common.Address, error) {
+	tokens, err := cachedMetadata(n, n.cacheContractTokens, TokenMetadataPairTokens, pairContract,
+		func() (itypes.Tuple2[common.Address, common.Address], error) {
+			var token0, token1 common.Address
+			err := parallel(func() (err error) {
+				token0, err = call(n, func(ctx context.Context, c bind.ContractCaller) (common.Address, error) {
+					pc, err := univ2pair.NewUniv2pairCaller(pairContract, c)
+					if err != nil {
+						return common.Address{}, err
+					}
+					return pc.Token0(withContext(callopts, ctx))
+				}, common.Address{})
+				return err
+			}, func() (err error) {
+				token1, err = call(n, func(ctx context.Context, c bind.ContractCaller) (common.Address, error) {
+					pc, err := univ2pair.NewUniv2pairCaller(pairContract, c)
+					if err != nil {
+						return common.Address{}, err
+					}
+					return pc.Token1(withContext(callopts, ctx))
+				}, common.Address{})
+				return err
+			})
+			return itypes.Tuple2[common.Address, common.Address]{First: token0, Second: token1}, err
+		})
+	return tokens.First, tokens.Second, err
 }
 
 // Cached RPC access to get decimals for ERC20 addresses
 func (n *MSPoolEthRPCImpl) GetERC20Decimals(erc20Address common.Address, callopts *bind.CallOpts) (uint8, error) {
-	lookupKey := erc20Address
-	if ret, ok := n.cacheERC20.Get(lookupKey); ok {
-		retI := ret.(uint8)
-		return retI, nil
-	}
-
-	decimals, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (uint8, error) {
-			erc20, err := ERC20.NewERC20(erc20Address, c)
+	return cachedMetadata(n, n.cacheERC20, TokenMetadataDecimals, erc20Address, func() (uint8, error) {
+		return call(n, func(ctx context.Context, c bind.ContractCaller) (uint8, error) {
+			erc20, err := ERC20.NewERC20Caller(erc20Address, c)
 			if err != nil {
 				return 0, nil
 			}
-			callopts.Context = ctx
-			return erc20.Decimals(callopts)
+			return erc20.Decimals(withContext(callopts, ctx))
 		}, 0)
-
-	if err != nil {
-		return 0, err
-	}
-
-	n.cacheERC20.Add(lookupKey, decimals)
-	return decimals, nil
+	})
 }
 
 // Non-cached RPC access to get balances for tuple (holderAddress, tokenAddress)
 func (n *MSPoolEthRPCImpl) GetERC20Balances(requests []itypes.Tuple2[common.Address, common.Address],
 	callopts *bind.CallOpts) ([]*big.Int, error) {
-	results := []*big.Int{}
-
-	for _, req := range requests {
-		balance, err := Do(n.pool,
-			n.sem,
-			func(ctx context.Context, c *ethclient.Client) (*big.Int, error) {
-				token, err := ERC20.NewERC20(req.Second, c)
+	results := make([]*big.Int, len(requests))
+
+	fetches := make([]func() error, len(requests))
+	for idx, req := range requests {
+		idx, req := idx, req
+		fetches[idx] = func() (err error) {
+			results[idx], err = call(n, func(ctx context.Context, c bind.ContractCaller) (*big.Int, error) {
+				token, err := ERC20.NewERC20Caller(req.Second, c)
 				if err != nil {
 					return big.NewInt(0), nil
 				}
-				callopts.Context = ctx
-				return token.BalanceOf(callopts, req.First)
+				return token.BalanceOf(withContext(callopts, ctx), req.First)
 			}, nil)
-		if err != nil {
-			return results, err
+			return err
 		}
-		results = append(results, balance)
+	}
+	if err := parallel(fetches...); err != nil {
+		return []*big.Int{}, err
 	}
 	return results, nil
 }
@@ -361,89 +711,88 @@ func (n *MSPoolEthRPCImpl) GetERC20Name(erc20Address common.Address, callopts *b
 	if erc20Address == common.HexToAddress("0xffffffffffffffffffffffffffffffffffffffff") {
 		return "USD", nil
 	}
-	lookupKey := erc20Address
-	if ret, ok := n.cacheERC20Name.Get(lookupKey); ok {
-		retI := ret.(string)
-		return retI, nil
-	}
 
-	name, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (string, error) {
-			token, err := ERC20.NewERC20(erc20Address, c)
+	name, err := cachedMetadata(n, n.cacheERC20Name, TokenMetadataName, erc20Address, func() (string, error) {
+		return call(n, func(ctx context.Context, c bind.ContractCaller) (string, error) {
+			token, err := ERC20.NewERC20Caller(erc20Address, c)
 			if err != nil {
 				return "unknown", err
 			}
-			callopts.Context = ctx
-			return token.Name(callopts)
+			return token.Name(withContext(callopts, ctx))
 		}, "unknown")
+	})
 	if err != nil {
 		return "unknown", err
 	}
-
-	n.cacheERC20Name.Add(lookupKey, name)
 	return name, nil
 }
 
-func (n *MSPoolEthRPCImpl) GetTokensUniV3(pairContract common.Address,
-	callopts *bind.CallOpts) (common.Address, common.Address, error) {
-	lookupKey := itypes.Tuple2[common.Address, bind.CallOpts]{pairContract, *callopts}
-	if ret, ok := n.cacheContractTokens.Get(lookupKey); ok {
-		retI := ret.(itypes.Tuple2[common.Address, common.Address])
-		return retI.First, retI.Second, nil
-	}
-
-	token0, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (common.Address, error) {
-			pc, err := univ3pair.NewUniv3pair(pairContract, c)
-			if err != nil {
-				return common.Address{}, err
-			}
-			callopts.Context = ctx
-			return pc.Token0(callopts)
-		}, common.Address{})
-	if err != nil {
-		return common.Address{}, common.Address{}, err
+// Cached RPC access to get symbol for erc20 addresses
+func (n *MSPoolEthRPCImpl) GetERC20Symbol(erc20Address common.Address, callopts *bind.CallOpts) (string, error) {
+	if erc20Address == common.HexToAddress("0xffffffffffffffffffffffffffffffffffffffff") {
+		return "USD", nil
 	}
 
-	token1, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (common.Address, error) {
-			pc, err := univ3pair.NewUniv3pair(pairContract, c)
+	symbol, err := cachedMetadata(n, n.cacheERC20Symbol, TokenMetadataSymbol, erc20Address, func() (string, error) {
+		return call(n, func(ctx context.Context, c bind.ContractCaller) (string, error) {
+			token, err := ERC20.NewERC20Caller(erc20Address, c)
 			if err != nil {
-				return common.Address{}, err
+				return "unknown", err
 			}
-			callopts.Context = ctx
-			return pc.Token1(callopts)
-		}, common.Address{})
+			return token.Symbol(withContext(callopts, ctx))
+		}, "unknown")
+	})
 	if err != nil {
-		return common.Address{}, common.Address{}, err
+		return "unknown", err
 	}
+	return symbol, nil
+}
 
-	n.cacheContractTokens.Add(lookupKey, itypes.Tuple2[common.Address, common.Address]{token0, token1})
-	return token0, token1, nil
+func (n *MSPoolEthRPCImpl) GetTokensUniV3(pairContract common.Address,
+	callopts *bind.CallOpts) (common.Address, common.Address, error) {
+	tokens, err := cachedMetadata(n, n.cacheContractTokens, TokenMetadataPairTokens, pairContract,
+		func() (itypes.Tuple2[common.Address, common.Address], error) {
+			var token0, token1 common.Address
+			err := parallel(func() (err error) {
+				token0, err = call(n, func(ctx context.Context, c bind.ContractCaller) (common.Address, error) {
+					pc, err := univ3pair.NewUniv3pairCaller(pairContract, c)
+					if err != nil {
+						return common.Address{}, err
+					}
+					return pc.Token0(withContext(callopts, ctx))
+				}, common.Address{})
+				return err
+			}, func() (err error) {
+				token1, err = call(n, func(ctx context.Context, c bind.ContractCaller) (common.Address, error) {
+					pc, err := univ3pair.NewUniv3pairCaller(pairContract, c)
+					if err != nil {
+						return common.Address{}, err
+					}
+					return pc.Token1(withContext(callopts, ctx))
+				}, common.Address{})
+				return err
+			})
+			return itypes.Tuple2[common.Address, common.Address]{First: token0, Second: token1}, err
+		})
+	return tokens.First, tokens.Second, err
 }
 
 func (n *MSPoolEthRPCImpl) GetTokensUniV3NFT(nftContract common.Address, tokenID *big.Int, callopts *bind.CallOpts) (common.Address, common.Address, error) {
 	// Cache checkup
-	lookupKey := itypes.Tuple2[common.Address, bind.CallOpts]{nftContract, *callopts}
+	lookupKey := itypes.Tuple2[common.Address, bind.CallOpts]{First: nftContract, Second: *callopts}
 	if ret, ok := n.cacheContractTokens.Get(lookupKey); ok {
 		retI := ret.(itypes.Tuple2[common.Address, common.Address])
 		return retI.First, retI.Second, nil
 	}
 
-	tokens, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (itypes.Tuple2[common.Address, common.Address], error) {
-			pc, err := univ3positionsnft.NewUniv3positionsnft(nftContract, c)
-			if err != nil {
-				return itypes.Tuple2[common.Address, common.Address]{}, err
-			}
-			callopts.Context = ctx
-			positions, err := pc.Positions(callopts, tokenID)
-			return itypes.Tuple2[common.Address, common.Address]{positions.Token0, positions.Token1}, err
-		}, itypes.Tuple2[common.Address, common.Address]{})
+	tokens, err := call(n, func(ctx context.Context, c bind.ContractCaller) (itypes.Tuple2[common.Address, common.Address], error) {
+		pc, err := univ3positionsnft.NewUniv3positionsnftCaller(nftContract, c)
+		if err != nil {
+			return itypes.Tuple2[common.Address, common.Address]{}, err
+		}
+		positions, err := pc.Positions(withContext(callopts, ctx), tokenID)
+		return itypes.Tuple2[common.Address, common.Address]{First: positions.Token0, Second: positions.Token1}, err
+	}, itypes.Tuple2[common.Address, common.Address]{})
 	if err != nil {
 		return common.Address{}, common.Address{}, err
 	}
@@ -455,73 +804,165 @@ func (n *MSPoolEthRPCImpl) GetTokensUniV3NFT(nftContract common.Address, tokenID
 
 func (n *MSPoolEthRPCImpl) GetChainlinkRoundData(
 	contractAddress common.Address, callopts *bind.CallOpts) (itypes.ChainlinkLatestRoundData, error) {
-	roundData, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (itypes.ChainlinkLatestRoundData, error) {
-			oracle, err := chainlink.NewChainlink(contractAddress, c)
-			if err != nil {
-				return itypes.ChainlinkLatestRoundData{}, err
-			}
-			callopts.Context = ctx
-			return oracle.LatestRoundData(callopts)
-		}, itypes.ChainlinkLatestRoundData{})
+	roundData, err := call(n, func(ctx context.Context, c bind.ContractCaller) (itypes.ChainlinkLatestRoundData, error) {
+		oracle, err := chainlink.NewChainlinkCaller(contractAddress, c)
+		if err != nil {
+			return itypes.ChainlinkLatestRoundData{}, err
+		}
+		return oracle.LatestRoundData(withContext(callopts, ctx))
+	}, itypes.ChainlinkLatestRoundData{})
 	return roundData, err
 }
 
 // CodeAt returns the contract bytecode associated with the given account. If the account isn't a contract, returns nil.
 func (n *MSPoolEthRPCImpl) IsContract(Address common.Address, callopts *bind.CallOpts) (bool, error) {
-	isAddressContract, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) ([]byte, error) {
-			return c.CodeAt(ctx, Address, nil)
-		}, []byte{})
-	if len(isAddressContract) > 0 {
-		return true, err
+	if ret, ok := n.cacheIsContract.Get(Address); ok {
+		return ret.(bool), nil
+	}
+	if n.metadata != nil {
+		var isContract bool
+		if ok, err := n.metadata.Get(TokenMetadataContract, Address, &isContract); ok && err == nil {
+			n.cacheIsContract.Add(Address, isContract)
+			return isContract, nil
+		}
+	}
+
+	isAddressContract, err := call(n, func(ctx context.Context, c bind.ContractCaller) ([]byte, error) {
+		return c.CodeAt(ctx, Address, nil)
+	}, []byte{})
+	if err != nil {
+		return false, err
+	}
+	if len(isAddressContract) == 0 {
+		// Code may still be deployed at address later, hence not cached
+		return false, nil
 	}
-	return false, err
+
+	n.cacheIsContract.Add(Address, true)
+	if n.metadata != nil {
+		if err := n.metadata.Set(TokenMetadataContract, Address, true); err != nil {
+			n.log.Warn("could not persist token metadata", "address", Address, "error", err)
+		}
+	}
+	return true, nil
 }
 
 func (n *MSPoolEthRPCImpl) GetChainlinkDecimals(
 	contractAddress common.Address, callopts *bind.CallOpts) (uint8, error) {
-	roundData, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (uint8, error) {
-			oracle, err := chainlink.NewChainlink(contractAddress, c)
-			if err != nil {
-				return 0, err
-			}
-			callopts.Context = ctx
-			return oracle.Decimals(callopts)
-		}, 0)
+	roundData, err := call(n, func(ctx context.Context, c bind.ContractCaller) (uint8, error) {
+		oracle, err := chainlink.NewChainlinkCaller(contractAddress, c)
+		if err != nil {
+			return 0, err
+		}
+		return oracle.Decimals(withContext(callopts, ctx))
+	}, 0)
 	return roundData, err
 }
 
 func (n *MSPoolEthRPCImpl) GetTraderJoeTokenX(
 	contractAddress common.Address, callopts *bind.CallOpts) (common.Address, error) {
-	tokenXAddress, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (common.Address, error) {
-			traderJoePool, err := traderjoev2.NewTraderjoev2(contractAddress, c)
-			if err != nil {
-				return common.Address{}, err
-			}
-			callopts.Context = ctx
-			return traderJoePool.TokenX(callopts)
-		}, common.Address{})
+	tokenXAddress, err := call(n, func(ctx context.Context, c bind.ContractCaller) (common.Address, error) {
+		traderJoePool, err := traderjoev2.NewTraderjoev2Caller(contractAddress, c)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return traderJoePool.TokenX(withContext(callopts, ctx))
+	}, common.Address{})
 	return tokenXAddress, err
 }
 
 func (n *MSPoolEthRPCImpl) GetTraderJoeTokenY(
 	contractAddress common.Address, callopts *bind.CallOpts) (common.Address, error) {
-	tokenYAddress, err := Do(n.pool,
-		n.sem,
-		func(ctx context.Context, c *ethclient.Client) (common.Address, error) {
-			traderJoePool, err := traderjoev2.NewTraderjoev2(contractAddress, c)
-			if err != nil {
-				return common.Address{}, err
-			}
-			callopts.Context = ctx
-			return traderJoePool.TokenY(callopts)
-		}, common.Address{})
+	tokenYAddress, err := call(n, func(ctx context.Context, c bind.ContractCaller) (common.Address, error) {
+		traderJoePool, err := traderjoev2.NewTraderjoev2Caller(contractAddress, c)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return traderJoePool.TokenY(withContext(callopts, ctx))
+	}, common.Address{})
 	return tokenYAddress, err
 }
+
+// call runs contract calls in foo. With batching turned on calls are
+// collected by batcher and sent along with concurrent calls, else foo
+// is run over the pool like Do
+func call[T any](n *MSPoolEthRPCImpl,
+	foo func(context.Context, bind.ContractCaller) (T, error),
+	defaultVal T) (T, error) {
+	if n.batcher != nil {
+		return foo(context.Background(), n.batcher)
+	}
+	return Do(n.pool,
+		n.sem,
+		func(ctx context.Context, c *ethclient.Client) (T, error) {
+			return foo(ctx, c)
+		}, defaultVal)
+}
+
+// withContext returns copy of callopts using ctx, callopts are shared
+// between concurrent calls and hence never modified
+func withContext(callopts *bind.CallOpts, ctx context.Context) *bind.CallOpts {
+	if callopts == nil {
+		return &bind.CallOpts{Context: ctx}
+	}
+	opts := *callopts
+	opts.Context = ctx
+	return &opts
+}
+
+// parallel runs fetches concurrently, so that their calls can be
+// batched together, returning first error if any
+func parallel(fetches ...func() error) error {
+	errs := make([]error, len(fetches))
+	wg := sync.WaitGroup{}
+	for idx, fetch := range fetches {
+		wg.Add(1)
+		go func(idx int, fetch func() error) {
+			defer wg.Done()
+			errs[idx] = fetch()
+		}(idx, fetch)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cachedMetadata looks up immutable metadata of kind for address in
+// cache and then in persistent store, calling fetch on miss. Fetched
+// values are added to both
+func cachedMetadata[T any](n *MSPoolEthRPCImpl,
+	cache *lru.ARCCache,
+	kind string,
+	address common.Address,
+	fetch func() (T, error)) (T, error) {
+	if ret, ok := cache.Get(address); ok {
+		return ret.(T), nil
+	}
+
+	var val T
+	if n.metadata != nil {
+		ok, err := n.metadata.Get(kind, address, &val)
+		if err != nil {
+			n.log.Warn("could not read token metadata", "kind", kind, "address", address, "error", err)
+		} else if ok {
+			cache.Add(address, val)
+			return val, nil
+		}
+	}
+
+	val, err := fetch()
+	if err != nil {
+		return val, err
+	}
+	cache.Add(address, val)
+	if n.metadata != nil {
+		if err := n.metadata.Set(kind, address, val); err != nil {
+			n.log.Warn("could not persist token metadata", "kind", kind, "address", address, "error", err)
+		}
+	}
+	return val, nil
+}
@@ -1,16 +1,37 @@
 package ethrpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/services/instrumentation"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/time/rate"
 )
 
+// Upstream selection strategies for MasterSlavePool
+const (
+	// Always use master, fail over to slaves in order
+	MSPoolStrategyFailover = "failover"
+	// Rotate through all alive upstreams
+	MSPoolStrategyRoundRobin = "roundrobin"
+	// Use alive upstream with least calls in flight
+	MSPoolStrategyLeastInFlight = "leastinflight"
+	// Use alive upstream with least exponentially weighted moving
+	// average of call latency
+	MSPoolStrategyEWMALatency = "ewma"
+)
+
+// Weight of a new latency observation in upstream latency EWMA
+const ewmaAlpha = 0.3
+
 type PoolNodeMeta struct {
 	Identity    string
 	IsAlive     bool
@@ -19,6 +40,38 @@ type PoolNodeMeta struct {
 	FirstReport time.Time
 	LastReport  time.Time
 	BringAlive  time.Time
+
+	inFlight    int64 // atomic
+	latencyEWMA int64 // atomic, nanoseconds, zero till first observation
+	limitMutex  sync.Mutex
+	limiter     *rate.Limiter // nil if not rate limited
+	quota       uint64        // zero if no quota
+	quotaPeriod time.Duration
+	quotaStart  time.Time
+	quotaUsed   uint64
+	head        uint64 // atomic, zero till first head poll
+	lagging     int32  // atomic, set if head is too far behind best head
+}
+
+// InFlight returns number of calls currently in flight on upstream
+func (m *PoolNodeMeta) InFlight() int64 {
+	return atomic.LoadInt64(&m.inFlight)
+}
+
+// Head returns last polled head of upstream, zero if unknown
+func (m *PoolNodeMeta) Head() uint64 {
+	return atomic.LoadUint64(&m.head)
+}
+
+// IsLagging tells if upstream head was more than MaxHeadLag behind
+// best head of the pool at last poll
+func (m *PoolNodeMeta) IsLagging() bool {
+	return atomic.LoadInt32(&m.lagging) == 1
+}
+
+// LatencyEWMA returns moving average of observed call latencies
+func (m *PoolNodeMeta) LatencyEWMA() time.Duration {
+	return time.Duration(atomic.LoadInt64(&m.latencyEWMA))
 }
 
 type PoolNode[I any] struct {
@@ -31,6 +84,18 @@ type MSPoolConfig struct {
 	ToleranceCount uint32
 	TimeStep       time.Duration
 	RetryTimesteps uint32
+	Strategy       string                   // one of MSPoolStrategy*, failover if empty
+	Limits         map[string]UpstreamLimit // keyed by upstream identity
+	MaxHeadLag     uint64                   // blocks an upstream may be behind best head
+}
+
+// UpstreamLimit caps requests sent to a single upstream. Zero values
+// mean no limit
+type UpstreamLimit struct {
+	RateLimit   float64 // requests per second
+	Burst       int     // requests allowed at once over RateLimit
+	Quota       uint64  // requests per QuotaPeriod
+	QuotaPeriod time.Duration
 }
 
 var DefaultMSPoolConfig MSPoolConfig = MSPoolConfig{
@@ -38,6 +103,7 @@ var DefaultMSPoolConfig MSPoolConfig = MSPoolConfig{
 	ToleranceCount: 5,                      // can tolerate maximum of 5 failures
 	TimeStep:       time.Millisecond * 100, // can report a max of 1 failure every 1000 millisec
 	RetryTimesteps: 300,                    // retry after 1 minute (60 seconds)
+	Strategy:       MSPoolStrategyFailover,
 }
 
 type MasterSlavePool[I any] struct {
@@ -50,6 +116,8 @@ type MasterSlavePool[I any] struct {
 	Master               *PoolNode[*I]
 	Slaves               []*PoolNode[*I]
 	RPCTimeout           time.Duration
+	nextIdx              uint64 // atomic, round robin start
+	headsKnown           int32  // atomic, set once a head poll round got any head
 }
 
 type DurationTuple[I any] struct {
@@ -82,29 +150,58 @@ func NewEthClientMasterSlavePool(masterURL string,
 	slaveURLs []string,
 	config MSPoolConfig,
 	timeout time.Duration,
-	log logger.Logger) (*MasterSlavePool[ethclient.Client], error) {
+	log logger.Logger) (*MasterSlavePool[ethclient.Client], map[*ethclient.Client]*rpc.Client, error) {
+	switch config.Strategy {
+	case "", MSPoolStrategyFailover, MSPoolStrategyRoundRobin,
+		MSPoolStrategyLeastInFlight, MSPoolStrategyEWMALatency:
+	default:
+		return nil, nil, errors.New("unknown mspool strategy: " + config.Strategy)
+	}
+
 	itemMap := make(map[*ethclient.Client]*PoolNode[*ethclient.Client], len(slaveURLs)+1)
+	// Underlying rpc clients, needed for batch calls
+	rpcClients := make(map[*ethclient.Client]*rpc.Client, len(slaveURLs)+1)
 
 	// Setup master
-	ms, err := ethclient.Dial(masterURL)
+	rc, err := rpc.Dial(masterURL)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	ms := ethclient.NewClient(rc)
+	rpcClients[ms] = rc
 	master := NewNode(ms, "master")
 	itemMap[ms] = &master
 
 	// Setup slaves
 	slaves := []*PoolNode[*ethclient.Client]{}
 	for idx, url := range slaveURLs {
-		cl, err := ethclient.Dial(url)
+		rc, err := rpc.Dial(url)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		cl := ethclient.NewClient(rc)
+		rpcClients[cl] = rc
 		slave := NewNode(cl, fmt.Sprintf("slave%v", idx))
 		itemMap[cl] = &slave
 		slaves = append(slaves, &slave)
 	}
 
+	for identity, limit := range config.Limits {
+		var meta *PoolNodeMeta
+		if identity == master.Meta.Identity {
+			meta = &master.Meta
+		}
+		for _, slave := range slaves {
+			if identity == slave.Meta.Identity {
+				meta = &slave.Meta
+			}
+		}
+		if meta == nil {
+			return nil, nil, errors.New("limits set for unknown mspool upstream: " + identity)
+		}
+		SetLimit(meta, limit)
+	}
+
 	return &MasterSlavePool[ethclient.Client]{
 		config:               config,
 		log:                  log,
@@ -115,7 +212,7 @@ func NewEthClientMasterSlavePool(masterURL string,
 		Master:               &master,
 		Slaves:               slaves,
 		RPCTimeout:           timeout,
-	}, nil
+	}, rpcClients, nil
 }
 
 func (m *MasterSlavePool[I]) Report(item *I, timedOut bool) error {
@@ -165,50 +262,206 @@ func (m *MasterSlavePool[I]) Report(item *I, timedOut bool) error {
 	return nil
 }
 
+// GetItem returns an upstream to make a call on as per pool strategy,
+// skipping upstreams in cooldown, over their rate limit or out of quota.
+// Blocks if none is available. Every call made on returned item should
+// be reported back using Observe.
 func (m *MasterSlavePool[I]) GetItem() (*I, *PoolNodeMeta) {
-	// Lock global RW lock for reads
-	m.rwlock.RLock()
+	return m.GetItemAtHeight(0)
+}
 
-	// Check if master is alive, if so return master
-	if m.Master.Meta.IsAlive {
-		m.rwlock.RUnlock()
-		return m.Master.Item, &m.Master.Meta
+// GetItemAtHeight is GetItem restricted to upstreams which are known to
+// have seen block at height. Returns nil if no alive upstream has.
+func (m *MasterSlavePool[I]) GetItemAtHeight(height uint64) (*I, *PoolNodeMeta) {
+	for {
+		candidates := m.candidates()
+
+		// If none of the upstreams are alive, we may have to
+		// wait till first rpc comes back online and send it.
+		// Very expensive proposition, lot of mutex lock unlocks
+		if len(candidates) == 0 {
+			item, meta := m.allFailureRecovery()
+			m.acquire(&m.itemMap[item].Meta)
+			return item, meta
+		}
+
+		candidates = m.order(inSync(candidates, height, atomic.LoadInt32(&m.headsKnown) == 1))
+		if len(candidates) == 0 {
+			return nil, nil
+		}
+
+		now := time.Now()
+		for _, node := range candidates {
+			if m.admit(&node.Meta, now) {
+				m.acquire(&node.Meta)
+				return node.Item, &node.Meta
+			}
+		}
+
+		// All alive upstreams are rate limited or out of quota
+		time.Sleep(m.config.TimeStep)
 	}
+}
 
-	// If master is not alive, check if time has come to
-	// recheck on master
+// inSync filters out upstreams whose head is below height. Unknown heads
+// are taken to be below height once heads of other upstreams are known.
+// Lagging upstreams are filtered out too, unless all of them are lagging
+func inSync[I any](candidates []*PoolNode[*I], height uint64, headsKnown bool) []*PoolNode[*I] {
+	upToDate := make([]*PoolNode[*I], 0, len(candidates))
+	lagging := []*PoolNode[*I]{}
+	for _, node := range candidates {
+		head := node.Meta.Head()
+		if head < height && (head != 0 || headsKnown) {
+			continue
+		}
+		if node.Meta.IsLagging() {
+			lagging = append(lagging, node)
+		} else {
+			upToDate = append(upToDate, node)
+		}
+	}
+	if len(upToDate) == 0 {
+		return lagging
+	}
+	return upToDate
+}
+
+// candidates returns alive upstreams, bringing back upstreams whose
+// cooldown is over
+func (m *MasterSlavePool[I]) candidates() []*PoolNode[*I] {
 	now := time.Now()
-	if m.Master.Meta.BringAlive.Sub(now) <= time.Duration(0) {
-		m.rwlock.RUnlock()
+	nodes := append([]*PoolNode[*I]{m.Master}, m.Slaves...)
+	candidates := make([]*PoolNode[*I], 0, len(nodes))
+	revive := []*PoolNode[*I]{}
+
+	m.rwlock.RLock()
+	for _, node := range nodes {
+		if node.Meta.IsAlive {
+			candidates = append(candidates, node)
+		} else if node.Meta.BringAlive.Sub(now) <= time.Duration(0) {
+			revive = append(revive, node)
+			candidates = append(candidates, node)
+		}
+	}
+	m.rwlock.RUnlock()
+
+	if len(revive) > 0 {
 		m.rwlock.Lock()
-		MakeAlive(&m.Master.Meta)
+		for _, node := range revive {
+			if !node.Meta.IsAlive {
+				MakeAlive(&node.Meta)
+			}
+		}
 		m.rwlock.Unlock()
-		return m.Master.Item, &m.Master.Meta
 	}
+	return candidates
+}
 
-	// If master is not alive, nor is the time to bring it
-	// back online, check if any of the slaves is ready.
-	for _, slave := range m.Slaves {
-		sm := &slave.Meta
-		if sm.IsAlive {
-			m.rwlock.RUnlock()
-			return slave.Item, &slave.Meta
+// order sorts candidates in order of preference as per pool strategy
+func (m *MasterSlavePool[I]) order(candidates []*PoolNode[*I]) []*PoolNode[*I] {
+	switch m.config.Strategy {
+	case "", MSPoolStrategyFailover:
+		return candidates
+	}
+
+	// Rotate before ordering so that ties are broken round robin
+	if len(candidates) > 1 {
+		start := int(atomic.AddUint64(&m.nextIdx, 1) % uint64(len(candidates)))
+		candidates = append(candidates[start:], candidates[:start]...)
+	}
+
+	switch m.config.Strategy {
+	case MSPoolStrategyLeastInFlight:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Meta.InFlight() < candidates[j].Meta.InFlight()
+		})
+	case MSPoolStrategyEWMALatency:
+		// Upstreams yet to be observed have zero latency and are
+		// hence tried first
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Meta.LatencyEWMA() < candidates[j].Meta.LatencyEWMA()
+		})
+	}
+	return candidates
+}
+
+// admit checks upstream rate limit and quota, consuming one request
+// of both if available
+func (m *MasterSlavePool[I]) admit(meta *PoolNodeMeta, now time.Time) bool {
+	meta.limitMutex.Lock()
+	defer meta.limitMutex.Unlock()
+
+	if meta.quota > 0 {
+		if now.Sub(meta.quotaStart) >= meta.quotaPeriod {
+			meta.quotaStart = now
+			meta.quotaUsed = 0
 		}
-		if sm.BringAlive.Sub(now) <= time.Duration(0) {
-			m.rwlock.RUnlock()
-			m.rwlock.Lock()
-			MakeAlive(&slave.Meta)
-			m.rwlock.Unlock()
-			return slave.Item, &slave.Meta
+		if meta.quotaUsed >= meta.quota {
+			return false
 		}
 	}
+	if meta.limiter != nil && !meta.limiter.AllowN(now, 1) {
+		return false
+	}
+	if meta.quota > 0 {
+		meta.quotaUsed++
+		if meta.quotaUsed == meta.quota {
+			m.log.Warn("mspool upstream quota exhausted", "upstream", meta.Identity,
+				"resets", meta.quotaStart.Add(meta.quotaPeriod))
+		}
+	}
+	return true
+}
 
-	// If none of the others were successfully, we may have to
-	// wait till first rpc comes back online and send it
-	m.rwlock.RUnlock()
+func (m *MasterSlavePool[I]) acquire(meta *PoolNodeMeta) {
+	atomic.AddInt64(&meta.inFlight, 1)
+	instrumentation.RPCUpstreamSelected.WithLabelValues(meta.Identity).Inc()
+	instrumentation.RPCUpstreamInFlight.WithLabelValues(meta.Identity).Inc()
+}
+
+// Observe reports completion of a call on item obtained from GetItem
+// along with the time it took, successful or not
+func (m *MasterSlavePool[I]) Observe(item *I, latency time.Duration) error {
+	pn, ok := m.itemMap[item]
+	if !ok {
+		return errors.New("item not found")
+	}
+	meta := &pn.Meta
+	atomic.AddInt64(&meta.inFlight, -1)
+
+	for {
+		old := atomic.LoadInt64(&meta.latencyEWMA)
+		updated := int64(latency)
+		if old != 0 {
+			updated = old + int64(ewmaAlpha*float64(int64(latency)-old))
+		}
+		if atomic.CompareAndSwapInt64(&meta.latencyEWMA, old, updated) {
+			instrumentation.RPCUpstreamLatencyEWMA.WithLabelValues(meta.Identity).Set(time.Duration(updated).Seconds())
+			break
+		}
+	}
+	instrumentation.RPCUpstreamInFlight.WithLabelValues(meta.Identity).Dec()
+	instrumentation.RPCUpstreamLatency.WithLabelValues(meta.Identity).Observe(latency.Seconds())
+	return nil
+}
+
+// SetLimit sets rate limit and quota for an upstream
+func SetLimit(m *PoolNodeMeta, limit UpstreamLimit) {
+	m.limitMutex.Lock()
+	defer m.limitMutex.Unlock()
 
-	// Very expensive proposition, lot of mutex lock unlocks
-	return m.allFailureRecovery()
+	m.limiter = nil
+	if limit.RateLimit > 0 {
+		burst := limit.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		m.limiter = rate.NewLimiter(rate.Limit(limit.RateLimit), burst)
+	}
+	m.quota = limit.Quota
+	m.quotaPeriod = limit.QuotaPeriod
+	m.quotaStart = time.Time{}
+	m.quotaUsed = 0
 }
 
 func (m *MasterSlavePool[I]) allFailureRecovery() (*I, *PoolNodeMeta) {
@@ -274,6 +527,79 @@ func MakeAlive(m *PoolNodeMeta) {
 	m.BringAlive = time.Time{}
 }
 
+// PollHeads periodically fetches head of every upstream, including ones
+// in cooldown, till ctx is done and marks upstreams more than MaxHeadLag
+// blocks behind best head as lagging. Head polls are not counted against
+// upstream limits
+func (m *MasterSlavePool[I]) PollHeads(ctx context.Context, dur time.Duration,
+	fetch func(context.Context, *I) (uint64, error)) {
+	nodes := append([]*PoolNode[*I]{m.Master}, m.Slaves...)
+	for {
+		wg := sync.WaitGroup{}
+		for _, node := range nodes {
+			wg.Add(1)
+			go func(node *PoolNode[*I]) {
+				defer wg.Done()
+				ctx, cancel := context.WithTimeout(ctx, m.RPCTimeout)
+				defer cancel()
+				head, err := fetch(ctx, node.Item)
+				if err != nil {
+					m.log.Warn("mspool head poll failed", "upstream", node.Meta.Identity, "error", err)
+					return
+				}
+				atomic.StoreUint64(&node.Meta.head, head)
+			}(node)
+		}
+		wg.Wait()
+		m.markLagging(nodes)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(dur):
+		}
+	}
+}
+
+// markLagging marks upstreams behind best head as lagging. Once any
+// head is known, upstreams whose head is not are marked lagging too
+func (m *MasterSlavePool[I]) markLagging(nodes []*PoolNode[*I]) {
+	var best uint64
+	for _, node := range nodes {
+		if head := node.Meta.Head(); head > best {
+			best = head
+		}
+	}
+	if best == 0 {
+		return
+	}
+	atomic.StoreInt32(&m.headsKnown, 1)
+	for _, node := range nodes {
+		head := node.Meta.Head()
+		lag := best - head
+		var lagging int32
+		if head == 0 || lag > m.config.MaxHeadLag {
+			lagging = 1
+		}
+		if atomic.SwapInt32(&node.Meta.lagging, lagging) != lagging {
+			if head == 0 {
+				m.log.Warn("mspool upstream head unknown, treating as lagging", "upstream", node.Meta.Identity,
+					"best", best)
+			} else if lagging == 1 {
+				m.log.Warn("mspool upstream lagging behind best head", "upstream", node.Meta.Identity,
+					"head", head, "best", best)
+			} else {
+				m.log.Info("mspool upstream caught up with best head", "upstream", node.Meta.Identity,
+					"head", head, "best", best)
+			}
+		}
+		if head == 0 {
+			continue
+		}
+		instrumentation.RPCUpstreamHead.WithLabelValues(node.Meta.Identity).Set(float64(head))
+		instrumentation.RPCUpstreamHeadLag.WithLabelValues(node.Meta.Identity).Set(float64(lag))
+	}
+}
+
 // No Read lock based approach, may be wrong but avoids
 // locking / unlocking saving mutex access for other goroutines
 func (m *MasterSlavePool[I]) PeriodicRecording(dur time.Duration) {
@@ -0,0 +1,378 @@
+package ethrpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/sync/semaphore"
+)
+
+// Call batching modes for MSPoolEthRPCImpl
+const (
+	// Every call is sent on its own
+	BatchingOff = "off"
+	// Concurrent calls are sent as one JSON-RPC batch
+	BatchingJSONRPC = "jsonrpc"
+	// Concurrent eth_calls for the same block are aggregated into one
+	// Multicall3 aggregate3 call, rest are sent as a JSON-RPC batch
+	BatchingMulticall3 = "multicall3"
+)
+
+// Multicall3 is deployed at the same address on most evm chains
+var DefaultMulticall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+const multicall3ABI = `[{"inputs":[{"components":[` +
+	`{"internalType":"address","name":"target","type":"address"},` +
+	`{"internalType":"bool","name":"allowFailure","type":"bool"},` +
+	`{"internalType":"bytes","name":"callData","type":"bytes"}],` +
+	`"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],` +
+	`"name":"aggregate3","outputs":[{"components":[` +
+	`{"internalType":"bool","name":"success","type":"bool"},` +
+	`{"internalType":"bytes","name":"returnData","type":"bytes"}],` +
+	`"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],` +
+	`"stateMutability":"payable","type":"function"}]`
+
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// batchedCall is a single JSON-RPC call waiting in a batch
+type batchedCall struct {
+	method string
+	args   []interface{}
+	result interface{}
+	err    error
+	done   chan struct{}
+
+	// Set for eth_call only
+	msg   *ethereum.CallMsg
+	block *big.Int
+}
+
+type callBatch struct {
+	calls   []*batchedCall
+	flushed bool
+}
+
+// callBatcher collects concurrent calls made within a window and sends
+// them upstream together. Errors of individual calls, such as reverts,
+// are returned to their callers only. callBatcher implements
+// bind.ContractCaller so that it can be used with abigen bindings.
+type callBatcher struct {
+	log              logger.Logger
+	pool             *MasterSlavePool[ethclient.Client]
+	rpcClients       map[*ethclient.Client]*rpc.Client
+	sem              *semaphore.Weighted
+	mode             string
+	window           time.Duration
+	maxBatchSize     int
+	multicall3       abi.ABI
+	multicall3Target common.Address
+
+	mu      sync.Mutex
+	pending *callBatch
+}
+
+func newCallBatcher(log logger.Logger,
+	pool *MasterSlavePool[ethclient.Client],
+	rpcClients map[*ethclient.Client]*rpc.Client,
+	sem *semaphore.Weighted,
+	mode string,
+	window time.Duration,
+	maxBatchSize int,
+	multicall3Target common.Address) (*callBatcher, error) {
+	if mode != BatchingJSONRPC && mode != BatchingMulticall3 {
+		return nil, errors.New("unknown batching mode: " + mode)
+	}
+	if maxBatchSize < 1 {
+		return nil, errors.New("batch size should be at least 1")
+	}
+	multicall3, err := abi.JSON(strings.NewReader(multicall3ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &callBatcher{
+		log:              log,
+		pool:             pool,
+		rpcClients:       rpcClients,
+		sem:              sem,
+		mode:             mode,
+		window:           window,
+		maxBatchSize:     maxBatchSize,
+		multicall3:       multicall3,
+		multicall3Target: multicall3Target,
+	}, nil
+}
+
+// CodeAt implements bind.ContractCaller
+func (b *callBatcher) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code hexutil.Bytes
+	err := b.call(ctx, &batchedCall{
+		method: "eth_getCode",
+		args:   []interface{}{contract, toBlockNumArg(blockNumber)},
+		result: &code,
+		block:  blockNumber,
+	})
+	return code, err
+}
+
+// CallContract implements bind.ContractCaller
+func (b *callBatcher) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var output hexutil.Bytes
+	err := b.call(ctx, &batchedCall{
+		method: "eth_call",
+		args:   []interface{}{toCallArg(msg), toBlockNumArg(blockNumber)},
+		result: &output,
+		msg:    &msg,
+		block:  blockNumber,
+	})
+	return output, err
+}
+
+// TransactionSender returns sender of transaction with given hash
+func (b *callBatcher) TransactionSender(ctx context.Context, txHash common.Hash) (common.Address, error) {
+	var tx struct {
+		From *common.Address `json:"from"`
+	}
+	if err := b.call(ctx, &batchedCall{
+		method: "eth_getTransactionByHash",
+		args:   []interface{}{txHash},
+		result: &tx,
+	}); err != nil {
+		return common.Address{}, err
+	}
+	if tx.From == nil {
+		return common.Address{}, ethereum.NotFound
+	}
+	return *tx.From, nil
+}
+
+// call adds c to pending batch and waits for it to be sent
+func (b *callBatcher) call(ctx context.Context, c *batchedCall) error {
+	c.done = make(chan struct{})
+
+	b.mu.Lock()
+	if b.pending == nil {
+		batch := &callBatch{}
+		b.pending = batch
+		time.AfterFunc(b.window, func() { b.flush(batch) })
+	}
+	batch := b.pending
+	batch.calls = append(batch.calls, c)
+	full := len(batch.calls) >= b.maxBatchSize
+	b.mu.Unlock()
+
+	if full {
+		go b.flush(batch)
+	}
+
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *callBatcher) flush(batch *callBatch) {
+	b.mu.Lock()
+	if batch.flushed {
+		b.mu.Unlock()
+		return
+	}
+	batch.flushed = true
+	if b.pending == batch {
+		b.pending = nil
+	}
+	b.mu.Unlock()
+
+	rest := batch.calls
+	wg := sync.WaitGroup{}
+	if b.mode == BatchingMulticall3 {
+		var groups map[string][]*batchedCall
+		groups, rest = b.multicallGroups(batch.calls)
+		for _, group := range groups {
+			wg.Add(1)
+			go func(group []*batchedCall) {
+				defer wg.Done()
+				b.sendMulticall(group)
+			}(group)
+		}
+	}
+	if len(rest) > 0 {
+		b.sendJSONRPC(rest)
+	}
+	wg.Wait()
+}
+
+// multicallGroups groups eth_calls which can be aggregated by block,
+// returning the rest. Calls setting sender, value or gas cannot be
+// aggregated as they would be made by Multicall3 contract.
+func (b *callBatcher) multicallGroups(calls []*batchedCall) (map[string][]*batchedCall, []*batchedCall) {
+	groups := make(map[string][]*batchedCall)
+	rest := []*batchedCall{}
+	for _, c := range calls {
+		if c.msg == nil || c.msg.To == nil || c.msg.From != (common.Address{}) ||
+			(c.msg.Value != nil && c.msg.Value.Sign() != 0) || c.msg.Gas != 0 {
+			rest = append(rest, c)
+			continue
+		}
+		key := toBlockNumArg(c.block)
+		groups[key] = append(groups[key], c)
+	}
+	for key, group := range groups {
+		if len(group) == 1 {
+			rest = append(rest, group[0])
+			delete(groups, key)
+		}
+	}
+	return groups, rest
+}
+
+func (b *callBatcher) sendMulticall(calls []*batchedCall) {
+	aggregated := make([]multicall3Call, len(calls))
+	for i, c := range calls {
+		aggregated[i] = multicall3Call{Target: *c.msg.To, AllowFailure: true, CallData: c.msg.Data}
+	}
+	data, err := b.multicall3.Pack("aggregate3", aggregated)
+	if err != nil {
+		b.finish(calls, err)
+		return
+	}
+
+	block := calls[0].block
+	output, err := DoAtHeight(b.pool, b.sem, heightOf(calls),
+		func(ctx context.Context, c *ethclient.Client) ([]byte, error) {
+			return c.CallContract(ctx, ethereum.CallMsg{To: &b.multicall3Target, Data: data}, block)
+		}, nil)
+
+	var results []multicall3Result
+	if err == nil {
+		var unpacked []interface{}
+		unpacked, err = b.multicall3.Unpack("aggregate3", output)
+		if err == nil && len(unpacked) == 1 {
+			results = *abi.ConvertType(unpacked[0], new([]multicall3Result)).(*[]multicall3Result)
+		}
+	}
+	if err != nil || len(results) != len(calls) {
+		// Multicall3 may not be deployed at this block
+		b.log.Debug("multicall3 aggregate failed, sending calls as batch",
+			"block", toBlockNumArg(block), "calls", len(calls), "error", err)
+		b.sendJSONRPC(calls)
+		return
+	}
+
+	for i, c := range calls {
+		if results[i].Success {
+			*c.result.(*hexutil.Bytes) = results[i].ReturnData
+		} else {
+			c.err = revertError(results[i].ReturnData)
+		}
+		close(c.done)
+	}
+}
+
+func (b *callBatcher) sendJSONRPC(calls []*batchedCall) {
+	_, err := DoAtHeight(b.pool, b.sem, heightOf(calls),
+		func(ctx context.Context, c *ethclient.Client) (bool, error) {
+			rc, ok := b.rpcClients[c]
+			if !ok {
+				return false, errors.New("no rpc client for upstream")
+			}
+			elems := make([]rpc.BatchElem, len(calls))
+			for i, c := range calls {
+				elems[i] = rpc.BatchElem{Method: c.method, Args: c.args, Result: c.result}
+			}
+			if err := rc.BatchCallContext(ctx, elems); err != nil {
+				return false, err
+			}
+			for i, c := range calls {
+				c.err = elems[i].Error
+			}
+			return true, nil
+		}, false)
+	if err != nil {
+		b.finish(calls, err)
+		return
+	}
+	for _, c := range calls {
+		close(c.done)
+	}
+}
+
+// finish fails all calls with err
+func (b *callBatcher) finish(calls []*batchedCall, err error) {
+	for _, c := range calls {
+		c.err = err
+		close(c.done)
+	}
+}
+
+// heightOf returns highest block number calls are made at
+func heightOf(calls []*batchedCall) uint64 {
+	var height uint64
+	for _, c := range calls {
+		if c.block != nil && c.block.Sign() > 0 && c.block.Uint64() > height {
+			height = c.block.Uint64()
+		}
+	}
+	return height
+}
+
+// revertError formats failure of an aggregated call the way upstreams
+// report reverted eth_calls
+func revertError(data []byte) error {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return errors.New("execution reverted: " + reason)
+	}
+	return errors.New("execution reverted")
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	pending := big.NewInt(-1)
+	if number.Cmp(pending) == 0 {
+		return "pending"
+	}
+	return hexutil.EncodeBig(number)
+}
+
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	return arg
+}
@@ -0,0 +1,239 @@
+package ethrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sync/semaphore"
+)
+
+var (
+	revertingData = []byte{0xde, 0xad}
+	codeResult    = []byte{0x60, 0x80}
+)
+
+type callArgs struct {
+	From common.Address  `json:"from"`
+	To   *common.Address `json:"to"`
+	Data hexutil.Bytes   `json:"data"`
+}
+
+// ethService echoes call data of eth_calls, reverting ones with
+// revertingData, and aggregates Multicall3 calls for blocks from
+// multicall3Block onwards
+type ethService struct {
+	multicall3      abi.ABI
+	multicall3Block uint64
+
+	mu         sync.Mutex
+	aggregated [][]multicall3Call
+}
+
+func (s *ethService) Call(args callArgs, block string) (hexutil.Bytes, error) {
+	if *args.To != DefaultMulticall3Address {
+		if bytes.Equal(args.Data, revertingData) {
+			return nil, errors.New("execution reverted")
+		}
+		return args.Data, nil
+	}
+
+	height, err := hexutil.DecodeUint64(block)
+	if err != nil || height < s.multicall3Block {
+		return nil, nil
+	}
+	method := s.multicall3.Methods["aggregate3"]
+	unpacked, err := method.Inputs.Unpack(args.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	calls := *abi.ConvertType(unpacked[0], new([]multicall3Call)).(*[]multicall3Call)
+	s.mu.Lock()
+	s.aggregated = append(s.aggregated, calls)
+	s.mu.Unlock()
+
+	results := make([]multicall3Result, len(calls))
+	for i, c := range calls {
+		if !bytes.Equal(c.CallData, revertingData) {
+			results[i] = multicall3Result{Success: true, ReturnData: c.CallData}
+		}
+	}
+	return method.Outputs.Pack(results)
+}
+
+func (s *ethService) GetCode(contract common.Address, block string) (hexutil.Bytes, error) {
+	return codeResult, nil
+}
+
+// requestCounter counts http requests carrying single calls and
+// JSON-RPC batches
+type requestCounter struct {
+	handler http.Handler
+
+	mu      sync.Mutex
+	singles int
+	batches []int
+}
+
+func (c *requestCounter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	c.mu.Lock()
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		c.batches = append(c.batches, bytes.Count(trimmed, []byte(`"method"`)))
+	} else {
+		c.singles++
+	}
+	c.mu.Unlock()
+	c.handler.ServeHTTP(w, r)
+}
+
+func newTestBatcher(t *testing.T, mode string, window time.Duration, maxBatchSize int) (*callBatcher, *ethService, *requestCounter) {
+	multicall3, err := abi.JSON(strings.NewReader(multicall3ABI))
+	assert.Nil(t, err)
+	service := &ethService{multicall3: multicall3, multicall3Block: 5}
+	server := rpc.NewServer()
+	assert.Nil(t, server.RegisterName("eth", service))
+	counter := &requestCounter{handler: server}
+	httpServer := httptest.NewServer(counter)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	rc, err := rpc.Dial(httpServer.URL)
+	assert.Nil(t, err)
+	client := ethclient.NewClient(rc)
+	master := NewNode(client, "master")
+	pool := &MasterSlavePool[ethclient.Client]{
+		config:     DefaultMSPoolConfig,
+		log:        logger.NewNopLogger(),
+		itemMap:    map[*ethclient.Client]*PoolNode[*ethclient.Client]{client: &master},
+		Master:     &master,
+		RPCTimeout: time.Second,
+	}
+	batcher, err := newCallBatcher(logger.NewNopLogger(), pool,
+		map[*ethclient.Client]*rpc.Client{client: rc}, semaphore.NewWeighted(4),
+		mode, window, maxBatchSize, DefaultMulticall3Address)
+	assert.Nil(t, err)
+	return batcher, service, counter
+}
+
+type callResult struct {
+	output []byte
+	err    error
+}
+
+// callConcurrently makes calls on batcher at once, returning their results in order
+func callConcurrently(batcher *callBatcher, msgs []ethereum.CallMsg, blocks []*big.Int) []callResult {
+	results := make([]callResult, len(msgs))
+	wg := sync.WaitGroup{}
+	for idx := range msgs {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			output, err := batcher.CallContract(context.Background(), msgs[idx], blocks[idx])
+			results[idx] = callResult{output, err}
+		}(idx)
+	}
+	wg.Wait()
+	return results
+}
+
+func callMsg(target byte, data []byte) ethereum.CallMsg {
+	to := common.BytesToAddress([]byte{target})
+	return ethereum.CallMsg{To: &to, Data: data}
+}
+
+func TestJSONRPCBatching(t *testing.T) {
+	batcher, service, counter := newTestBatcher(t, BatchingJSONRPC, 50*time.Millisecond, 10)
+
+	msgs := []ethereum.CallMsg{callMsg(1, []byte{1}), callMsg(2, revertingData), callMsg(3, []byte{3})}
+	blocks := []*big.Int{big.NewInt(6), big.NewInt(6), nil}
+	var code []byte
+	var codeErr error
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		code, codeErr = batcher.CodeAt(context.Background(), *msgs[0].To, nil)
+	}()
+	results := callConcurrently(batcher, msgs, blocks)
+	wg.Wait()
+
+	// Revert fails only the call which reverted
+	assert.Equal(t, callResult{[]byte{1}, nil}, results[0])
+	assert.EqualError(t, results[1].err, "execution reverted")
+	assert.Equal(t, callResult{[]byte{3}, nil}, results[2])
+	assert.Nil(t, codeErr)
+	assert.Equal(t, codeResult, code)
+
+	assert.Equal(t, 0, counter.singles)
+	assert.Equal(t, []int{4}, counter.batches)
+	assert.Empty(t, service.aggregated)
+}
+
+func TestJSONRPCBatchFlushedOnceFull(t *testing.T) {
+	batcher, _, counter := newTestBatcher(t, BatchingJSONRPC, time.Hour, 2)
+
+	msgs := []ethereum.CallMsg{callMsg(1, []byte{1}), callMsg(2, []byte{2})}
+	results := callConcurrently(batcher, msgs, []*big.Int{nil, nil})
+	assert.Equal(t, []callResult{{[]byte{1}, nil}, {[]byte{2}, nil}}, results)
+	assert.Equal(t, []int{2}, counter.batches)
+}
+
+func TestMulticall3Batching(t *testing.T) {
+	batcher, service, counter := newTestBatcher(t, BatchingMulticall3, 50*time.Millisecond, 10)
+
+	withSender := callMsg(4, []byte{4})
+	withSender.From = common.BytesToAddress([]byte{0xff})
+	msgs := []ethereum.CallMsg{
+		callMsg(1, []byte{1}), callMsg(2, revertingData), callMsg(3, []byte{3}),
+		withSender, callMsg(5, []byte{5}),
+	}
+	blocks := []*big.Int{big.NewInt(6), big.NewInt(6), big.NewInt(6), big.NewInt(6), big.NewInt(7)}
+	results := callConcurrently(batcher, msgs, blocks)
+
+	assert.Equal(t, callResult{[]byte{1}, nil}, results[0])
+	assert.EqualError(t, results[1].err, "execution reverted")
+	assert.Equal(t, callResult{[]byte{3}, nil}, results[2])
+	assert.Equal(t, callResult{[]byte{4}, nil}, results[3])
+	assert.Equal(t, callResult{[]byte{5}, nil}, results[4])
+
+	// Calls at block 6 are aggregated, call with sender and lone call
+	// at block 7 are sent as a batch
+	assert.Equal(t, 1, len(service.aggregated))
+	assert.Equal(t, 3, len(service.aggregated[0]))
+	assert.Equal(t, 1, counter.singles)
+	assert.Equal(t, []int{2}, counter.batches)
+}
+
+func TestMulticall3FallsBackToBatch(t *testing.T) {
+	batcher, service, counter := newTestBatcher(t, BatchingMulticall3, 50*time.Millisecond, 10)
+
+	// Multicall3 is not deployed before block 5
+	msgs := []ethereum.CallMsg{callMsg(1, []byte{1}), callMsg(2, revertingData)}
+	results := callConcurrently(batcher, msgs, []*big.Int{big.NewInt(4), big.NewInt(4)})
+
+	assert.Equal(t, callResult{[]byte{1}, nil}, results[0])
+	assert.EqualError(t, results[1].err, "execution reverted")
+	assert.Empty(t, service.aggregated)
+	assert.Equal(t, 1, counter.singles)
+	assert.Equal(t, []int{2}, counter.batches)
+}
@@ -3,6 +3,7 @@ package ethrpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/supragya/EtherScope/libs/util"
@@ -13,6 +14,17 @@ func Do[C any, T any](upstreams *MasterSlavePool[C],
 	sem *semaphore.Weighted,
 	foo func(context.Context, *C) (T, error),
 	defaultVal T) (T, error) {
+	return DoAtHeight(upstreams, sem, 0, foo, defaultVal)
+}
+
+// DoAtHeight is Do for calls which need upstream to have seen block
+// at height, e.g. log queries. Such calls are never sent to upstreams
+// known to be behind height.
+func DoAtHeight[C any, T any](upstreams *MasterSlavePool[C],
+	sem *semaphore.Weighted,
+	height uint64,
+	foo func(context.Context, *C) (T, error),
+	defaultVal T) (T, error) {
 
 	util.ENOK(sem.Acquire(context.Background(), 1))
 	defer sem.Release(1)
@@ -21,12 +33,19 @@ func Do[C any, T any](upstreams *MasterSlavePool[C],
 	maxRetries := (len(upstreams.Slaves) + 1) * int(DefaultMSPoolConfig.WindowSize)
 
 	for retries := 0; retries < maxRetries; retries++ {
-		client, _ := upstreams.GetItem()
+		client, _ := upstreams.GetItemAtHeight(height)
+		if client == nil {
+			gerr = fmt.Errorf("no upstream has reached height %v", height)
+			time.Sleep(upstreams.config.TimeStep)
+			continue
+		}
 		// log.Info(client)
 		ctx := util.NewCtx(upstreams.RPCTimeout)
 		// d, _ := ctx.Deadline()
 		// log.Info("client found: ", meta.Identity, " with ", meta.Reports, " time rem context: ", d.Sub(time.Now()))
+		start := time.Now()
 		out, _err := foo(ctx, client)
+		upstreams.Observe(client, time.Since(start))
 		if _err == nil {
 			return out, nil
 		}
@@ -1,13 +1,14 @@
 package ethrpc
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	logger "github.com/supragya/EtherScope/libs/log"
-	"github.com/supragya/EtherScope/libs/util"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -17,16 +18,12 @@ type IS struct {
 }
 
 func TestBasic(t *testing.T) {
-	logger.SetupLog()
-	util.ENOK(logger.SetLogLevel("fatal"))
 	pool := newIntegerPool(1)
 	item, _ := pool.GetItem()
 	assert.Equal(t, *item, IS{0})
 }
 
 func TestSwitchoverMasterFailureSimulated(t *testing.T) {
-	logger.SetupLog()
-	util.ENOK(logger.SetLogLevel("fatal"))
 	pool := newIntegerPool(1)
 	item, _ := pool.GetItem()
 	assert.Equal(t, *item, IS{0})
@@ -39,8 +36,6 @@ func TestSwitchoverMasterFailureSimulated(t *testing.T) {
 }
 
 func TestNoSwitchoverMasterIntermittentSimulated0(t *testing.T) {
-	logger.SetupLog()
-	util.ENOK(logger.SetLogLevel("fatal"))
 	pool := newIntegerPool(1)
 	item, _ := pool.GetItem()
 	assert.Equal(t, *item, IS{0})
@@ -53,8 +48,6 @@ func TestNoSwitchoverMasterIntermittentSimulated0(t *testing.T) {
 }
 
 func TestNoSwitchoverMasterIntermittentSimulated1(t *testing.T) {
-	logger.SetupLog()
-	util.ENOK(logger.SetLogLevel("fatal"))
 	pool := newIntegerPool(1)
 	item, _ := pool.GetItem()
 	assert.Equal(t, *item, IS{0})
@@ -66,25 +59,35 @@ func TestNoSwitchoverMasterIntermittentSimulated1(t *testing.T) {
 }
 
 func TestNoSwitchoverMasterIntermittentSimulated2(t *testing.T) {
-	logger.SetupLog()
-	util.ENOK(logger.SetLogLevel("fatal"))
 	pool := newIntegerPool(1)
 	item, _ := pool.GetItem()
 	assert.Equal(t, *item, IS{0})
+	// Failures are reported at once, not spread over spawning, and
+	// upstreams asked for once all are in
+	wg, reported := sync.WaitGroup{}, sync.WaitGroup{}
+	start, get := make(chan struct{}), make(chan struct{})
 	for i := 0; i < 10000; i++ {
+		wg.Add(1)
+		reported.Add(1)
 		go func(pool *MasterSlavePool[IS]) {
+			defer wg.Done()
+			<-start
 			assert.Equal(t, nil, pool.Report(item, true))
+			reported.Done()
+			<-get
 			threadItem, _ := pool.GetItem()
 			assert.Equal(t, *threadItem, IS{0})
-		}(&pool)
+		}(pool)
 	}
+	close(start)
+	reported.Wait()
+	close(get)
+	wg.Wait()
 	newItem, _ := pool.GetItem()
 	assert.Equal(t, *newItem, IS{0})
 }
 
 func TestSwitchoverMasterFailureReal(t *testing.T) {
-	logger.SetupLog()
-	util.ENOK(logger.SetLogLevel("fatal"))
 	pool := newIntegerPool(1)
 	item, _ := pool.GetItem()
 	assert.Equal(t, *item, IS{0})
@@ -97,8 +100,6 @@ func TestSwitchoverMasterFailureReal(t *testing.T) {
 }
 
 func TestRetryMasterSimulated(t *testing.T) {
-	logger.SetupLog()
-	util.ENOK(logger.SetLogLevel("fatal"))
 	pool := newIntegerPool(1)
 	item, _ := pool.GetItem()
 	assert.Equal(t, *item, IS{0})
@@ -114,8 +115,6 @@ func TestRetryMasterSimulated(t *testing.T) {
 }
 
 func TestApplicationBlockageShouldProgress(t *testing.T) {
-	logger.SetupLog()
-	util.ENOK(logger.SetLogLevel("fatal"))
 	pool := newIntegerPool(1)
 	item, _ := pool.GetItem()
 	assert.Equal(t, *item, IS{0})
@@ -123,7 +122,7 @@ func TestApplicationBlockageShouldProgress(t *testing.T) {
 		go func(pool *MasterSlavePool[IS]) {
 			threadItem, _ := pool.GetItem()
 			assert.Equal(t, nil, pool.Report(threadItem, true))
-		}(&pool)
+		}(pool)
 		time.Sleep(time.Millisecond)
 	}
 	time.Sleep(time.Millisecond * 10 * 100)
@@ -132,7 +131,7 @@ func TestApplicationBlockageShouldProgress(t *testing.T) {
 }
 
 // Returns a 10ms timestep MS pool
-func newIntegerPool(slaveCount int) MasterSlavePool[IS] {
+func newIntegerPool(slaveCount int) *MasterSlavePool[IS] {
 	itemMap := make(map[*IS]*PoolNode[*IS], slaveCount+1)
 	masterNode := &IS{0}
 	master := NewNode(masterNode, "master")
@@ -153,8 +152,9 @@ func newIntegerPool(slaveCount int) MasterSlavePool[IS] {
 		RetryTimesteps: 100,
 	}
 
-	return MasterSlavePool[IS]{
+	return &MasterSlavePool[IS]{
 		config:               cfg,
+		log:                  logger.NewNopLogger(),
 		rwlock:               sync.RWMutex{},
 		allFailureLogTime:    time.Time{},
 		allFailureCachedItem: nil,
@@ -163,3 +163,159 @@ func newIntegerPool(slaveCount int) MasterSlavePool[IS] {
 		Slaves:               slaves,
 	}
 }
+
+func TestRoundRobinStrategy(t *testing.T) {
+	pool := newIntegerPool(2)
+	pool.config.Strategy = MSPoolStrategyRoundRobin
+	seen := map[IS]int{}
+	for i := 0; i < 6; i++ {
+		item, _ := pool.GetItem()
+		seen[*item]++
+		assert.Equal(t, nil, pool.Observe(item, time.Millisecond))
+	}
+	assert.Equal(t, map[IS]int{{0}: 2, {1}: 2, {2}: 2}, seen)
+}
+
+func TestLeastInFlightStrategy(t *testing.T) {
+	pool := newIntegerPool(2)
+	pool.config.Strategy = MSPoolStrategyLeastInFlight
+	seen := map[IS]bool{}
+	for i := 0; i < 3; i++ {
+		item, meta := pool.GetItem()
+		assert.Equal(t, int64(1), meta.InFlight())
+		seen[*item] = true
+	}
+	assert.Equal(t, 3, len(seen))
+
+	// Upstream which completed its call is the only one idle
+	slave := pool.Slaves[1]
+	assert.Equal(t, nil, pool.Observe(slave.Item, time.Millisecond))
+	item, _ := pool.GetItem()
+	assert.Equal(t, IS{2}, *item)
+}
+
+func TestEWMALatencyStrategy(t *testing.T) {
+	pool := newIntegerPool(2)
+	pool.config.Strategy = MSPoolStrategyEWMALatency
+	for idx, latency := range []time.Duration{30, 10, 20} {
+		node := pool.Master
+		if idx > 0 {
+			node = pool.Slaves[idx-1]
+		}
+		pool.acquire(&node.Meta)
+		assert.Equal(t, nil, pool.Observe(node.Item, latency*time.Millisecond))
+	}
+	item, meta := pool.GetItem()
+	assert.Equal(t, IS{1}, *item)
+	assert.Equal(t, 10*time.Millisecond, meta.LatencyEWMA())
+
+	// A slow call moves average towards it: 10ms + 0.3 * (100ms - 10ms)
+	assert.Equal(t, nil, pool.Observe(item, 100*time.Millisecond))
+	assert.Equal(t, 37*time.Millisecond, meta.LatencyEWMA())
+	item, _ = pool.GetItem()
+	assert.Equal(t, IS{2}, *item)
+}
+
+func TestQuotaExhaustedUpstreamSkipped(t *testing.T) {
+	pool := newIntegerPool(1)
+	SetLimit(&pool.Master.Meta, UpstreamLimit{Quota: 1, QuotaPeriod: time.Hour})
+	item, _ := pool.GetItem()
+	assert.Equal(t, IS{0}, *item)
+	item, _ = pool.GetItem()
+	assert.Equal(t, IS{1}, *item)
+}
+
+func TestLaggingUpstreamSkipped(t *testing.T) {
+	pool := newIntegerPool(2)
+	pool.config.MaxHeadLag = 5
+	nodes := append([]*PoolNode[*IS]{pool.Master}, pool.Slaves...)
+	setHeads := func(heads ...uint64) {
+		for idx, head := range heads {
+			atomic.StoreUint64(&nodes[idx].Meta.head, head)
+		}
+		pool.markLagging(nodes)
+	}
+
+	setHeads(100, 110, 108)
+	assert.True(t, pool.Master.Meta.IsLagging())
+	assert.False(t, pool.Slaves[1].Meta.IsLagging())
+	item, _ := pool.GetItem()
+	assert.Equal(t, IS{1}, *item)
+
+	// Only upstreams which have seen height are used for it
+	pool.config.Strategy = MSPoolStrategyRoundRobin
+	for i := 0; i < 3; i++ {
+		item, _ = pool.GetItemAtHeight(109)
+		assert.Equal(t, IS{1}, *item)
+	}
+	item, _ = pool.GetItemAtHeight(111)
+	assert.Nil(t, item)
+
+	// Master catches up
+	setHeads(107)
+	assert.False(t, pool.Master.Meta.IsLagging())
+}
+
+func TestAllLaggingUpstreamsUsed(t *testing.T) {
+	pool := newIntegerPool(1)
+	pool.config.MaxHeadLag = 5
+	atomic.StoreUint64(&pool.Master.Meta.head, 100)
+	atomic.StoreUint64(&pool.Slaves[0].Meta.head, 110)
+	pool.markLagging([]*PoolNode[*IS]{pool.Master, pool.Slaves[0]})
+
+	// Up to date slave in cooldown leaves lagging master as only choice
+	pool.Slaves[0].Meta.IsAlive = false
+	pool.Slaves[0].Meta.BringAlive = time.Now().Add(time.Hour)
+	item, _ := pool.GetItemAtHeight(100)
+	assert.Equal(t, IS{0}, *item)
+	item, _ = pool.GetItemAtHeight(101)
+	assert.Nil(t, item)
+}
+
+func TestUnknownHeadUpstreamSkipped(t *testing.T) {
+	pool := newIntegerPool(1)
+	pool.config.MaxHeadLag = 5
+
+	// No head known yet, any upstream may serve any height
+	item, _ := pool.GetItemAtHeight(100)
+	assert.Equal(t, IS{0}, *item)
+
+	// Master head poll never succeeded while slave's did
+	atomic.StoreUint64(&pool.Slaves[0].Meta.head, 110)
+	pool.markLagging([]*PoolNode[*IS]{pool.Master, pool.Slaves[0]})
+	assert.True(t, pool.Master.Meta.IsLagging())
+	item, _ = pool.GetItemAtHeight(100)
+	assert.Equal(t, IS{1}, *item)
+
+	pool.Slaves[0].Meta.IsAlive = false
+	pool.Slaves[0].Meta.BringAlive = time.Now().Add(time.Hour)
+	item, _ = pool.GetItemAtHeight(100)
+	assert.Nil(t, item)
+	item, _ = pool.GetItem()
+	assert.Equal(t, IS{0}, *item)
+}
+
+func TestPollHeadsStops(t *testing.T) {
+	pool := newIntegerPool(1)
+	pool.RPCTimeout = time.Second
+	polls := int32(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		pool.PollHeads(ctx, time.Millisecond, func(ctx context.Context, item *IS) (uint64, error) {
+			atomic.AddInt32(&polls, 1)
+			return uint64(100 + item.item), nil
+		})
+		close(done)
+	}()
+	for atomic.LoadInt32(&polls) < 4 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected head polling to stop")
+	}
+	assert.Equal(t, uint64(101), pool.Slaves[0].Meta.Head())
+}
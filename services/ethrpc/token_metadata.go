@@ -0,0 +1,125 @@
+package ethrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	lb "github.com/supragya/EtherScope/services/local_backend"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Kinds of token metadata persisted in LocalBackend
+const (
+	TokenMetadataPairTokens = "pair" // token0 and token1 of uniswap style pairs
+	TokenMetadataDecimals   = "decimals"
+	TokenMetadataName       = "name"
+	TokenMetadataSymbol     = "symbol"
+	TokenMetadataContract   = "contract" // address has code
+)
+
+const TokenMetadataSeedVersion = 1
+
+// TokenMetadataSeed is the format of token metadata seed files. Entries
+// are keyed by `<kind>:<address>` and hold JSON encoded values
+type TokenMetadataSeed struct {
+	Version int                        `json:"version"`
+	Entries map[string]json.RawMessage `json:"entries"`
+}
+
+// TokenMetadataStore persists immutable facts about contracts in
+// LocalBackend so that they need not be refetched across restarts
+type TokenMetadataStore struct {
+	lb lb.LocalBackend
+}
+
+func NewTokenMetadataStore(localBackend lb.LocalBackend) *TokenMetadataStore {
+	return &TokenMetadataStore{lb: localBackend}
+}
+
+func tokenMetadataKey(kind string, address common.Address) string {
+	return fmt.Sprintf("%s:%s:%s", lb.KeyTokenMetadataPrefix, kind, address.Hex())
+}
+
+// Get decodes metadata of kind for address into val, returning false
+// if it is not known
+func (s *TokenMetadataStore) Get(kind string, address common.Address, val interface{}) (bool, error) {
+	data, ok, err := s.lb.Get(tokenMetadataKey(kind, address))
+	if err != nil || !ok {
+		return false, err
+	}
+	return true, json.Unmarshal(data, val)
+}
+
+func (s *TokenMetadataStore) Set(kind string, address common.Address, val interface{}) error {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return s.lb.Set(tokenMetadataKey(kind, address), data)
+}
+
+// Iterate calls fn for every metadata entry in store
+func (s *TokenMetadataStore) Iterate(fn func(kind string, address common.Address, val json.RawMessage) error) error {
+	return s.lb.Iterate(lb.KeyTokenMetadataPrefix+":", func(key string, val []byte) error {
+		parts := strings.Split(key, ":")
+		if len(parts) != 3 || !common.IsHexAddress(parts[2]) {
+			return errors.New("malformed token metadata key: " + key)
+		}
+		return fn(parts[1], common.HexToAddress(parts[2]), val)
+	})
+}
+
+// Export writes all entries in store as a seed file, returning number
+// of entries written
+func (s *TokenMetadataStore) Export(w io.Writer) (int, error) {
+	seed := TokenMetadataSeed{
+		Version: TokenMetadataSeedVersion,
+		Entries: make(map[string]json.RawMessage),
+	}
+	err := s.Iterate(func(kind string, address common.Address, val json.RawMessage) error {
+		seed.Entries[kind+":"+address.Hex()] = val
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return len(seed.Entries), encoder.Encode(seed)
+}
+
+// Import reads a seed file into store, returning number of entries
+// not known to store before. Caller is expected to sync LocalBackend
+func (s *TokenMetadataStore) Import(r io.Reader) (int, error) {
+	seed := TokenMetadataSeed{}
+	if err := json.NewDecoder(r).Decode(&seed); err != nil {
+		return 0, err
+	}
+	if seed.Version != TokenMetadataSeedVersion {
+		return 0, fmt.Errorf("unsupported token metadata seed version %v, expected %v",
+			seed.Version, TokenMetadataSeedVersion)
+	}
+
+	imported := 0
+	for entry, val := range seed.Entries {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 || !common.IsHexAddress(parts[1]) || !json.Valid(val) {
+			return imported, errors.New("malformed token metadata seed entry: " + entry)
+		}
+		key := tokenMetadataKey(parts[0], common.HexToAddress(parts[1]))
+		if _, ok, err := s.lb.Get(key); err != nil || ok {
+			if err != nil {
+				return imported, err
+			}
+			continue
+		}
+		if err := s.lb.Set(key, val); err != nil {
+			return imported, err
+		}
+		imported++
+	}
+	return imported, nil
+}
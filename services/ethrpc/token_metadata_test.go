@@ -0,0 +1,105 @@
+package ethrpc
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+
+	lb "github.com/supragya/EtherScope/services/local_backend"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// memLB is an in-memory LocalBackend
+type memLB struct {
+	lb.LocalBackend
+	kv map[string][]byte
+}
+
+func (m *memLB) Get(key string) ([]byte, bool, error) {
+	val, ok := m.kv[key]
+	return val, ok, nil
+}
+
+func (m *memLB) Set(key string, val []byte) error {
+	m.kv[key] = val
+	return nil
+}
+
+func (m *memLB) Iterate(prefix string, fn func(key string, val []byte) error) error {
+	keys := []string{}
+	for key := range m.kv {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := fn(key, m.kv[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestMetadataStore() *TokenMetadataStore {
+	return NewTokenMetadataStore(&memLB{kv: map[string][]byte{}})
+}
+
+func TestTokenMetadataExportImport(t *testing.T) {
+	token := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	pair := common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")
+	store := newTestMetadataStore()
+	assert.Nil(t, store.Set(TokenMetadataDecimals, token, uint8(18)))
+	assert.Nil(t, store.Set(TokenMetadataSymbol, token, "WETH"))
+	assert.Nil(t, store.Set(TokenMetadataPairTokens, pair, [2]common.Address{token, pair}))
+
+	seed := bytes.Buffer{}
+	exported, err := store.Export(&seed)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, exported)
+
+	// Entries already known are kept as they are
+	imported := newTestMetadataStore()
+	assert.Nil(t, imported.Set(TokenMetadataSymbol, token, "ETH"))
+	count, err := imported.Import(bytes.NewReader(seed.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	var decimals uint8
+	ok, err := imported.Get(TokenMetadataDecimals, token, &decimals)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(18), decimals)
+	var symbol string
+	_, err = imported.Get(TokenMetadataSymbol, token, &symbol)
+	assert.Nil(t, err)
+	assert.Equal(t, "ETH", symbol)
+	var tokens [2]common.Address
+	_, err = imported.Get(TokenMetadataPairTokens, pair, &tokens)
+	assert.Nil(t, err)
+	assert.Equal(t, [2]common.Address{token, pair}, tokens)
+
+	ok, err = imported.Get(TokenMetadataName, token, &symbol)
+	assert.False(t, ok)
+	assert.Nil(t, err)
+
+	// Importing again adds nothing
+	count, err = imported.Import(bytes.NewReader(seed.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestTokenMetadataImportRejectsMalformedSeed(t *testing.T) {
+	for _, seed := range []string{
+		`{"version": 2, "entries": {}}`,
+		`{"version": 1, "entries": {"decimals": 18}}`,
+		`{"version": 1, "entries": {"decimals:0x12": 18}}`,
+		`not json`,
+	} {
+		count, err := newTestMetadataStore().Import(strings.NewReader(seed))
+		assert.NotNil(t, err, seed)
+		assert.Equal(t, 0, count)
+	}
+}
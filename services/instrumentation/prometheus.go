@@ -23,6 +23,11 @@ var (
 	ProcessedBlock = pg("processed_block", "processed block")
 	CurrentBlock   = pg("current_block", "current blockchain height")
 
+	ReorgsDetected = pc("reorgs_detected", "chain reorganisations detected")
+
+	HeadSubscriptionActive = pg("head_subscription_active", "whether newHeads subscription is driving indexing")
+	HeadSubscriptionEvents = pc("head_subscription_events", "headers received over newHeads subscription")
+
 	TfrFound    = pc("tfr_found", "transfers found")
 	MintV2Found = pc("mintv2_found", "mint v2 found")
 	MintV3Found = pc("mintv3_found", "mint v3 found")
@@ -41,6 +46,17 @@ var (
 
 	TraderJoeV2SwapFound     = pc("trader_joe_v2_swap_found", "trader joe v2 swap found")
 	TraderJoeV2SwapProcessed = pc("trader_joe_v2_swap_processed", "trader joe v2 swap processed")
+
+	GenericEventFound       = pc("generic_event_found", "generic event found")
+	GenericEventProcessed   = pc("generic_event_processed", "generic event processed")
+	GenericEventUndecodable = pc("generic_event_undecodable", "generic event not conforming to abi")
+
+	RPCUpstreamSelected    = pcv("rpc_upstream_selected", "rpc calls sent to upstream", "upstream")
+	RPCUpstreamInFlight    = pgv("rpc_upstream_in_flight", "rpc calls in flight on upstream", "upstream")
+	RPCUpstreamLatency     = phv("rpc_upstream_latency_seconds", "rpc call latency of upstream", "upstream")
+	RPCUpstreamLatencyEWMA = pgv("rpc_upstream_latency_ewma_seconds", "moving average of rpc call latency of upstream", "upstream")
+	RPCUpstreamHead        = pgv("rpc_upstream_head", "last polled head of upstream", "upstream")
+	RPCUpstreamHeadLag     = pgv("rpc_upstream_head_lag", "blocks upstream head is behind best head", "upstream")
 )
 
 func pc(name string, help string) prometheus.Counter {
@@ -56,3 +72,25 @@ func pg(name string, help string) prometheus.Gauge {
 		Help: help,
 	})
 }
+
+func pcv(name string, help string, labels ...string) *prometheus.CounterVec {
+	return promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "indexer_" + name,
+		Help: help,
+	}, labels)
+}
+
+func pgv(name string, help string, labels ...string) *prometheus.GaugeVec {
+	return promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "indexer_" + name,
+		Help: help,
+	}, labels)
+}
+
+func phv(name string, help string, labels ...string) *prometheus.HistogramVec {
+	return promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "indexer_" + name,
+		Help:    help,
+		Buckets: prometheus.DefBuckets,
+	}, labels)
+}
@@ -3,6 +3,8 @@ package localbackend
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -55,6 +57,7 @@ type BadgerDBLocalBackendImpl struct {
 	dbLocation   string
 	namespace    string
 	inMem        map[string][]byte
+	inMemDeleted map[string]bool // keys deleted since last sync
 	db           *badger.DB
 }
 
@@ -92,6 +95,9 @@ func (n *BadgerDBLocalBackendImpl) Get(key string) ([]byte, bool, error) {
 	if val, ok := n.inMem[queryKey]; ok {
 		return val, true, nil
 	}
+	if n.inMemDeleted[queryKey] {
+		return []byte{}, false, nil
+	}
 	value := []byte{}
 	err := n.db.View(func(txn *badger.Txn) error {
 		item, err := txn.Get([]byte(queryKey))
@@ -119,9 +125,76 @@ func (n *BadgerDBLocalBackendImpl) Set(key string, val []byte) error {
 	// 	n.log.Info("set", queryKey, val)
 	// }
 	n.inMem[queryKey] = val
+	delete(n.inMemDeleted, queryKey)
 	return nil
 }
 
+func (n *BadgerDBLocalBackendImpl) Delete(key string) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	queryKey := fmt.Sprintf("%s::%s", n.namespace, key)
+	delete(n.inMem, queryKey)
+	n.inMemDeleted[queryKey] = true
+	return nil
+}
+
+// Iterate calls fn in key order. Matching entries are copied out first,
+// so that fn may use the backend itself
+func (n *BadgerDBLocalBackendImpl) Iterate(prefix string, fn func(key string, val []byte) error) error {
+	entries, err := n.entries(prefix)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := fn(key, entries[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// entries returns entries with keys starting with prefix, values yet
+// to be synced taking precedence over ones on disk
+func (n *BadgerDBLocalBackendImpl) entries(prefix string) (map[string][]byte, error) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	namespacePrefix := fmt.Sprintf("%s::", n.namespace)
+	queryPrefix := namespacePrefix + prefix
+	entries := make(map[string][]byte)
+	err := n.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Seek([]byte(queryPrefix)); it.ValidForPrefix([]byte(queryPrefix)); it.Next() {
+			key := string(it.Item().Key())
+			if _, ok := n.inMem[key]; ok || n.inMemDeleted[key] {
+				continue
+			}
+			val, err := it.Item().ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			entries[strings.TrimPrefix(key, namespacePrefix)] = val
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range n.inMem {
+		if strings.HasPrefix(key, queryPrefix) {
+			entries[strings.TrimPrefix(key, namespacePrefix)] = val
+		}
+	}
+	return entries, nil
+}
+
 func (n *BadgerDBLocalBackendImpl) loop() {
 	for {
 		<-time.After(n.periodicSync)
@@ -140,7 +213,7 @@ func (n *BadgerDBLocalBackendImpl) Sync() error {
 	defer n.lock.Unlock()
 
 	// multiple sync call protection
-	if len(n.inMem) == 0 {
+	if len(n.inMem) == 0 && len(n.inMemDeleted) == 0 {
 		// n.log.Info("multiple sync call protection")
 		return nil
 	}
@@ -168,6 +241,22 @@ func (n *BadgerDBLocalBackendImpl) Sync() error {
 		}
 		count++
 	}
+	for key := range n.inMemDeleted {
+		err = txn.Delete([]byte(key))
+		if err == badger.ErrTxnTooBig {
+			n.log.Info("too big of a transaction, splitting")
+			err = txn.Commit()
+			if err != nil {
+				return err
+			}
+			txn = n.db.NewTransaction(true)
+			err = txn.Delete([]byte(key))
+		}
+		if err != nil {
+			return err
+		}
+		count++
+	}
 	err = txn.Commit()
 	if err != nil {
 		n.log.Error("transaction commit failed", "error", err)
@@ -187,6 +276,7 @@ func (n *BadgerDBLocalBackendImpl) Sync() error {
 
 	// Flush inMem db
 	n.inMem = make(map[string][]byte, 10000)
+	n.inMemDeleted = make(map[string]bool)
 
 	return nil
 }
@@ -207,6 +297,7 @@ func NewBadgerDBWithViperFields(log logger.Logger) (LocalBackend, error) {
 		dbLocation:   viper.GetString(BadgerCFGSection + ".dbLocation"),
 		namespace:    viper.GetString(BadgerCFGSection + ".namespace"),
 		inMem:        make(map[string][]byte, 10000),
+		inMemDeleted: make(map[string]bool),
 		db:           nil,
 	}
 	lb.BaseService = *service.NewBaseService(log, "localbackend", lb)
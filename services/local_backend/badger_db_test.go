@@ -0,0 +1,67 @@
+package localbackend
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+)
+
+func newTestBadgerDB(t *testing.T) *BadgerDBLocalBackendImpl {
+	lb := &BadgerDBLocalBackendImpl{
+		log:          logger.NewNopLogger(),
+		lock:         &sync.RWMutex{},
+		periodicSync: time.Hour,
+		dbLocation:   t.TempDir(),
+		namespace:    "bp",
+		inMem:        make(map[string][]byte),
+		inMemDeleted: make(map[string]bool),
+	}
+	if err := lb.OnStart(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(lb.OnStop)
+	return lb
+}
+
+func TestBadgerDBIterate(t *testing.T) {
+	lb := newTestBadgerDB(t)
+	for _, key := range []string{"pg:3", "pg:1", "pg:4", "other"} {
+		if err := lb.Set(key, []byte(key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := lb.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unsynced changes over synced entries
+	lb.Set("pg:2", []byte("pg:2"))
+	lb.Set("pg:3", []byte("pg:3 updated"))
+	lb.Delete("pg:4")
+
+	// Callbacks may use backend, entries come in key order
+	seen := []string{}
+	err := lb.Iterate("pg:", func(key string, val []byte) error {
+		if _, _, err := lb.Get(key); err != nil {
+			return err
+		}
+		seen = append(seen, string(val))
+		return lb.Delete(key)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(seen, ","); got != "pg:1,pg:2,pg:3 updated" {
+		t.Errorf("unexpected entries %s", got)
+	}
+	if _, ok, _ := lb.Get("pg:1"); ok {
+		t.Error("expected entry deleted during iteration to be gone")
+	}
+	if _, ok, _ := lb.Get("other"); !ok {
+		t.Error("expected entry outside prefix to be kept")
+	}
+}
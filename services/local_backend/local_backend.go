@@ -9,21 +9,39 @@ type LocalBackend interface {
 
 	Get(key string) ([]byte, bool, error)
 	Set(key string, val []byte) error
+	Delete(key string) error
 	Sync() error
+	// Iterate calls fn for every key starting with prefix in key
+	// order, stopping at first error returned by fn
+	Iterate(prefix string, fn func(key string, val []byte) error) error
 }
 
 const (
 	// Provides a uint64 of latest height
 	KeyLatestHeight = "lh"
 
-	// Provides a tuple containing latest pricing graph
-	// height and graph definition
+	// Provides a uint64 of latest height successfully
+	// sent to output sink by node
+	KeyCheckpointHeight = "cph"
+
+	// Provides latest pricing graph as stored before graph
+	// snapshots (KeyGraphPrefix), read only for migration
 	KeyLatestPricingGraph = "lapg"
 
 	// Provides lowest height for which pricing graph is
 	// available
 	KeyLowestPricingHeight = "loph"
 
-	// Graph prefix for height
+	// Pricing graph snapshot prefix, keyed pg:<height>:f for
+	// full graph and pg:<height>:d for edges changed at height
 	KeyGraphPrefix = "pg"
+
+	// Block hash prefix for height slot, provides a tuple
+	// containing height and block hash. Slots are reused
+	// modulo node confirmation depth
+	KeyBlockHashPrefix = "bh"
+
+	// Token metadata prefix, provides immutable facts about
+	// contracts such as erc20 decimals and pair tokens
+	KeyTokenMetadataPrefix = "tm"
 )
@@ -29,10 +29,18 @@ func (n *NoneDBImpl) Set(key string, val []byte) error {
 	return errors.New("cannot set on none_db")
 }
 
+func (n *NoneDBImpl) Delete(key string) error {
+	return nil
+}
+
 func (n *NoneDBImpl) Sync() error {
 	return nil
 }
 
+func (n *NoneDBImpl) Iterate(prefix string, fn func(key string, val []byte) error) error {
+	return nil
+}
+
 func NewNoneDB(log logger.Logger) (LocalBackend, error) {
 	lb := &NoneDBImpl{}
 	lb.BaseService = *service.NewBaseService(log, "localbackend", lb)
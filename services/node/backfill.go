@@ -0,0 +1,160 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/service"
+	"github.com/supragya/EtherScope/services/instrumentation"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// backfillRange is a span of blocks fetched and decoded by a
+// backfill worker. Decoded blocks are handed over on done
+type backfillRange struct {
+	start uint64
+	end   uint64
+	done  chan []*decodedBlock
+}
+
+// Creates a new node service which indexes blocks in [from, to] and
+// stops itself once the range is done. Fetching and decoding happens
+// over many workers in parallel while pricing and dispatch to output
+// sink happens in order of height
+func NewBackfillNodeWithViperFields(log logger.Logger,
+	from uint64,
+	to uint64,
+	workers int) (service.Service, error) {
+	if to < from {
+		return nil, fmt.Errorf("invalid backfill range: %d to %d", from, to)
+	}
+	if workers < 1 {
+		return nil, fmt.Errorf("invalid backfill workers: %d", workers)
+	}
+
+	_node, err := NewNodeWithViperFields(log)
+	if err != nil {
+		return nil, err
+	}
+	node := _node.(*NodeImpl)
+	node.isBackfill = true
+	node.backfillFrom = from
+	node.backfillTo = to
+	node.backfillWorkers = workers
+
+	// Backfills are historical, reorg checks are of no use
+	node.confirmationDepth = 0
+	return node, nil
+}
+
+// backfill runs the backfill for configured range and stops the
+// node on completion
+func (n *NodeImpl) backfill() {
+	n.log.Info(fmt.Sprintf("backfilling blocks %d to %d", n.backfillFrom, n.backfillTo),
+		"workers", n.backfillWorkers,
+		"span", n.maxBlockSpanPerCall)
+
+	span := n.maxBlockSpanPerCall
+	if span == 0 {
+		span = 1
+	}
+
+	jobs := make(chan *backfillRange)
+	pending := make(chan *backfillRange, n.backfillWorkers)
+	quit := make(chan struct{})
+
+	// Dispatcher: hands ranges to workers while keeping them in
+	// order for the committer
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for start := n.backfillFrom; start <= n.backfillTo; start += span {
+			end := start + span - 1
+			if end > n.backfillTo || end < start {
+				end = n.backfillTo
+			}
+			r := &backfillRange{start: start, end: end, done: make(chan []*decodedBlock, 1)}
+			select {
+			case pending <- r:
+			case <-quit:
+				return
+			}
+			select {
+			case jobs <- r:
+			case <-quit:
+				return
+			}
+			if end == n.backfillTo {
+				return
+			}
+		}
+	}()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < n.backfillWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range jobs {
+				r.done <- n.fetchRange(r.start, r.end)
+			}
+		}()
+	}
+
+	// Committer: prices and sends blocks in order of height. next is
+	// tracked instead of last indexed height, as range may start at 0
+	stopped := false
+	next := n.backfillFrom
+COMMIT:
+	for r := range pending {
+		blocks := <-r.done
+		for _, decoded := range blocks {
+			select {
+			case <-n.quitCh:
+				n.log.Info("quitting backfill indexer", "next", next)
+				stopped = true
+				close(quit)
+				break COMMIT
+			default:
+			}
+			backoff.Retry(func() error { return n.commitBlock(decoded) }, n.backoff)
+			n.indexedHeight = decoded.synopsis.Height
+			next = n.indexedHeight + 1
+			instrumentation.ProcessedBlock.Set(float64(n.indexedHeight))
+		}
+		n.log.Info(fmt.Sprintf("backfilled: %d (%d remaining)", r.end, n.backfillTo-r.end))
+	}
+
+	if stopped {
+		return
+	}
+	wg.Wait()
+
+	n.log.Info(fmt.Sprintf("backfill completed for blocks %d to %d", n.backfillFrom, n.backfillTo))
+	n.Stop()
+}
+
+// fetchRange fetches logs and decodes every block in [start, end],
+// retrying till successful
+func (n *NodeImpl) fetchRange(start uint64, end uint64) []*decodedBlock {
+	var release func()
+	kv, _ := backoff.RetryWithData(func() (map[uint64]CLogType, error) {
+		kv, rel, err := n.fetchLogs(start, end)
+		if err != nil {
+			n.log.Error("encountered error", "error", err)
+		}
+		release = rel
+		return kv, err
+	}, n.backoff)
+	defer release()
+
+	blocks := make([]*decodedBlock, 0, end-start+1)
+	for block := start; block <= end; block++ {
+		decoded, _ := backoff.RetryWithData(func() (*decodedBlock, error) {
+			return n.decodeBlock(kv, block)
+		}, n.backoff)
+		blocks = append(blocks, decoded)
+	}
+	return blocks
+}
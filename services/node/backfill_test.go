@@ -0,0 +1,100 @@
+package node
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	oldpriceresolver "github.com/supragya/EtherScope/libs/oldpricing"
+	"github.com/supragya/EtherScope/libs/util"
+	lb "github.com/supragya/EtherScope/services/local_backend"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// rangeRPC records log queries, answering earlier ranges slower so
+// that workers complete out of order
+type rangeRPC struct {
+	*chainRPC
+	mu      sync.Mutex
+	queries [][2]uint64
+}
+
+func (r *rangeRPC) GetFilteredLogs(query ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	r.mu.Lock()
+	r.queries = append(r.queries, [2]uint64{from, to})
+	r.mu.Unlock()
+	time.Sleep(time.Duration(100-from) * time.Millisecond / 10)
+	return nil, nil
+}
+
+func newBackfillNode(from uint64, to uint64, workers int) (*NodeImpl, *rangeRPC, *memLB, *testSink) {
+	rpc := &rangeRPC{chainRPC: newChainRPC()}
+	rpc.extend("a", 0, to)
+	backend, sink := newMemLB(), &testSink{}
+	n := newTestNode(rpc, backend, sink)
+	n.oldpricer = &oldpriceresolver.Pricing{}
+	n.isBackfill = true
+	n.backfillFrom, n.backfillTo, n.backfillWorkers = from, to, workers
+	n.maxBlockSpanPerCall = 10
+	n.confirmationDepth = 0
+	return n, rpc, backend, sink
+}
+
+func TestBackfillOrdering(t *testing.T) {
+	n, _, _, sink := newBackfillNode(0, 45, 4)
+	n.backfill()
+
+	payloads := sink.sent()
+	if len(payloads) != 46 {
+		t.Fatalf("expected 46 payloads, got %d", len(payloads))
+	}
+	for idx, payload := range payloads {
+		if payload.Height() != uint64(idx) {
+			t.Fatalf("expected block %d sent at position %d, got %d", idx, idx, payload.Height())
+		}
+	}
+	if n.indexedHeight != 45 {
+		t.Errorf("expected indexed height 45, got %d", n.indexedHeight)
+	}
+}
+
+func TestBackfillRangeBounds(t *testing.T) {
+	for _, tc := range []struct {
+		from, to uint64
+		ranges   [][2]uint64
+	}{
+		{0, 0, [][2]uint64{{0, 0}}},
+		{0, 25, [][2]uint64{{0, 9}, {10, 19}, {20, 25}}},
+		{5, 14, [][2]uint64{{5, 14}}},
+		{5, 15, [][2]uint64{{5, 14}, {15, 15}}},
+	} {
+		n, rpc, _, sink := newBackfillNode(tc.from, tc.to, 2)
+		n.backfill()
+
+		sort.Slice(rpc.queries, func(i, j int) bool { return rpc.queries[i][0] < rpc.queries[j][0] })
+		if !reflect.DeepEqual(rpc.queries, tc.ranges) {
+			t.Errorf("backfill %d to %d: expected ranges %v, got %v", tc.from, tc.to, tc.ranges, rpc.queries)
+		}
+		payloads := sink.sent()
+		if uint64(len(payloads)) != tc.to-tc.from+1 ||
+			payloads[0].Height() != tc.from || payloads[len(payloads)-1].Height() != tc.to {
+			t.Errorf("backfill %d to %d: expected every block sent once", tc.from, tc.to)
+		}
+	}
+}
+
+func TestBackfillKeepsCheckpoint(t *testing.T) {
+	n, _, backend, _ := newBackfillNode(0, 5, 1)
+	backend.Set(lb.KeyCheckpointHeight, util.GobEncode(uint64(100)))
+	n.backfill()
+
+	var checkpoint uint64
+	val, _, _ := backend.Get(lb.KeyCheckpointHeight)
+	if err := util.GobDecode(val, &checkpoint); err != nil || checkpoint != 100 {
+		t.Errorf("expected realtime checkpoint 100 kept, got %d, %v", checkpoint, err)
+	}
+}
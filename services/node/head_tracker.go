@@ -0,0 +1,123 @@
+package node
+
+import (
+	"time"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	"github.com/supragya/EtherScope/services/instrumentation"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// headTracker wakes up indexing loop whenever chain may have moved.
+// With subscription turned on, it wakes up on every newHeads header
+// and passes the header along. When subscription cannot be set up or
+// drops, it falls back to waking up every pollInterval (without
+// header) till subscription is set up again.
+type headTracker struct {
+	log                 logger.Logger
+	rpc                 ethrpc.EthRPC
+	subscribe           bool
+	pollInterval        time.Duration
+	resubscribeInterval time.Duration
+
+	heads chan *types.Header // latest head only, nil for poll ticks
+	quit  chan struct{}
+}
+
+func newHeadTracker(log logger.Logger, rpc ethrpc.EthRPC, subscribe bool) *headTracker {
+	return &headTracker{
+		log:                 log,
+		rpc:                 rpc,
+		subscribe:           subscribe,
+		pollInterval:        time.Second * 2,
+		resubscribeInterval: time.Second * 10,
+		heads:               make(chan *types.Header, 1),
+		quit:                make(chan struct{}),
+	}
+}
+
+// Heads returns channel on which loop is woken up. Header received
+// is nil if the wake up is due to polling
+func (h *headTracker) Heads() <-chan *types.Header {
+	return h.heads
+}
+
+func (h *headTracker) run() {
+	if !h.subscribe {
+		h.poll(nil)
+		return
+	}
+	for {
+		if err := h.follow(); err != nil {
+			h.log.Warn("newHeads subscription unavailable, falling back to polling",
+				"error", err,
+				"retryIn", h.resubscribeInterval)
+		}
+		instrumentation.HeadSubscriptionActive.Set(0)
+		if !h.poll(time.After(h.resubscribeInterval)) {
+			return
+		}
+	}
+}
+
+func (h *headTracker) stop() {
+	close(h.quit)
+}
+
+// follow forwards headers from a newHeads subscription till it fails
+// or tracker is stopped
+func (h *headTracker) follow() error {
+	ch := make(chan *types.Header, 16)
+	sub, err := h.rpc.SubscribeNewHeads(ch)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	instrumentation.HeadSubscriptionActive.Set(1)
+
+	for {
+		select {
+		case header := <-ch:
+			instrumentation.HeadSubscriptionEvents.Inc()
+			h.notify(header)
+		case err := <-sub.Err():
+			return err
+		case <-h.quit:
+			return nil
+		}
+	}
+}
+
+// poll wakes up loop every pollInterval till until fires (forever if
+// nil). Returns false if tracker is stopped meanwhile
+func (h *headTracker) poll(until <-chan time.Time) bool {
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			h.notify(nil)
+		case <-until:
+			return true
+		case <-h.quit:
+			return false
+		}
+	}
+}
+
+// notify replaces any unconsumed wake up with header, loop only ever
+// cares about the latest head
+func (h *headTracker) notify(header *types.Header) {
+	for {
+		select {
+		case h.heads <- header:
+			return
+		default:
+		}
+		select {
+		case <-h.heads:
+		default:
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package node
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// subscriptionRPC fails first newHeads subscription, later ones
+// stay up till dropped
+type subscriptionRPC struct {
+	ethrpc.EthRPC
+	mu         sync.Mutex
+	attempts   int
+	headers    chan<- *types.Header
+	dropped    chan error
+	subscribed chan struct{}
+}
+
+func (s *subscriptionRPC) SubscribeNewHeads(ch chan<- *types.Header) (ethereum.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts == 1 {
+		return nil, errors.New("notifications not supported")
+	}
+	s.headers = ch
+	dropped := make(chan error, 1)
+	s.dropped = dropped
+	s.subscribed <- struct{}{}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		select {
+		case err := <-dropped:
+			return err
+		case <-quit:
+			return nil
+		}
+	}), nil
+}
+
+func (s *subscriptionRPC) send(header *types.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.headers <- header
+}
+
+func (s *subscriptionRPC) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropped <- errors.New("connection reset")
+}
+
+// nextHead waits for a wake up from tracker
+func nextHead(t *testing.T, h *headTracker) *types.Header {
+	select {
+	case header := <-h.Heads():
+		return header
+	case <-time.After(time.Second):
+		t.Fatal("expected tracker to wake up loop")
+		return nil
+	}
+}
+
+// waitForHead skips poll ticks and heads till head at height
+func waitForHead(t *testing.T, h *headTracker, height uint64) {
+	for {
+		if header := nextHead(t, h); header != nil && header.Number.Uint64() == height {
+			return
+		}
+	}
+}
+
+func TestHeadTrackerResubscribes(t *testing.T) {
+	rpc := &subscriptionRPC{subscribed: make(chan struct{}, 2)}
+	h := newHeadTracker(logger.NewNopLogger(), rpc, true)
+	h.pollInterval = time.Millisecond * 5
+	h.resubscribeInterval = time.Millisecond * 50
+	done := make(chan struct{})
+	go func() {
+		h.run()
+		close(done)
+	}()
+
+	// Failed subscription falls back to polling till resubscribed
+	if header := nextHead(t, h); header != nil {
+		t.Fatalf("expected poll tick, got header %v", header.Number)
+	}
+	<-rpc.subscribed
+	rpc.send(&types.Header{Number: big.NewInt(1)})
+	waitForHead(t, h, 1)
+
+	// Dropped subscription falls back to polling and is set up again
+	rpc.drop()
+	if header := nextHead(t, h); header != nil {
+		t.Fatalf("expected poll tick after subscription dropped, got header %v", header.Number)
+	}
+	select {
+	case <-rpc.subscribed:
+	case <-time.After(time.Second):
+		t.Fatal("expected tracker to resubscribe")
+	}
+	rpc.send(&types.Header{Number: big.NewInt(2)})
+	waitForHead(t, h, 2)
+
+	h.stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected tracker to stop")
+	}
+	if rpc.attempts != 3 {
+		t.Errorf("expected 3 subscription attempts, got %d", rpc.attempts)
+	}
+}
+
+func TestHeadTrackerNotifyCoalesces(t *testing.T) {
+	h := newHeadTracker(logger.NewNopLogger(), nil, true)
+	h.notify(nil)
+	h.notify(&types.Header{Number: big.NewInt(1)})
+	h.notify(&types.Header{Number: big.NewInt(2)})
+
+	// Only latest head is left for loop, without blocking tracker
+	if header := nextHead(t, h); header == nil || header.Number.Uint64() != 2 {
+		t.Fatalf("expected latest head 2, got %v", header)
+	}
+	select {
+	case header := <-h.Heads():
+		t.Fatalf("expected no further wake up, got %v", header)
+	default:
+	}
+}
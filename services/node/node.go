@@ -6,22 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"net/http"
 	"runtime"
 	"strings"
 	"sync"
 	"time"
 
-	iamqp "github.com/supragya/EtherScope/libs/amqp"
 	cfg "github.com/supragya/EtherScope/libs/config"
 	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/networks"
 	oldpriceresolver "github.com/supragya/EtherScope/libs/oldpricing"
+	"github.com/supragya/EtherScope/libs/payloadpb"
 	priceresolver "github.com/supragya/EtherScope/libs/pricing"
-	erc20 "github.com/supragya/EtherScope/libs/processors/erc20"
-	traderjoev2 "github.com/supragya/EtherScope/libs/processors/traderjoev2"
-	uniswapv2 "github.com/supragya/EtherScope/libs/processors/uniswapV2"
-	uniswapv3 "github.com/supragya/EtherScope/libs/processors/uniswapV3"
+	"github.com/supragya/EtherScope/libs/processors"
 	"github.com/supragya/EtherScope/libs/service"
 	"github.com/supragya/EtherScope/libs/util"
 	"github.com/supragya/EtherScope/services/ethrpc"
@@ -31,7 +28,6 @@ import (
 	itypes "github.com/supragya/EtherScope/types"
 	"github.com/supragya/EtherScope/version"
 	"github.com/cenkalti/backoff/v4"
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
@@ -55,14 +51,26 @@ type NodeImpl struct {
 	remoteResumeType                string   // Dictates the remote resume type - can be removed once all deployments transition to event based
 	prodcheck                       bool     // checks for prod grade settings
 	eventsToIndex                   []string // user requested events to index in string form
+	processorNames                  []string // user requested processors to enable
 	maxCPUParallels                 int      // user requested CPU threads to allocate to the process
 	maxBlockSpanPerCall             uint64   // max block spans to log per initial filtering call
 	pricingChainlinkOraclesDumpFile string   // user provided chainlink oracles to trust
 	pricingDexDumpFile              string   // user provided dexes for faster catchup
+	pricingMinLiquidityUSD          uint64   // routes through thinner dex pools are not used for pricing
+	pricingSnapshotRetention        uint64   // blocks of pricing graph versions kept, 0 keeps all
+	confirmationDepth               uint64   // number of recent blocks checked for reorgs
+	isBackfill                      bool     // index a bounded range instead of following chainhead
+	backfillFrom                    uint64   // first block of backfill range
+	backfillTo                      uint64   // last block of backfill range (inclusive)
+	backfillWorkers                 int      // number of parallel fetch workers for backfill
+	resumeFromCheckpoint            bool     // resume strictly from last checkpointed height
+	ingestionMode                   string   // fetch logs by eth_getLogs or by block receipts
+	subscribeNewHeads               bool     // drive indexing from newHeads instead of polling
 
 	// Internal Data Structures
 	moniker            string                                // user defined moniker for this node
 	network            string                                // user defined evm compatible network name
+	chainID            uint64                                // expected chain ID of network, 0 if unknown
 	nodeID             uuid.UUID                             // system generated node identifier unique for each run
 	mergedTopics       map[common.Hash]itypes.ProcessingType // information on topics to index
 	mergedTopicsKeys   []common.Hash                         // cached keys of mergedTopics
@@ -70,18 +78,21 @@ type NodeImpl struct {
 	currentHeight      uint64
 	allowPricingState  bool
 	oldPricerOracleMap string
+	blockHashes        map[uint64]common.Hash // recently indexed block hashes for reorg detection
+	hasPersistentLB    bool                   // whether localbackend persists to disk (not none)
+	senderCache        *txSenderCache         // tx senders learnt from blocks, receipts mode only
+	headTracker        *headTracker           // wakes up loop on new chain heads
 	quitCh             chan struct{}
 
 	// Backoff configuration
 	backoff *backoff.ConstantBackOff
 
 	// Library instances
-	procUniV2  uniswapv2.UniswapV2Processor
-	procUniV3  uniswapv3.UniswapV3Processor
-	procERC20  erc20.ERC20Processor
-	procTJoeV2 traderjoev2.TraderJoeProcessor
-	pricer     *priceresolver.Engine
-	oldpricer  *oldpriceresolver.Pricing
+	topicProcessors map[common.Hash]processors.Processor // processor handling each merged topic
+	pricer          *priceresolver.Engine
+	oldpricer       *oldpriceresolver.Pricing
+	cex             priceresolver.CEX         // CEX prices for pricer, nil if unused
+	cexSymbols      map[common.Address]string // CEX symbols of tokens priced using cex
 }
 
 // OnStart starts the Node. It implements service.Service.
@@ -103,11 +114,16 @@ func (n *NodeImpl) OnStart(ctx context.Context) error {
 
 	n.log.Info("node identifier generated", "moniker", n.moniker, "nodeID", n.nodeID)
 
+	// LocalBackend is started first as EthRPC warms its caches from it
+	if err := n.LocalBackend.Start(ctx); err != nil {
+		return err
+	}
+
 	if err := n.EthRPC.Start(ctx); err != nil {
 		return err
 	}
 
-	if err := n.LocalBackend.Start(ctx); err != nil {
+	if err := n.checkChainID(); err != nil {
 		return err
 	}
 
@@ -115,20 +131,16 @@ func (n *NodeImpl) OnStart(ctx context.Context) error {
 		n.log.Info("Error initializing output sink, will reattempt connection until ready")
 	}
 
-	// Setup what to index
-	requestedEvents, err := util.ConstructTopics(n.eventsToIndex)
-	if err != nil {
-		return err
-	}
-	// required topics by the pricing engine
-	var extraRequiredEvents []common.Hash
-	if n.allowPricingState {
-		extraRequiredEvents = []common.Hash{itypes.UniV2MintTopic,
-			itypes.UniV2BurnTopic,
-			itypes.UniV2SwapTopic}
+	// In receipts mode, processors are served tx senders learnt from
+	// fetched blocks instead of querying upstream per log
+	if n.ingestionMode == IngestionReceipts {
+		n.senderCache = &txSenderCache{EthRPC: n.EthRPC}
 	}
 
-	n.mergedTopics = mergeTopics(requestedEvents, extraRequiredEvents)
+	// Setup processors and what to index
+	if err := n.setupProcessors(); err != nil {
+		return err
+	}
 	keys := make([]common.Hash, len(n.mergedTopics))
 
 	i := 0
@@ -149,26 +161,37 @@ func (n *NodeImpl) OnStart(ctx context.Context) error {
 	}
 	n.mergedTopicsKeys = keys
 
-	// Setup processors
-	n.procUniV2 = uniswapv2.UniswapV2Processor{n.mergedTopics, n.EthRPC}
-	n.procUniV3 = uniswapv3.UniswapV3Processor{n.mergedTopics, n.EthRPC}
-	n.procERC20 = erc20.ERC20Processor{n.mergedTopics, n.EthRPC}
-	n.procTJoeV2 = traderjoev2.TraderJoeProcessor{n.mergedTopics, n.EthRPC}
 	if n.allowPricingState {
 		n.pricer = priceresolver.NewDefaultEngine(n.log.With("module", "pricing"),
 			n.pricingChainlinkOraclesDumpFile,
 			n.pricingDexDumpFile,
+			n.pricingMinLiquidityUSD,
 			n.EthRPC,
 			n.LocalBackend)
+		n.pricer.RetainSnapshots(n.pricingSnapshotRetention)
+		if n.cex != nil {
+			if err := n.cex.Start(ctx); err != nil {
+				return err
+			}
+			n.pricer.UseCEX(n.cex, n.cexSymbols)
+		}
 	} else {
 		n.oldpricer = oldpriceresolver.GetPricingEngine(n.oldPricerOracleMap, n.EthRPC)
 	}
 
+	if n.isBackfill {
+		go n.backfill()
+		return nil
+	}
+
 	// TODO: Do height syncup using both LocalBackend and remote http
 	// startHeight, err := n.getResumeHeight()
 	n.indexedHeight = n.syncStartHeight()
+	n.loadBlockHashes()
 
 	// Loop for impl
+	n.headTracker = newHeadTracker(n.log.With("module", "headtracker"), n.EthRPC, n.subscribeNewHeads)
+	go n.headTracker.run()
 	go n.loop()
 
 	return nil
@@ -177,6 +200,9 @@ func (n *NodeImpl) OnStart(ctx context.Context) error {
 // OnStop stops the Node. It implements service.Service
 func (n *NodeImpl) OnStop() {
 	n.quitCh <- struct{}{}
+	if n.headTracker != nil {
+		n.headTracker.stop()
+	}
 
 	wg := sync.WaitGroup{}
 
@@ -201,10 +227,21 @@ func (n *NodeImpl) OnStop() {
 func (n *NodeImpl) loop() {
 	for {
 		select {
-		case <-time.After(time.Second * 2):
-			// Loop in case we are lagging, so we dont wait 3 secs between epochs
+		case head := <-n.headTracker.Heads():
+			// Loop in case we are lagging, so we dont wait for next head between epochs
 			for {
-				height, err := n.EthRPC.GetCurrentBlockHeight()
+				// Head is only current on first pass, later passes query rpc
+				var (
+					height uint64
+					err    error
+				)
+				if head != nil {
+					height = head.Number.Uint64()
+				} else {
+					height, err = n.EthRPC.GetCurrentBlockHeight()
+				}
+				hint := head
+				head = nil
 
 				if err != nil {
 					n.log.Warn(fmt.Sprintf("Error retrieving block height, retrying. Caused by: %s", err))
@@ -215,10 +252,25 @@ func (n *NodeImpl) loop() {
 				if n.currentHeight < n.indexedHeight {
 					n.log.Warn(fmt.Sprintf("rpc height (%d) is less than indexed height (%d), possible n/w reorg or p2p failure",
 						n.currentHeight, n.indexedHeight))
+					break
 				}
 				if n.currentHeight == n.indexedHeight {
+					// Caught up, wait for next head
+					break
+				}
+
+				rolledBack, err := n.checkReorg(hint)
+				if err != nil {
+					if errors.Is(err, ErrReorgTooDeep) {
+						n.log.Fatal(err.Error())
+					}
+					n.log.Warn(fmt.Sprintf("Error checking for reorg, retrying. Caused by: %s", err))
+					break
+				}
+				if rolledBack {
 					continue
 				}
+
 				endingBlock := n.currentHeight
 				isOnHead := true
 				if (endingBlock - n.indexedHeight) > n.maxBlockSpanPerCall {
@@ -231,18 +283,15 @@ func (n *NodeImpl) loop() {
 
 				instrumentation.CurrentBlock.Set(float64(n.currentHeight))
 
-				logs, err := n.EthRPC.GetFilteredLogs(ethereum.FilterQuery{
-					FromBlock: big.NewInt(int64(n.indexedHeight + 1)),
-					ToBlock:   big.NewInt(int64(endingBlock)),
-					Topics:    [][]common.Hash{n.mergedTopicsKeys},
-				})
+				kv, release, err := n.fetchLogs(n.indexedHeight+1, endingBlock)
 
 				if err != nil {
 					n.log.Error("encountered error", "error", err)
 					continue
 				}
 
-				n.processBatchedBlockLogs(logs, n.indexedHeight+1, endingBlock)
+				n.processBatchedBlockLogs(kv, n.indexedHeight+1, endingBlock)
+				release()
 
 				n.indexedHeight = endingBlock
 				instrumentation.ProcessedBlock.Set(float64(n.currentHeight))
@@ -257,39 +306,68 @@ func (n *NodeImpl) loop() {
 	}
 }
 
-func (n *NodeImpl) processBatchedBlockLogs(logs []types.Log, start uint64, end uint64) {
+func (n *NodeImpl) processBatchedBlockLogs(kv map[uint64]CLogType, start uint64, end uint64) {
 	// Assuming for any height H, either we will have all the concerned logs
 	// or not even one
-	kv := GroupByBlockNumber(logs)
-
 	for block := start; block <= end; block++ {
 		backoff.Retry(func() error { return n.processBlock(kv, block) }, n.backoff)
 	}
 }
 
+// decodedBlock is a block whose logs have been run through processors
+// and awaits pricing and dispatch to output sink
+type decodedBlock struct {
+	synopsis       itypes.BlockSynopsis
+	hash           common.Hash
+	items          []interface{}
+	startTime      time.Time
+	processingTime time.Time
+}
+
 func (n *NodeImpl) processBlock(kv map[uint64]CLogType, block uint64) error {
+	decoded, err := n.decodeBlock(kv, block)
+	if err != nil {
+		return err
+	}
+	return n.commitBlock(decoded)
+}
+
+// decodeBlock runs all logs of a block through processors. It does not
+// touch any shared state and is safe to be run for many blocks in parallel
+func (n *NodeImpl) decodeBlock(kv map[uint64]CLogType, block uint64) (*decodedBlock, error) {
 	n.log.Info(fmt.Sprintf("processing block %d", block))
 	startTime := time.Now()
-	_time, err := n.EthRPC.GetBlockTimestamp(block)
+	header, err := n.EthRPC.GetBlockHeader(block)
 	if err != nil {
-		n.log.Warn(fmt.Sprintf("Error retrieving timestamp for block %d. Caused by: %s", block, err))
-		return err
+		n.log.Warn(fmt.Sprintf("Error retrieving header for block %d. Caused by: %s", block, err))
+		return nil, err
 	}
 
 	logs := kv[block]
 	blockSynopis := itypes.BlockSynopsis{
 		Height:        block,
-		BlockTime:     _time,
+		BlockTime:     header.Time,
 		EventsScanned: uint64(logs.Len()),
 	}
 
+	// Logs may have been fetched before header on a different fork,
+	// we remember the hash of what was actually indexed
+	blockHash := header.Hash()
+	if logs.Len() > 0 && logs[0].BlockHash != blockHash {
+		n.log.Warn("logs and header hash mismatch, possible n/w reorg",
+			"height", block,
+			"logs", logs[0].BlockHash,
+			"header", blockHash)
+		blockHash = logs[0].BlockHash
+	}
+
 	eg := new(errgroup.Group)
 
 	var processedItems []interface{} = make([]interface{}, len(logs))
 	for idx, _log := range logs {
 		index, l := idx, _log
 		eg.Go(func() error {
-			err = n.decodeLog(l, processedItems, index, blockSynopis.BlockTime)
+			err := n.decodeLog(l, processedItems, index, blockSynopis.BlockTime)
 			if err != nil {
 				fmt.Println(err)
 			}
@@ -300,10 +378,27 @@ func (n *NodeImpl) processBlock(kv map[uint64]CLogType, block uint64) error {
 	err = eg.Wait()
 	if err != nil {
 		n.log.Debug(fmt.Sprintf("Error processing block %d. Retrying. Error caused by: %s", block, err))
-		return err
+		return nil, err
 	}
 
-	processingTime := time.Now()
+	return &decodedBlock{
+		synopsis:       blockSynopis,
+		hash:           blockHash,
+		items:          processedItems,
+		startTime:      startTime,
+		processingTime: time.Now(),
+	}, nil
+}
+
+// commitBlock prices a decoded block and sends it to output sink.
+// Blocks are expected to be committed in order of height
+func (n *NodeImpl) commitBlock(decoded *decodedBlock) error {
+	var (
+		block          = decoded.synopsis.Height
+		processedItems = decoded.items
+		blockSynopis   = decoded.synopsis
+		err            error
+	)
 
 	// Run processedItems through pricing engine
 	var newDexes []itypes.UniV2Metadata
@@ -313,7 +408,7 @@ func (n *NodeImpl) processBlock(kv map[uint64]CLogType, block uint64) error {
 			func() ([]itypes.UniV2Metadata, error) {
 				newDexes, err := n.pricer.Resolve(block, processedItems)
 				if err != nil {
-					if errors.Is(err, priceresolver.ErrorRequestedResolutionPresent) {
+					if errors.Is(err, priceresolver.ErrorResolutionBeforeLowestSnapshot) {
 						n.log.Debugf("%s", err)
 						return newDexes, nil
 					}
@@ -335,16 +430,19 @@ func (n *NodeImpl) processBlock(kv map[uint64]CLogType, block uint64) error {
 	pricingTime := time.Now()
 
 	// Package processedItems into payload for output
-	populateBlockSynopsis(&blockSynopis, processedItems, startTime, processingTime, pricingTime, n.eventsToIndex)
+	populateBlockSynopsis(&blockSynopis, processedItems, decoded.startTime, decoded.processingTime, pricingTime, n.eventsToIndex)
 	payload := n.genPayload(&blockSynopis, processedItems, newDexes)
 	payload.allowPricingState = n.allowPricingState
-	for {
-		err = n.OutputSink.Send(payload)
-		if err == nil {
-			break
-		}
-		n.log.Warn("Error sending message to output sink: " + fmt.Sprint(err))
-		time.Sleep(2 * time.Second)
+	n.sendPayload(payload)
+
+	// Checkpoint only after output sink accepted the payload, a crash
+	// in between leads to this block being resent (at-least-once)
+	if err := n.checkpoint(block); err != nil {
+		n.log.Warn(fmt.Sprintf("Error checkpointing block %d. Caused by: %s", block, err))
+	}
+
+	if err := n.recordBlockHash(block, decoded.hash); err != nil {
+		n.log.Warn(fmt.Sprintf("Error recording hash for block %d. Caused by: %s", block, err))
 	}
 
 	n.log.Debug("Syncing local backend")
@@ -353,46 +451,39 @@ func (n *NodeImpl) processBlock(kv map[uint64]CLogType, block uint64) error {
 	return nil
 }
 
+// checkpoint records height as successfully sent to output sink.
+// Backfills run over ranges behind realtime indexing and leave the
+// checkpoint of realtime indexing untouched. none localbackend cannot
+// hold a checkpoint, resuming from checkpoint is refused on startup
+func (n *NodeImpl) checkpoint(height uint64) error {
+	if !n.hasPersistentLB || n.isBackfill {
+		return nil
+	}
+	return n.LocalBackend.Set(lb.KeyCheckpointHeight, util.GobEncode(height))
+}
+
+// sendPayload blocks till payload is accepted by output sink
+func (n *NodeImpl) sendPayload(payload *Payload) {
+	for {
+		err := n.OutputSink.Send(payload)
+		if err == nil {
+			return
+		}
+		n.log.Warn("Error sending message to output sink: " + fmt.Sprint(err))
+		time.Sleep(2 * time.Second)
+	}
+}
+
 func (n *NodeImpl) decodeLog(l types.Log,
 	items []interface{},
 	idx int,
 	blockTime uint64,
 ) error {
-	primaryTopic := l.Topics[0]
-	switch primaryTopic {
-	// ---- Uniswap V2 ----
-	case itypes.UniV2MintTopic:
-		instrumentation.MintV2Found.Inc()
-		return n.procUniV2.ProcessUniV2Mint(l, items, idx, blockTime)
-	case itypes.UniV2BurnTopic:
-		instrumentation.BurnV2Found.Inc()
-		return n.procUniV2.ProcessUniV2Burn(l, items, idx, blockTime)
-	case itypes.UniV2SwapTopic:
-		instrumentation.SwapV2Found.Inc()
-		return n.procUniV2.ProcessUniV2Swap(l, items, idx, blockTime)
-
-	// // ---- Uniswap V3 ----
-	case itypes.UniV3MintTopic:
-		instrumentation.MintV3Found.Inc()
-		return n.procUniV3.ProcessUniV3Mint(l, items, idx, blockTime)
-	case itypes.UniV3BurnTopic:
-		instrumentation.BurnV3Found.Inc()
-		return n.procUniV3.ProcessUniV3Burn(l, items, idx, blockTime)
-	case itypes.UniV3SwapTopic:
-		instrumentation.SwapV3Found.Inc()
-		return n.procUniV3.ProcessUniV3Swap(l, items, idx, blockTime)
-
-	// ---- ERC 20 ----
-	case itypes.ERC20TransferTopic:
-		instrumentation.TfrFound.Inc()
-		return n.procERC20.ProcessERC20Transfer(l, items, idx, blockTime)
-
-	// ---- Trader Joe ----
-	case itypes.TraderJoeV2SwapTopic:
-		instrumentation.TraderJoeV2SwapFound.Inc()
-		return n.procTJoeV2.ProcessTokenExchange(l, items, idx, blockTime)
+	proc, ok := n.topicProcessors[l.Topics[0]]
+	if !ok {
+		return nil
 	}
-	return nil
+	return proc.Process(l, items, idx, blockTime)
 }
 
 func populateBlockSynopsis(bs *itypes.BlockSynopsis,
@@ -423,7 +514,9 @@ func populateBlockSynopsis(bs *itypes.BlockSynopsis,
 			isPricedCorrectly = i.Price0 != nil && i.Price1 != nil && i.Amount0 != nil && i.Amount1 != nil && i.AmountUSD != nil
 		case *itypes.Transfer:
 			itemKey = fmt.Sprintf("(%v, %v)", i.Type, i.ProcessingType.ToString())
-			isPricedCorrectly = i.AmountUSD != nil
+			isPricedCorrectly = i.PriceDerivationMeta != nil && i.Amount != nil && i.AmountUSD != nil
+		case *itypes.GenericEvent:
+			itemKey = fmt.Sprintf("(%v, %v)", i.Type, i.ProcessingType.ToString())
 		}
 
 		if itemKey != defaultKey {
@@ -456,33 +549,119 @@ type Payload struct {
 	BlockSynopsis     *itypes.BlockSynopsis
 	NewDexes          []itypes.UniV2Metadata
 	Items             []interface{}
+	pricingItems      []interface{} // items decoded for pricing only, not sent by default
+}
+
+// Height returns the block height payload is for. Rollback payloads
+// report the common ancestor. Implements outs.HeightKeyedPayload
+func (p *Payload) Height() uint64 {
+	if p.BlockSynopsis != nil {
+		return p.BlockSynopsis.Height
+	}
+	for _, item := range p.Items {
+		if rollback, ok := item.(*itypes.Rollback); ok {
+			return rollback.CommonAncestor
+		}
+	}
+	return 0
+}
+
+// SplitByEventType implements outs.SplittablePayload
+func (p *Payload) SplitByEventType() (interface{}, map[string]interface{}) {
+	base := *p
+	base.Items = []interface{}{}
+	base.pricingItems = nil
+
+	parts := make(map[string]interface{})
+	for _, item := range p.Items {
+		eventType := itypes.ItemType(item)
+		part, ok := parts[eventType]
+		if !ok {
+			newPart := *p
+			newPart.Items = []interface{}{}
+			newPart.NewDexes = []itypes.UniV2Metadata{}
+			newPart.pricingItems = nil
+			part = &newPart
+			parts[eventType] = part
+		}
+		part.(*Payload).Items = append(part.(*Payload).Items, item)
+	}
+	return &base, parts
+}
+
+// FilterItems implements outs.FilterablePayload. Items decoded for
+// pricing only are offered to keep as well and end up in Items if kept
+func (p *Payload) FilterItems(keep func(item interface{}) bool) interface{} {
+	filtered := *p
+	filtered.Items = []interface{}{}
+	filtered.pricingItems = nil
+	for _, item := range p.Items {
+		if keep(item) {
+			filtered.Items = append(filtered.Items, item)
+		}
+	}
+	for _, item := range p.pricingItems {
+		if keep(item) {
+			filtered.Items = append(filtered.Items, item)
+		}
+	}
+	return &filtered
+}
+
+// ToProto implements outs.ProtoPayload
+func (p *Payload) ToProto() *payloadpb.Payload {
+	pb := &payloadpb.Payload{
+		PersistenceVersion: uint32(version.PersistenceVersion),
+		NodeMoniker:        p.NodeMoniker,
+		NodeId:             p.NodeID[:],
+		NodeVersion:        p.NodeVersion,
+		Environment:        p.Environment,
+		Network:            p.Network,
+		BlockSynopsis:      payloadpb.NewBlockSynopsis(p.BlockSynopsis),
+	}
+	for _, dex := range p.NewDexes {
+		pb.NewDexes = append(pb.NewDexes, payloadpb.NewUniV2Metadata(dex))
+	}
+	for _, item := range p.Items {
+		if pbItem := payloadpb.NewItem(item); pbItem != nil {
+			pb.Items = append(pb.Items, pbItem)
+		}
+	}
+	return pb
+}
+
+// Synopsis implements outs.BlockPayload
+func (p *Payload) Synopsis() *itypes.BlockSynopsis {
+	return p.BlockSynopsis
+}
+
+// BlockItems implements outs.BlockPayload
+func (p *Payload) BlockItems() []interface{} {
+	return p.Items
+}
+
+// Source implements outs.BlockPayload
+func (p *Payload) Source() (string, string) {
+	return p.NodeMoniker, p.Network
 }
 
 func (n *NodeImpl) genPayload(bs *itypes.BlockSynopsis,
 	items []interface{},
 	newDexes []itypes.UniV2Metadata) *Payload {
 	nonNilUserItems := []interface{}{}
+	nonNilPricingItems := []interface{}{}
 	for _, item := range items {
 		if item == nil {
 			continue
 		}
-		switch i := item.(type) {
-		case *itypes.Mint:
-			if i.ProcessingType == itypes.UserRequested {
-				nonNilUserItems = append(nonNilUserItems, i)
-			}
-		case *itypes.Burn:
-			if i.ProcessingType == itypes.UserRequested {
-				nonNilUserItems = append(nonNilUserItems, i)
-			}
-		case *itypes.Swap:
-			if i.ProcessingType == itypes.UserRequested {
-				nonNilUserItems = append(nonNilUserItems, i)
-			}
-		case *itypes.Transfer:
-			if i.ProcessingType == itypes.UserRequested {
-				nonNilUserItems = append(nonNilUserItems, i)
-			}
+		ptype, ok := itypes.ItemProcessingType(item)
+		switch {
+		case ok && ptype == itypes.UserRequested:
+			nonNilUserItems = append(nonNilUserItems, item)
+		case ok:
+			nonNilPricingItems = append(nonNilPricingItems, item)
+		case itypes.ItemType(item) != "unknown":
+			nonNilUserItems = append(nonNilUserItems, item)
 		}
 	}
 	env := "staging"
@@ -498,32 +677,28 @@ func (n *NodeImpl) genPayload(bs *itypes.BlockSynopsis,
 		BlockSynopsis: bs,
 		Items:         nonNilUserItems,
 		NewDexes:      newDexes,
+		pricingItems:  nonNilPricingItems,
 	}
 }
 
 func (n *NodeImpl) syncStartHeight() uint64 {
+	if n.resumeFromCheckpoint {
+		return n.checkpointStartHeight()
+	}
+
 	// start by assuming cfg height is correct
 	startBlock := n.startBlock
-	var lbLatestHeightUint64 uint64
 
 	// Check localBackend
 	if !n.skipResumeLocal {
-		lbLatestHeight, ok, err := n.LocalBackend.Get(lb.KeyLatestHeight)
-		if err != nil {
-			n.log.Fatal(fmt.Sprintf("error while fetching latest height from localbackend: %v", err))
-		}
+		lbHeight, ok := n.localResumeHeight()
 		if !ok {
 			n.log.Warn("local backend does not have record for latest height, assuming new LB")
-		} else {
-			if err := util.GobDecode(lbLatestHeight, &lbLatestHeightUint64); err != nil {
-				n.log.Fatal(fmt.Sprintf("wrong latest height encoding: %s", err))
-			}
-			if lbLatestHeightUint64 > startBlock {
-				n.log.Warn(fmt.Sprintf("local backend reports latest height as %v but config requested %v. overriding config",
-					lbLatestHeightUint64,
-					n.startBlock))
-				startBlock = lbLatestHeightUint64
-			}
+		} else if lbHeight > startBlock {
+			n.log.Warn(fmt.Sprintf("local backend reports latest height as %v but config requested %v. overriding config",
+				lbHeight,
+				n.startBlock))
+			startBlock = lbHeight
 		}
 	}
 
@@ -576,6 +751,73 @@ func (n *NodeImpl) syncStartHeight() uint64 {
 	return startBlock
 }
 
+// localResumeHeight returns height to resume from as per localbackend.
+// Pricing engine stores latest height on resolving a block, before it
+// is sent to output sink, hence the lower of it and checkpoint is used
+func (n *NodeImpl) localResumeHeight() (uint64, bool) {
+	checkpoint, hasCheckpoint := n.getHeight(lb.KeyCheckpointHeight)
+	latest, hasLatest := n.getHeight(lb.KeyLatestHeight)
+	switch {
+	case hasCheckpoint && hasLatest && latest < checkpoint:
+		return latest, true
+	case hasCheckpoint:
+		return checkpoint, true
+	default:
+		// Localbackends from before checkpoints only have latest height
+		return latest, hasLatest
+	}
+}
+
+// getHeight reads height stored in localbackend under key
+func (n *NodeImpl) getHeight(key string) (uint64, bool) {
+	val, ok, err := n.LocalBackend.Get(key)
+	if err != nil {
+		n.log.Fatal(fmt.Sprintf("error while fetching %s height from localbackend: %v", key, err))
+	}
+	if !ok {
+		return 0, false
+	}
+	var height uint64
+	if err := util.GobDecode(val, &height); err != nil {
+		n.log.Fatal(fmt.Sprintf("wrong %s height encoding: %s", key, err))
+	}
+	return height, true
+}
+
+// checkpointStartHeight returns the last height checkpointed by the
+// node, ignoring remote and pricing engine heights
+func (n *NodeImpl) checkpointStartHeight() uint64 {
+	checkpoint, ok := n.getHeight(lb.KeyCheckpointHeight)
+	if !ok {
+		n.log.Warn("local backend does not have a checkpoint, starting from config",
+			"start", n.startBlock)
+		return n.startBlock
+	}
+	n.log.Info("resuming from checkpoint", "checkpoint", checkpoint)
+	return checkpoint
+}
+
+// checkChainID ensures ethrpc upstreams are on the network node is
+// configured for
+func (n *NodeImpl) checkChainID() error {
+	if n.chainID == 0 {
+		n.log.Warn("no built-in profile or chainID for network, skipping chain ID check",
+			"network", n.network,
+			"known", strings.Join(networks.Names(), ","))
+		return nil
+	}
+	chainID, err := n.EthRPC.GetChainID()
+	if err != nil {
+		return fmt.Errorf("could not fetch chain ID from ethrpc: %w", err)
+	}
+	if chainID != n.chainID {
+		return fmt.Errorf("ethrpc is on chain ID %d, expected %d for network %s",
+			chainID, n.chainID, n.network)
+	}
+	n.log.Info("chain ID matches network", "network", n.network, "chainID", chainID)
+	return nil
+}
+
 func (n *NodeImpl) getRemoteLatestheight() (uint64, error) {
 	resp, err := http.Get(n.remoteResumeURL)
 	if err != nil {
@@ -638,11 +880,21 @@ func NewNodeWithViperFields(log logger.Logger) (service.Service, error) {
 	}
 
 	var (
-		lbType     = viper.GetString(NodeCFGSection + ".localBackendType")
-		outsType   = viper.GetString(NodeCFGSection + ".outputSinkType")
-		ethrpcType = viper.GetString(NodeCFGSection + ".ethRPCType")
+		lbType        = viper.GetString(NodeCFGSection + ".localBackendType")
+		outsType      = viper.GetString(NodeCFGSection + ".outputSinkType")
+		ethrpcType    = viper.GetString(NodeCFGSection + ".ethRPCType")
+		ingestionMode = viper.GetString(NodeCFGSection + ".ingestionMode")
+		cexType       = viper.GetString(NodeCFGSection + ".pricingCEXType")
 	)
 
+	// Chain ID in config overrides that of network profile
+	chainID := networks.ExpectedChainID(viper.GetString(NodeCFGSection+".network"),
+		viper.GetUint64(NodeCFGSection+".chainID"))
+
+	if ingestionMode != IngestionLogs && ingestionMode != IngestionReceipts {
+		return nil, errors.New("unsupported ingestionMode: " + ingestionMode)
+	}
+
 	// Setup local backend
 	var (
 		localBackend         lb.LocalBackend
@@ -664,21 +916,61 @@ func NewNodeWithViperFields(log logger.Logger) (service.Service, error) {
 	default:
 		log.Fatal("unsupported localbackend: " + lbType)
 	}
+	if isLocalBackendNoneDB && viper.GetBool(NodeCFGSection+".resumeFromCheckpoint") {
+		return nil, errors.New("resume from checkpoint needs a persistent localBackendType, none cannot hold checkpoints")
+	}
+	if retention := viper.GetUint64(NodeCFGSection + ".pricingSnapshotRetention"); retention != 0 &&
+		retention <= viper.GetUint64(NodeCFGSection+".confirmationDepth") {
+		return nil, errors.New("pricingSnapshotRetention must exceed confirmationDepth for reorgs to be rolled back")
+	}
 
 	// Setup output link
-	if outsType != "rabbitmq" {
-		log.Fatal("unsupported outputsink: " + outsType)
-	}
-	outputSink, err := outs.NewRabbitMQOutputSinkWithViperFields(log.With("service", "outputsink"), &iamqp.AMQPImpl{})
+	outputSink, err := outs.NewOutputSinkWithViperFields(outsType, log.With("service", "outputsink"))
 	if err != nil {
 		return nil, err
 	}
 
 	// Setup ethrpc
-	if ethrpcType != "mspool" {
+	// Token metadata is persisted only on a persistent local backend
+	var metadataBackend lb.LocalBackend
+	if !isLocalBackendNoneDB {
+		metadataBackend = localBackend
+	}
+	var _ethrpc ethrpc.EthRPC
+	switch ethrpcType {
+	case "mspool":
+		_ethrpc, err = ethrpc.NewMSPoolEthRPCWithViperFields(log.With("service", "ethrpc"), metadataBackend)
+	case "record":
+		_ethrpc, err = ethrpc.NewMSPoolEthRPCWithViperFields(log.With("service", "ethrpc"), metadataBackend)
+		if err == nil {
+			_ethrpc, err = ethrpc.NewFixtureRecorderWithViperFields(log.With("service", "ethrpc"), _ethrpc)
+		}
+	case "replay":
+		_ethrpc, err = ethrpc.NewFixtureReplayerWithViperFields(log.With("service", "ethrpc"))
+	default:
 		log.Fatal("unsupported ethrpc: " + ethrpcType)
 	}
-	_ethrpc, err := ethrpc.NewMSPoolEthRPCWithViperFields(log.With("service", "ethrpc"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup CEX prices for pricing engine
+	var (
+		cex        priceresolver.CEX
+		cexSymbols map[common.Address]string
+	)
+	switch cexType {
+	case "none":
+	case "csv":
+		cex, err = priceresolver.NewCSVCEXWithViperFields(log.With("service", "cex"))
+	case "http":
+		cex, err = priceresolver.NewHTTPCEXWithViperFields(log.With("service", "cex"))
+	default:
+		return nil, errors.New("unsupported pricingCEXType: " + cexType)
+	}
+	if err == nil && cex != nil {
+		cexSymbols, err = priceresolver.CEXSymbolsWithViperFields()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -694,16 +986,28 @@ func NewNodeWithViperFields(log logger.Logger) (service.Service, error) {
 		remoteResumeURL:                 viper.GetString(NodeCFGSection + ".remoteResumeURL"),
 		remoteResumeType:                viper.GetString(NodeCFGSection + ".remoteResumeType"),
 		eventsToIndex:                   viper.GetStringSlice(NodeCFGSection + ".eventsToIndex"),
+		processorNames:                  viper.GetStringSlice(NodeCFGSection + ".processors"),
 		maxCPUParallels:                 viper.GetInt(NodeCFGSection + ".maxCPUParallels"),
 		maxBlockSpanPerCall:             viper.GetUint64(NodeCFGSection + ".maxBlockSpanPerCall"),
 		quitCh:                          make(chan struct{}, 1),
 		moniker:                         viper.GetString(NodeCFGSection + ".moniker"),
 		network:                         viper.GetString(NodeCFGSection + ".network"),
+		chainID:                         chainID,
 		allowPricingState:               !isLocalBackendNoneDB,
 		pricingChainlinkOraclesDumpFile: viper.GetString(NodeCFGSection + ".pricingChainlinkOraclesDumpFile"),
 		oldPricerOracleMap:              viper.GetString(NodeCFGSection + ".oldPricerOracleMap"),
 		pricingDexDumpFile:              viper.GetString(NodeCFGSection + ".pricingDexDumpFile"),
+		pricingMinLiquidityUSD:          viper.GetUint64(NodeCFGSection + ".pricingMinLiquidityUSD"),
+		pricingSnapshotRetention:        viper.GetUint64(NodeCFGSection + ".pricingSnapshotRetention"),
+		cex:                             cex,
+		cexSymbols:                      cexSymbols,
 		prodcheck:                       viper.GetBool(NodeCFGSection + ".prodcheck"),
+		confirmationDepth:               viper.GetUint64(NodeCFGSection + ".confirmationDepth"),
+		blockHashes:                     make(map[uint64]common.Hash),
+		hasPersistentLB:                 !isLocalBackendNoneDB,
+		resumeFromCheckpoint:            viper.GetBool(NodeCFGSection + ".resumeFromCheckpoint"),
+		ingestionMode:                   ingestionMode,
+		subscribeNewHeads:               viper.GetBool(NodeCFGSection + ".subscribeNewHeads"),
 	}
 	node.BaseService = *service.NewBaseService(log, "node", node)
 	return node, nil
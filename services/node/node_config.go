@@ -38,8 +38,20 @@ var (
 			Name:      "network",
 			Type:      "string",
 			Necessity: "always needed",
-			Info:      cfg.SArr("evm compatible network name"),
-			Default:   "ethereum-mainnet",
+			Info: cfg.SArr("evm compatible network name. built-in profiles",
+				"supplying chain ID and chain specific addresses exist for",
+				"`ethereum-mainnet`, `arbitrum-one`, `avalanche-c`,",
+				"`bsc-mainnet` and `polygon-mainnet`"),
+			Default: "ethereum-mainnet",
+		},
+		{
+			Name:      "chainID",
+			Type:      "uint64",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("chain ID ethrpc upstreams are expected to be on,",
+				"checked at startup. 0 takes chain ID from network profile,",
+				"check is skipped if network has no built-in profile"),
+			Default: 0,
 		},
 		{
 			Name:      "startBlock",
@@ -91,8 +103,8 @@ var (
 			Type:      "string",
 			Necessity: "always needed",
 			Info: cfg.SArr("type of output sink backend indexer should",
-				"offload indexed information to. only possible type",
-				"right now is `rabbitmq`"),
+				"offload indexed information to. Use `rabbitmq`,",
+				"`kafka`, `postgres`, `file` or `fanout`"),
 			Default: "rabbitmq",
 		},
 		{
@@ -100,28 +112,34 @@ var (
 			Type:      "string",
 			Necessity: "always needed",
 			Info: cfg.SArr("type of ethrpc handler to route requests",
-				"through. only possible type right now is `mspool`"),
+				"through. `mspool`, `record` (mspool recording calls into",
+				"a fixture file) or `replay` (serving calls from a fixture",
+				"file offline)"),
 			Default: "mspool",
 		},
 		{
 			Name:      "eventsToIndex",
 			Type:      "[]string",
 			Necessity: "always needed",
-			Info: cfg.SArr("ethereum events to index. events listed here",
+			Info: append(cfg.SArr("ethereum events to index. events listed here",
 				"are not guaranteed to be the only calls made",
 				"to underlying rpc for processing, but are guaranteed",
 				"to be the only events presented to the output sink",
-				"could be one or many of the following:",
-				"- UniswapV2Swap",
-				"- UniswapV2Mint",
-				"- UniswapV2Burn",
-				"- UniswapV3Swap",
-				"- UniswapV3Mint",
-				"- UniswapV3Burn",
-				"- ERC20Transfer",
-				"- TraderJoeV2Swap"),
+				"could be one or many of the following (processor):"),
+				registeredEvents()...),
 			Default: "\n    - ERC20Transfer\n    - UniswapV2Swap",
 		},
+		{
+			Name:      "processors",
+			Type:      "[]string",
+			Necessity: cfg.Optional,
+			Info: append(cfg.SArr("processors to enable for decoding logs. every event",
+				"in `eventsToIndex` should be handled by an enabled",
+				"processor. processors needed by pricing engine are",
+				"enabled regardless. could be one or many of the following:"),
+				registeredProcessors()...),
+			Default: []string{"erc20", "uniswapv2", "uniswapv3", "traderjoev2"},
+		},
 		{
 			Name:      "maxBlockSpanPerCall",
 			Type:      "uint64",
@@ -130,6 +148,38 @@ var (
 				"beginning of processing loop"),
 			Default: 5,
 		},
+		{
+			Name:      "ingestionMode",
+			Type:      "string",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("how logs are fetched. `logs` filters logs by topic",
+				"over ranges using eth_getLogs. `receipts` fetches whole",
+				"blocks and their receipts using eth_getBlockReceipts,",
+				"filters logs locally and verifies receipts against block",
+				"logs bloom. `receipts` saves a call per log for tx",
+				"senders and needs upstreams supporting eth_getBlockReceipts"),
+			Default: "logs",
+		},
+		{
+			Name:      "subscribeNewHeads",
+			Type:      "bool",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("drive indexing from newHeads subscription instead of",
+				"polling chain height every 2 seconds. Needs ethrpc upstreams",
+				"over websockets. Falls back to polling while subscription",
+				"is down"),
+			Default: false,
+		},
+		{
+			Name:      "confirmationDepth",
+			Type:      "uint64",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("number of recently indexed block hashes to remember",
+				"for reorg detection. on parent hash mismatch node rolls",
+				"back to common ancestor and emits a rollback payload.",
+				"reorgs deeper than this halt the node. 0 disables checks"),
+			Default: 64,
+		},
 		{
 			Name:      "pricingChainlinkOraclesDumpFile",
 			Type:      "string",
@@ -144,5 +194,34 @@ var (
 			Info:      cfg.SArr("dump file containing list of dexes to take into account historically"),
 			Default:   "dex_dumpfile.csv",
 		},
+		{
+			Name:      "pricingMinLiquidityUSD",
+			Type:      "uint64",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("minimum USD liquidity of every dex pool on a pricing",
+				"route. routes through thinner pools are not used. prices",
+				"are liquidity weighted median of remaining routes"),
+			Default: 10000,
+		},
+		{
+			Name:      "pricingSnapshotRetention",
+			Type:      "uint64",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("blocks behind latest for which pricing graph versions",
+				"are kept, older ones are pruned. backfills below are",
+				"emitted unpriced. must exceed confirmationDepth. disk",
+				"use is about retention/100 + 2 whole graphs plus a delta",
+				"per block. 0 keeps all, growing without bound"),
+			Default: 5000,
+		},
+		{
+			Name:      "pricingCEXType",
+			Type:      "string",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("source of CEX prices for tokens without on-chain",
+				"liquidity. `none`, `csv` (local candle archive) or `http`",
+				"(price service), configured in pricingCEX section"),
+			Default: "none",
+		},
 	}
 )
@@ -0,0 +1,254 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	oldpriceresolver "github.com/supragya/EtherScope/libs/oldpricing"
+	"github.com/supragya/EtherScope/libs/util"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	lb "github.com/supragya/EtherScope/services/local_backend"
+	outs "github.com/supragya/EtherScope/services/output_sink"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// memLB is an in-memory LocalBackend
+type memLB struct {
+	lb.LocalBackend
+	mu sync.Mutex
+	kv map[string][]byte
+}
+
+func newMemLB() *memLB {
+	return &memLB{kv: map[string][]byte{}}
+}
+
+func (m *memLB) Get(key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	val, ok := m.kv[key]
+	return val, ok, nil
+}
+
+func (m *memLB) Set(key string, val []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.kv[key] = val
+	return nil
+}
+
+func (m *memLB) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.kv, key)
+	return nil
+}
+
+func (m *memLB) Sync() error { return nil }
+
+func (m *memLB) Iterate(prefix string, fn func(key string, val []byte) error) error {
+	m.mu.Lock()
+	keys := []string{}
+	for key := range m.kv {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	m.mu.Unlock()
+	sort.Strings(keys)
+	for _, key := range keys {
+		val, _, _ := m.Get(key)
+		if err := fn(key, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chainRPC serves headers of a test chain
+type chainRPC struct {
+	ethrpc.EthRPC
+	mu      sync.Mutex
+	headers map[uint64]*types.Header
+}
+
+func newChainRPC() *chainRPC {
+	return &chainRPC{headers: map[uint64]*types.Header{}}
+}
+
+// extend adds headers of heights [from, to] on fork, each building
+// upon header below it
+func (c *chainRPC) extend(fork string, from uint64, to uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for height := from; height <= to; height++ {
+		header := &types.Header{
+			Number:     new(big.Int).SetUint64(height),
+			Difficulty: big.NewInt(0),
+			Time:       1600000000 + height*12,
+			Extra:      []byte(fork),
+		}
+		if parent, ok := c.headers[height-1]; ok && height > 0 {
+			header.ParentHash = parent.Hash()
+		}
+		c.headers[height] = header
+	}
+}
+
+func (c *chainRPC) hash(height uint64) common.Hash {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.headers[height].Hash()
+}
+
+func (c *chainRPC) GetBlockHeader(height uint64) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	header, ok := c.headers[height]
+	if !ok {
+		return nil, fmt.Errorf("no header at %d", height)
+	}
+	return header, nil
+}
+
+func (c *chainRPC) GetBlockTimestamp(height uint64) (uint64, error) {
+	header, err := c.GetBlockHeader(height)
+	if err != nil {
+		return 0, err
+	}
+	return header.Time, nil
+}
+
+// testSink records accepted payloads, failing first `failures` sends
+type testSink struct {
+	outs.OutputSink
+	mu       sync.Mutex
+	failures int
+	payloads []*Payload
+}
+
+func (s *testSink) Send(payload interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("send failed")
+	}
+	s.payloads = append(s.payloads, payload.(*Payload))
+	return nil
+}
+
+func (s *testSink) sent() []*Payload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Payload{}, s.payloads...)
+}
+
+func newTestNode(rpc ethrpc.EthRPC, backend lb.LocalBackend, sink outs.OutputSink) *NodeImpl {
+	return &NodeImpl{
+		log:               logger.NewNopLogger(),
+		EthRPC:            rpc,
+		LocalBackend:      backend,
+		OutputSink:        sink,
+		confirmationDepth: 16,
+		blockHashes:       make(map[uint64]common.Hash),
+		hasPersistentLB:   true,
+		quitCh:            make(chan struct{}, 1),
+		backoff:           backoff.NewConstantBackOff(time.Millisecond),
+	}
+}
+
+func TestCommitBlockCheckpointsAfterSend(t *testing.T) {
+	rpc := newChainRPC()
+	rpc.extend("a", 0, 5)
+	backend := newMemLB()
+	sink := &checkpointSink{testSink: testSink{failures: 1}, backend: backend}
+	n := newTestNode(rpc, backend, sink)
+	n.oldpricer = &oldpriceresolver.Pricing{}
+
+	decoded, err := n.decodeBlock(map[uint64]CLogType{}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.commitBlock(decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	// Failed send is retried, checkpoint is written only once accepted
+	if sink.attempts != 2 || len(sink.sent()) != 1 {
+		t.Errorf("expected payload accepted on second attempt, got %d attempts", sink.attempts)
+	}
+	if sink.checkpointed {
+		t.Error("expected no checkpoint before payload was accepted")
+	}
+	if checkpoint, ok := n.getHeight(lb.KeyCheckpointHeight); !ok || checkpoint != 5 {
+		t.Errorf("expected checkpoint 5, got %d", checkpoint)
+	}
+	if n.blockHashes[5] != rpc.hash(5) {
+		t.Error("expected hash of committed block recorded")
+	}
+}
+
+// checkpointSink notes whether checkpoint was present on any send
+type checkpointSink struct {
+	testSink
+	backend      *memLB
+	attempts     int
+	checkpointed bool
+}
+
+func (s *checkpointSink) Send(payload interface{}) error {
+	s.attempts++
+	if _, ok, _ := s.backend.Get(lb.KeyCheckpointHeight); ok {
+		s.checkpointed = true
+	}
+	return s.testSink.Send(payload)
+}
+
+func TestResumeHeight(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		checkpoint     *uint64
+		latest         *uint64
+		fromCheckpoint bool
+		expected       uint64
+	}{
+		{"new localbackend", nil, nil, false, 3},
+		{"checkpoint only", uint64Ptr(9), nil, false, 9},
+		{"latest only", nil, uint64Ptr(7), false, 7},
+		{"crash after pricing before send", uint64Ptr(10), uint64Ptr(11), false, 10},
+		{"pricing behind checkpoint", uint64Ptr(10), uint64Ptr(8), false, 8},
+		{"resume from checkpoint", uint64Ptr(10), uint64Ptr(8), true, 10},
+		{"resume without checkpoint", nil, uint64Ptr(8), true, 3},
+	} {
+		backend := newMemLB()
+		if tc.checkpoint != nil {
+			backend.Set(lb.KeyCheckpointHeight, util.GobEncode(*tc.checkpoint))
+		}
+		if tc.latest != nil {
+			backend.Set(lb.KeyLatestHeight, util.GobEncode(*tc.latest))
+		}
+
+		// Restarted node picks up heights left by previous run
+		n := newTestNode(newChainRPC(), backend, &testSink{})
+		n.startBlock = 3
+		n.skipResumeRemote = true
+		n.resumeFromCheckpoint = tc.fromCheckpoint
+		if height := n.syncStartHeight(); height != tc.expected {
+			t.Errorf("%s: expected start height %d, got %d", tc.name, tc.expected, height)
+		}
+	}
+}
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
@@ -0,0 +1,124 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/supragya/EtherScope/libs/processors"
+	"github.com/supragya/EtherScope/libs/util"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	// Processors available to the node, enabled by name using
+	// `node.processors` in config
+	_ "github.com/supragya/EtherScope/libs/processors/erc20"
+	_ "github.com/supragya/EtherScope/libs/processors/generic"
+	_ "github.com/supragya/EtherScope/libs/processors/traderjoev2"
+	_ "github.com/supragya/EtherScope/libs/processors/uniswapV2"
+	_ "github.com/supragya/EtherScope/libs/processors/uniswapV3"
+)
+
+// setupProcessors instantiates processors enabled in config (and
+// those required by pricing engine), populates mergedTopics and
+// routes every merged topic to the processor handling it
+func (n *NodeImpl) setupProcessors() error {
+	registered := make(map[string]bool)
+	for _, name := range processors.Registered() {
+		registered[name] = true
+	}
+	enabled := make(map[string]bool, len(n.processorNames))
+	for _, name := range n.processorNames {
+		if !registered[name] {
+			return fmt.Errorf("unknown processor: %s", name)
+		}
+		enabled[name] = true
+	}
+
+	// Processors hold on to mergedTopics, it is populated in place
+	// once all processors are known
+	n.mergedTopics = make(map[common.Hash]itypes.ProcessingType)
+
+	handlers := make(map[common.Hash]processors.Processor)
+	handlerNames := make(map[common.Hash]string)
+	var requiredEvents []common.Hash
+	var rpc ethrpc.EthRPC = n.EthRPC
+	if n.senderCache != nil {
+		rpc = n.senderCache
+	}
+	for _, name := range processors.Registered() {
+		proc, err := processors.New(name, n.mergedTopics, rpc)
+		if err != nil {
+			if enabled[name] {
+				return err
+			}
+			n.log.Warn("skipping disabled processor failing setup", "processor", name, "error", err)
+			continue
+		}
+		pricingTopics := proc.PricingTopics()
+		if n.allowPricingState && len(pricingTopics) > 0 {
+			if !enabled[name] {
+				n.log.Info("enabling processor needed by pricing engine", "processor", name)
+			}
+			enabled[name] = true
+			requiredEvents = append(requiredEvents, pricingTopics...)
+		}
+		if !enabled[name] {
+			continue
+		}
+		for _, topic := range proc.HandledTopics() {
+			if other, ok := handlerNames[topic]; ok {
+				return fmt.Errorf("topic %s handled by both %s and %s processors", topic, other, name)
+			}
+			handlers[topic] = proc
+			handlerNames[topic] = name
+		}
+	}
+
+	requestedEvents, err := util.ConstructTopics(n.eventsToIndex)
+	if err != nil {
+		return err
+	}
+	for idx, topic := range requestedEvents {
+		if _, ok := handlers[topic]; !ok {
+			return fmt.Errorf("no enabled processor handles requested event %s", n.eventsToIndex[idx])
+		}
+	}
+
+	for topic, ptype := range mergeTopics(requestedEvents, requiredEvents) {
+		n.mergedTopics[topic] = ptype
+	}
+
+	n.topicProcessors = make(map[common.Hash]processors.Processor, len(n.mergedTopics))
+	for topic := range n.mergedTopics {
+		n.topicProcessors[topic] = handlers[topic]
+	}
+	return nil
+}
+
+// registeredProcessors lists names of all registered processors,
+// for config documentation
+func registeredProcessors() []string {
+	names := []string{}
+	for _, name := range processors.Registered() {
+		names = append(names, "- "+name)
+	}
+	return names
+}
+
+// registeredEvents lists names of all events handled by registered
+// processors, for config documentation
+func registeredEvents() []string {
+	events := []string{}
+	for _, name := range processors.Registered() {
+		proc, err := processors.New(name, nil, nil)
+		if err != nil {
+			continue
+		}
+		for _, topic := range proc.HandledTopics() {
+			if str, ok := itypes.GetStringForTopic(topic); ok {
+				events = append(events, fmt.Sprintf("- %s (%s)", str, name))
+			}
+		}
+	}
+	return events
+}
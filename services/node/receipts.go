@@ -0,0 +1,144 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/supragya/EtherScope/services/ethrpc"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// Ingestion modes of node
+const (
+	// Logs are filtered by topic upstream using eth_getLogs
+	IngestionLogs = "logs"
+	// Whole blocks and receipts are fetched and logs filtered locally
+	IngestionReceipts = "receipts"
+)
+
+// txSenderCache serves tx senders learnt while fetching blocks in
+// receipts mode, falling back to upstream for unknown transactions
+type txSenderCache struct {
+	ethrpc.EthRPC
+	senders sync.Map // common.Hash -> common.Address
+}
+
+func (c *txSenderCache) GetTxSender(txHash, blockHash common.Hash, txIdx uint) (common.Address, error) {
+	if sender, ok := c.senders.Load(txHash); ok {
+		return sender.(common.Address), nil
+	}
+	return c.EthRPC.GetTxSender(txHash, blockHash, txIdx)
+}
+
+// fetchLogs fetches logs of interest for blocks in [start, end] grouped
+// by block number. Returned release func is to be called once all
+// blocks are decoded
+func (n *NodeImpl) fetchLogs(start uint64, end uint64) (map[uint64]CLogType, func(), error) {
+	if n.ingestionMode != IngestionReceipts {
+		logs, err := n.EthRPC.GetFilteredLogs(ethereum.FilterQuery{
+			FromBlock: big.NewInt(int64(start)),
+			ToBlock:   big.NewInt(int64(end)),
+			Topics:    [][]common.Hash{n.mergedTopicsKeys},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return GroupByBlockNumber(logs), func() {}, nil
+	}
+
+	var (
+		mu      sync.Mutex
+		logs    []types.Log
+		learnt  []common.Hash
+		release = func() {
+			for _, txHash := range learnt {
+				n.senderCache.senders.Delete(txHash)
+			}
+		}
+	)
+	eg := new(errgroup.Group)
+	eg.SetLimit(n.maxCPUParallels)
+	for block := start; block <= end; block++ {
+		height := block
+		eg.Go(func() error {
+			blockLogs, senders, err := n.fetchBlockReceipts(height)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			logs = append(logs, blockLogs...)
+			for txHash, sender := range senders {
+				n.senderCache.senders.Store(txHash, sender)
+				learnt = append(learnt, txHash)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		release()
+		return nil, nil, err
+	}
+	return GroupByBlockNumber(logs), release, nil
+}
+
+// fetchBlockReceipts fetches block and receipts at height, verifies
+// receipts are complete and returns logs of interest along with
+// senders of transactions emitting them
+func (n *NodeImpl) fetchBlockReceipts(height uint64) ([]types.Log, map[common.Hash]common.Address, error) {
+	block, senders, err := n.EthRPC.GetBlockWithSenders(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipts, err := n.EthRPC.GetBlockReceipts(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := verifyReceipts(block, receipts); err != nil {
+		return nil, nil, err
+	}
+
+	logs := []types.Log{}
+	learnt := make(map[common.Hash]common.Address)
+	for idx, receipt := range receipts {
+		for _, l := range receipt.Logs {
+			if len(l.Topics) == 0 {
+				continue
+			}
+			if _, ok := n.mergedTopics[l.Topics[0]]; !ok {
+				continue
+			}
+			logs = append(logs, *l)
+			learnt[receipt.TxHash] = senders[idx]
+		}
+	}
+	return logs, learnt, nil
+}
+
+// verifyReceipts checks that receipts are those of all transactions of
+// block, and that logs in them make up the logs bloom of block
+func verifyReceipts(block *types.Block, receipts []*types.Receipt) error {
+	txs := block.Transactions()
+	if len(receipts) != len(txs) {
+		return fmt.Errorf("block %d has %d txs, got %d receipts",
+			block.NumberU64(), len(txs), len(receipts))
+	}
+	for idx, receipt := range receipts {
+		if receipt.TxHash != txs[idx].Hash() {
+			return fmt.Errorf("block %d receipt %d is for tx %s, expected %s",
+				block.NumberU64(), idx, receipt.TxHash, txs[idx].Hash())
+		}
+		if receipt.BlockHash != block.Hash() {
+			return fmt.Errorf("block %d receipt %d is from block %s, expected %s, possible n/w reorg",
+				block.NumberU64(), idx, receipt.BlockHash, block.Hash())
+		}
+	}
+	if types.CreateBloom(receipts) != block.Bloom() {
+		return fmt.Errorf("block %d logs do not match logs bloom, partial receipts", block.NumberU64())
+	}
+	return nil
+}
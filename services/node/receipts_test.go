@@ -0,0 +1,170 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// newReceiptsBlock returns a block at height of n txs, each emitting a
+// log with topic idx+1, along with receipts of them
+func newReceiptsBlock(height uint64, n int) (*types.Block, []*types.Receipt) {
+	txs := []*types.Transaction{}
+	receipts := []*types.Receipt{}
+	for idx := 0; idx < n; idx++ {
+		tx := types.NewTransaction(uint64(idx), common.BytesToAddress([]byte{byte(idx + 1)}),
+			big.NewInt(0), 21000, big.NewInt(1), nil)
+		receipt := &types.Receipt{
+			Status: types.ReceiptStatusSuccessful,
+			TxHash: tx.Hash(),
+			Logs: []*types.Log{{
+				Address:     common.BytesToAddress([]byte{byte(idx + 1)}),
+				Topics:      []common.Hash{common.BytesToHash([]byte{byte(idx + 1)})},
+				BlockNumber: height,
+				TxHash:      tx.Hash(),
+				TxIndex:     uint(idx),
+			}},
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+	}
+	block := types.NewBlock(&types.Header{Number: new(big.Int).SetUint64(height)}, txs, nil, receipts, trie.NewStackTrie(nil))
+	for _, receipt := range receipts {
+		receipt.BlockHash = block.Hash()
+		receipt.Logs[0].BlockHash = block.Hash()
+	}
+	return block, receipts
+}
+
+func TestVerifyReceipts(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		tamper func(block *types.Block, receipts []*types.Receipt) []*types.Receipt
+		err    string
+	}{
+		{"complete", func(block *types.Block, receipts []*types.Receipt) []*types.Receipt {
+			return receipts
+		}, ""},
+		{"missing receipt", func(block *types.Block, receipts []*types.Receipt) []*types.Receipt {
+			return receipts[:2]
+		}, "has 3 txs, got 2 receipts"},
+		{"mismatched tx hash", func(block *types.Block, receipts []*types.Receipt) []*types.Receipt {
+			receipts[0], receipts[1] = receipts[1], receipts[0]
+			return receipts
+		}, "receipt 0 is for tx"},
+		{"mismatched block hash", func(block *types.Block, receipts []*types.Receipt) []*types.Receipt {
+			receipts[2].BlockHash = common.HexToHash("0x01")
+			return receipts
+		}, "possible n/w reorg"},
+		{"bad bloom", func(block *types.Block, receipts []*types.Receipt) []*types.Receipt {
+			receipts[1].Logs = nil
+			receipts[1].Bloom = types.Bloom{}
+			return receipts
+		}, "do not match logs bloom"},
+	} {
+		block, receipts := newReceiptsBlock(10, 3)
+		err := verifyReceipts(block, tc.tamper(block, receipts))
+		if tc.err == "" && err != nil {
+			t.Errorf("%s: expected receipts verified, got %v", tc.name, err)
+		}
+		if tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.err, err)
+		}
+	}
+}
+
+var fallbackSender = common.HexToAddress("0xff")
+
+// receiptsRPC serves blocks of newReceiptsBlock, senders of txs being
+// 0xa0 + tx index
+type receiptsRPC struct {
+	*chainRPC
+	blocks   map[uint64]*types.Block
+	receipts map[uint64][]*types.Receipt
+}
+
+func newReceiptsRPC(heights ...uint64) *receiptsRPC {
+	rpc := &receiptsRPC{
+		chainRPC: newChainRPC(),
+		blocks:   map[uint64]*types.Block{},
+		receipts: map[uint64][]*types.Receipt{},
+	}
+	for _, height := range heights {
+		rpc.blocks[height], rpc.receipts[height] = newReceiptsBlock(height, 3)
+	}
+	return rpc
+}
+
+func (r *receiptsRPC) GetBlockWithSenders(height uint64) (*types.Block, []common.Address, error) {
+	block, ok := r.blocks[height]
+	if !ok {
+		return nil, nil, fmt.Errorf("no block at %d", height)
+	}
+	senders := []common.Address{}
+	for idx := range block.Transactions() {
+		senders = append(senders, common.BytesToAddress([]byte{byte(0xa0 + idx)}))
+	}
+	return block, senders, nil
+}
+
+func (r *receiptsRPC) GetBlockReceipts(height uint64) ([]*types.Receipt, error) {
+	return r.receipts[height], nil
+}
+
+func (r *receiptsRPC) GetTxSender(txHash, blockHash common.Hash, txIdx uint) (common.Address, error) {
+	return fallbackSender, nil
+}
+
+func TestFetchLogsFromReceipts(t *testing.T) {
+	rpc := newReceiptsRPC(10, 11)
+	n := newTestNode(rpc, newMemLB(), &testSink{})
+	n.ingestionMode = IngestionReceipts
+	n.maxCPUParallels = 2
+	n.senderCache = &txSenderCache{EthRPC: rpc}
+	topic := common.BytesToHash([]byte{2})
+	n.mergedTopics = map[common.Hash]itypes.ProcessingType{topic: itypes.UserRequested}
+
+	logs, release, err := n.fetchLogs(10, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only logs of second tx carry topic of interest
+	for _, height := range []uint64{10, 11} {
+		blockLogs := logs[height]
+		if len(blockLogs) != 1 || blockLogs[0].Topics[0] != topic {
+			t.Fatalf("expected single log of interest at %d, got %v", height, blockLogs)
+		}
+		txHash := rpc.receipts[height][1].TxHash
+		if blockLogs[0].TxHash != txHash {
+			t.Errorf("expected log of tx %s at %d, got %s", txHash, height, blockLogs[0].TxHash)
+		}
+		sender, err := n.senderCache.GetTxSender(txHash, rpc.blocks[height].Hash(), 1)
+		if err != nil || sender != common.BytesToAddress([]byte{0xa1}) {
+			t.Errorf("expected sender of tx at %d learnt from block, got %s, %v", height, sender, err)
+		}
+	}
+
+	// Learnt senders are dropped once blocks are decoded
+	release()
+	txHash := rpc.receipts[10][1].TxHash
+	if sender, _ := n.senderCache.GetTxSender(txHash, rpc.blocks[10].Hash(), 1); sender != fallbackSender {
+		t.Errorf("expected sender from upstream after release, got %s", sender)
+	}
+
+	// Incomplete receipts fail fetch
+	rpc.receipts[11] = rpc.receipts[11][:2]
+	if _, _, err := n.fetchLogs(10, 11); err == nil || !strings.Contains(err.Error(), "got 2 receipts") {
+		t.Errorf("expected incomplete receipts error, got %v", err)
+	}
+	if sender, _ := n.senderCache.GetTxSender(txHash, rpc.blocks[10].Hash(), 1); sender != fallbackSender {
+		t.Errorf("expected senders of failed fetch dropped, got %s", sender)
+	}
+}
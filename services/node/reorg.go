@@ -0,0 +1,168 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/supragya/EtherScope/libs/util"
+	"github.com/supragya/EtherScope/services/instrumentation"
+	lb "github.com/supragya/EtherScope/services/local_backend"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type heightHash = itypes.Tuple2[uint64, common.Hash]
+
+var (
+	ErrReorgTooDeep = errors.New("ReorgTooDeepError")
+)
+
+// blockHashKey returns localbackend slot for given height. Slots
+// are reused modulo confirmationDepth so that localbackend does not
+// grow with chain height
+func (n *NodeImpl) blockHashKey(height uint64) string {
+	return fmt.Sprintf("%s%d", lb.KeyBlockHashPrefix, height%n.confirmationDepth)
+}
+
+// recordBlockHash remembers hash of an indexed block for reorg
+// detection. Hashes older than confirmationDepth are forgotten
+func (n *NodeImpl) recordBlockHash(height uint64, hash common.Hash) error {
+	if n.confirmationDepth == 0 {
+		return nil
+	}
+	n.blockHashes[height] = hash
+	if height >= n.confirmationDepth {
+		delete(n.blockHashes, height-n.confirmationDepth)
+	}
+	if !n.hasPersistentLB {
+		return nil
+	}
+	return n.LocalBackend.Set(n.blockHashKey(height), util.GobEncode(heightHash{First: height, Second: hash}))
+}
+
+// loadBlockHashes warms up in-memory block hashes from localbackend
+// for heights within confirmationDepth of indexedHeight
+func (n *NodeImpl) loadBlockHashes() {
+	if n.confirmationDepth == 0 || !n.hasPersistentLB {
+		return
+	}
+	loaded := 0
+	for i := uint64(0); i < n.confirmationDepth && i <= n.indexedHeight; i++ {
+		height := n.indexedHeight - i
+		val, ok, err := n.LocalBackend.Get(n.blockHashKey(height))
+		if err != nil {
+			n.log.Fatal(fmt.Sprintf("error while fetching block hash from localbackend: %v", err))
+		}
+		if !ok {
+			continue
+		}
+		var record heightHash
+		if err := util.GobDecode(val, &record); err != nil {
+			n.log.Fatal(fmt.Sprintf("wrong block hash encoding: %s", err))
+		}
+		// slot may be holding a hash for some other height
+		if record.First != height {
+			continue
+		}
+		n.blockHashes[height] = record.Second
+		loaded++
+	}
+	n.log.Info("loaded block hashes for reorg detection",
+		"loaded", loaded,
+		"depth", n.confirmationDepth)
+}
+
+// checkReorg verifies that the block following indexedHeight builds
+// upon the block we indexed. On parent hash mismatch it rolls back
+// indexedHeight, checkpoint and pricing state to common ancestor and
+// emits a rollback payload.
+// Parent hash is taken from head if it is the following block (as
+// received over newHeads), else fetched from rpc.
+// Returns true if a rollback took place
+func (n *NodeImpl) checkReorg(head *types.Header) (bool, error) {
+	if n.confirmationDepth == 0 {
+		return false, nil
+	}
+	known, ok := n.blockHashes[n.indexedHeight]
+	if !ok {
+		// Nothing indexed by us yet at this height
+		return false, nil
+	}
+	header := head
+	if header == nil || header.Number.Uint64() != n.indexedHeight+1 {
+		var err error
+		header, err = n.EthRPC.GetBlockHeader(n.indexedHeight + 1)
+		if err != nil {
+			return false, err
+		}
+	}
+	if header.ParentHash == known {
+		return false, nil
+	}
+
+	n.log.Warn("parent hash mismatch, chain reorganisation detected",
+		"height", n.indexedHeight+1,
+		"parent", header.ParentHash,
+		"indexed", known)
+
+	ancestor, orphaned, err := n.findCommonAncestor()
+	if err != nil {
+		return false, err
+	}
+
+	n.log.Warn(fmt.Sprintf("rolling back indexed height from %d to %d", n.indexedHeight, ancestor),
+		"orphaned", len(orphaned))
+
+	// Pricing graph versions of orphaned blocks are dropped before
+	// anything is sent, so that a failure here redoes the rollback
+	if n.allowPricingState {
+		if err := n.pricer.Rollback(ancestor); err != nil {
+			return false, fmt.Errorf("could not roll back pricing state to %d: %w", ancestor, err)
+		}
+	}
+
+	n.sendPayload(n.genPayload(nil, []interface{}{&itypes.Rollback{
+		Type:           "rollback",
+		CommonAncestor: ancestor,
+		OrphanedHeight: n.indexedHeight,
+		OrphanedHashes: orphaned,
+	}}, nil))
+
+	// Blocks above ancestor are to be sent again after restart
+	if err := n.checkpoint(ancestor); err != nil {
+		n.log.Warn(fmt.Sprintf("Error checkpointing common ancestor %d. Caused by: %s", ancestor, err))
+	}
+
+	for height := ancestor + 1; height <= n.indexedHeight; height++ {
+		delete(n.blockHashes, height)
+	}
+	n.indexedHeight = ancestor
+	instrumentation.ReorgsDetected.Inc()
+	return true, nil
+}
+
+// findCommonAncestor walks back from indexedHeight till the hash
+// known to us matches the one reported by rpc. Returns the ancestor
+// height along with hashes of orphaned blocks (highest first)
+func (n *NodeImpl) findCommonAncestor() (uint64, []common.Hash, error) {
+	orphaned := []common.Hash{}
+	for height := n.indexedHeight; ; height-- {
+		known, ok := n.blockHashes[height]
+		if !ok {
+			return 0, orphaned, fmt.Errorf("no common ancestor found within confirmation depth %d above %d: %w",
+				n.confirmationDepth, height, ErrReorgTooDeep)
+		}
+		header, err := n.EthRPC.GetBlockHeader(height)
+		if err != nil {
+			return 0, orphaned, err
+		}
+		if header.Hash() == known {
+			return height, orphaned, nil
+		}
+		orphaned = append(orphaned, known)
+		if height == 0 {
+			return 0, orphaned, fmt.Errorf("no common ancestor found till genesis: %w", ErrReorgTooDeep)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	priceresolver "github.com/supragya/EtherScope/libs/pricing"
+	lb "github.com/supragya/EtherScope/services/local_backend"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// indexChain records hashes of blocks [from, to] of rpc as indexed
+func indexChain(t *testing.T, n *NodeImpl, rpc *chainRPC, from uint64, to uint64) {
+	for height := from; height <= to; height++ {
+		if err := n.recordBlockHash(height, rpc.hash(height)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n.indexedHeight = to
+}
+
+func TestCheckReorg(t *testing.T) {
+	rpc := newChainRPC()
+	rpc.extend("a", 0, 11)
+	backend, sink := newMemLB(), &testSink{}
+	n := newTestNode(rpc, backend, sink)
+	indexChain(t, n, rpc, 0, 10)
+
+	// Pricing graph versions stored while indexing fork a
+	n.allowPricingState = true
+	n.pricer = priceresolver.NewDefaultEngine(logger.NewNopLogger(), "", "", 0, rpc, backend)
+	graphKey := func(height uint64, kind string) string {
+		return fmt.Sprintf("%s:%020d:%s", lb.KeyGraphPrefix, height, kind)
+	}
+	backend.Set(graphKey(5, "f"), []byte{})
+	backend.Set(graphKey(8, "d"), []byte{})
+	backend.Set(graphKey(10, "d"), []byte{})
+
+	rolledBack, err := n.checkReorg(nil)
+	if err != nil || rolledBack {
+		t.Fatalf("expected no reorg on same fork, got %v, %v", rolledBack, err)
+	}
+
+	// Fork b replaces blocks from 8 onwards
+	orphaned := []common.Hash{rpc.hash(10), rpc.hash(9), rpc.hash(8)}
+	rpc.extend("b", 8, 11)
+	rolledBack, err = n.checkReorg(nil)
+	if err != nil || !rolledBack {
+		t.Fatalf("expected reorg, got %v, %v", rolledBack, err)
+	}
+	if n.indexedHeight != 7 {
+		t.Errorf("expected indexed height rolled back to 7, got %d", n.indexedHeight)
+	}
+	if checkpoint, ok := n.getHeight(lb.KeyCheckpointHeight); !ok || checkpoint != 7 {
+		t.Errorf("expected checkpoint lowered to 7, got %d", checkpoint)
+	}
+	for height := uint64(8); height <= 10; height++ {
+		if _, ok := n.blockHashes[height]; ok {
+			t.Errorf("expected hash of orphaned block %d to be forgotten", height)
+		}
+	}
+
+	payloads := sink.sent()
+	if len(payloads) != 1 || len(payloads[0].Items) != 1 {
+		t.Fatalf("expected a single rollback payload, got %v", payloads)
+	}
+	rollback, ok := payloads[0].Items[0].(*itypes.Rollback)
+	if !ok {
+		t.Fatalf("expected rollback item, got %T", payloads[0].Items[0])
+	}
+	if rollback.CommonAncestor != 7 || rollback.OrphanedHeight != 10 ||
+		!reflect.DeepEqual(rollback.OrphanedHashes, orphaned) {
+		t.Errorf("unexpected rollback %+v", rollback)
+	}
+
+	// Only pricing state of blocks up to ancestor is left
+	if _, ok := backend.kv[graphKey(5, "f")]; !ok {
+		t.Error("expected pricing graph of ancestor kept")
+	}
+	for _, key := range []string{graphKey(8, "d"), graphKey(10, "d")} {
+		if _, ok := backend.kv[key]; ok {
+			t.Errorf("expected pricing graph %s of orphaned block dropped", key)
+		}
+	}
+}
+
+func TestFindCommonAncestor(t *testing.T) {
+	rpc := newChainRPC()
+	rpc.extend("a", 0, 10)
+	n := newTestNode(rpc, newMemLB(), &testSink{})
+	indexChain(t, n, rpc, 0, 10)
+
+	ancestor, orphaned, err := n.findCommonAncestor()
+	if err != nil || ancestor != 10 || len(orphaned) != 0 {
+		t.Errorf("expected indexed height as ancestor, got %d, %v, %v", ancestor, orphaned, err)
+	}
+
+	orphanedHashes := []common.Hash{rpc.hash(10), rpc.hash(9)}
+	rpc.extend("b", 9, 10)
+	ancestor, orphaned, err = n.findCommonAncestor()
+	if err != nil || ancestor != 8 || !reflect.DeepEqual(orphaned, orphanedHashes) {
+		t.Errorf("expected ancestor 8 with 2 orphaned blocks, got %d, %v, %v", ancestor, orphaned, err)
+	}
+}
+
+func TestReorgTooDeep(t *testing.T) {
+	rpc := newChainRPC()
+	rpc.extend("a", 0, 11)
+	sink := &testSink{}
+	n := newTestNode(rpc, newMemLB(), sink)
+	n.confirmationDepth = 3
+	indexChain(t, n, rpc, 0, 10)
+
+	// Hashes below 8 are forgotten, fork from 6 cannot be resolved
+	rpc.extend("b", 6, 11)
+	rolledBack, err := n.checkReorg(nil)
+	if !errors.Is(err, ErrReorgTooDeep) || rolledBack {
+		t.Fatalf("expected ErrReorgTooDeep, got %v, %v", rolledBack, err)
+	}
+	if n.indexedHeight != 10 || len(sink.sent()) != 0 {
+		t.Error("expected no rollback on too deep reorg")
+	}
+}
@@ -0,0 +1,165 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	priceresolver "github.com/supragya/EtherScope/libs/pricing"
+	"github.com/supragya/EtherScope/services/ethrpc"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const replayHeight = 100
+
+var (
+	replayWETH   = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	replayOracle = common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419")
+	replaySender = common.HexToAddress("0x56178a0d5f301baf6cf3e1cd53d9863437345bf9")
+)
+
+// tokenRPC serves a test chain on which WETH is priced by a chainlink
+// feed answering 1500 USD
+type tokenRPC struct {
+	*chainRPC
+}
+
+func (tokenRPC) GetERC20Decimals(address common.Address, callopts *bind.CallOpts) (uint8, error) {
+	if address == replayOracle {
+		return 8, nil
+	}
+	return 18, nil
+}
+
+func (tokenRPC) GetERC20Name(address common.Address, callopts *bind.CallOpts) (string, error) {
+	return "Wrapped Ether", nil
+}
+
+func (tokenRPC) GetTxSender(txHash, blockHash common.Hash, txIdx uint) (common.Address, error) {
+	return replaySender, nil
+}
+
+func (r tokenRPC) GetChainlinkRoundData(contractAddress common.Address, callopts *bind.CallOpts) (itypes.ChainlinkLatestRoundData, error) {
+	updatedAt, _ := r.GetBlockTimestamp(callopts.BlockNumber.Uint64() - 1)
+	return itypes.ChainlinkLatestRoundData{
+		RoundId:         big.NewInt(7),
+		Answer:          big.NewInt(1500_00000000),
+		StartedAt:       new(big.Int).SetUint64(updatedAt),
+		UpdatedAt:       new(big.Int).SetUint64(updatedAt),
+		AnsweredInRound: big.NewInt(7),
+	}, nil
+}
+
+// writePricingDumps writes dumps pricing graph is built from, with
+// WETH/USD chainlink feed and no dexes
+func writePricingDumps(t *testing.T, dir string) (string, string) {
+	chainlinkDump := filepath.Join(dir, "chainlink.csv")
+	dexDump := filepath.Join(dir, "dex.csv")
+	chainlink := "from,to,oracle,startBlock,heartbeat\n" +
+		replayWETH.Hex() + ",USD," + replayOracle.Hex() + ",0,3600\n"
+	if err := os.WriteFile(chainlinkDump, []byte(chainlink), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dex := "pair,token0,token1,fee,startBlock,type,exchange\n"
+	if err := os.WriteFile(dexDump, []byte(dex), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return chainlinkDump, dexDump
+}
+
+// indexWithPricing runs block at replayHeight, holding a transfer of
+// 2.5 WETH, through a fresh node with pricing engine on
+func indexWithPricing(t *testing.T, rpc ethrpc.EthRPC, blockHash common.Hash, chainlinkDump, dexDump string) []*Payload {
+	backend, sink := newMemLB(), &testSink{}
+	n := newTestNode(rpc, backend, sink)
+	n.allowPricingState = true
+	n.processorNames = []string{"erc20"}
+	n.eventsToIndex = []string{"ERC20Transfer"}
+	n.pricer = priceresolver.NewDefaultEngine(logger.NewNopLogger(), chainlinkDump, dexDump, 0, rpc, backend)
+	if err := n.setupProcessors(); err != nil {
+		t.Fatal(err)
+	}
+
+	transfer := types.Log{
+		Address: replayWETH,
+		Topics: []common.Hash{
+			itypes.ERC20TransferTopic,
+			common.BytesToHash(common.HexToAddress("0x596183ad5b82a845f43ce6826cb80bdfaa38187e").Bytes()),
+			common.BytesToHash(common.HexToAddress("0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640").Bytes()),
+		},
+		Data:        common.BigToHash(big.NewInt(2_500_000_000_000_000_000)).Bytes(),
+		BlockNumber: replayHeight,
+		TxHash:      common.HexToHash("0x22db0df3c18a7c7a3a66dd42ad588abf359856771930ceb7d2b5c9e9c5f04838"),
+		BlockHash:   blockHash,
+	}
+	decoded, err := n.decodeBlock(map[uint64]CLogType{replayHeight: {transfer}}, replayHeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.commitBlock(decoded); err != nil {
+		t.Fatal(err)
+	}
+	return sink.sent()
+}
+
+func TestReplayFixtureWithPricing(t *testing.T) {
+	dir := t.TempDir()
+	chainlinkDump, dexDump := writePricingDumps(t, dir)
+	fixture := filepath.Join(dir, "ethrpc.fixture.json")
+
+	// Record run against test chain
+	chain := newChainRPC()
+	chain.extend("a", 0, replayHeight)
+	recorder := ethrpc.NewFixtureRecorder(logger.NewNopLogger(), tokenRPC{chain}, fixture)
+	recorded := indexWithPricing(t, recorder, chain.hash(replayHeight), chainlinkDump, dexDump)
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replay run only has fixture to go by
+	replayer := ethrpc.NewFixtureReplayer(logger.NewNopLogger(), fixture)
+	if err := replayer.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	replayed := indexWithPricing(t, replayer, chain.hash(replayHeight), chainlinkDump, dexDump)
+
+	if len(recorded) != 1 || len(replayed) != 1 {
+		t.Fatalf("expected a payload on each run, got %d recorded, %d replayed", len(recorded), len(replayed))
+	}
+	recordedItems, _ := json.Marshal(recorded[0].Items)
+	replayedItems, _ := json.Marshal(replayed[0].Items)
+	if string(recordedItems) != string(replayedItems) {
+		t.Errorf("expected replayed items to match recorded\nrecorded: %s\nreplayed: %s", recordedItems, replayedItems)
+	}
+
+	if len(replayed[0].Items) != 1 {
+		t.Fatalf("expected a single transfer, got %v", replayed[0].Items)
+	}
+	transfer, ok := replayed[0].Items[0].(*itypes.Transfer)
+	if !ok {
+		t.Fatalf("expected transfer, got %T", replayed[0].Items[0])
+	}
+	if transfer.TxSender != replaySender || transfer.Amount.Text('f', 1) != "2.5" {
+		t.Errorf("unexpected transfer %+v", transfer)
+	}
+	if transfer.PriceDerivationMeta == nil || transfer.AmountUSD == nil {
+		t.Fatal("expected transfer priced by chainlink feed")
+	}
+	if price := transfer.PriceDerivationMeta.Price.Text('f', 0); price != "1500" {
+		t.Errorf("expected price 1500, got %s", price)
+	}
+	if usd := transfer.AmountUSD.Text('f', 0); usd != "3750" {
+		t.Errorf("expected 3750 USD, got %s", usd)
+	}
+	if !strings.Contains(string(replayedItems), "Chainlink (Wrapped Ether") {
+		t.Errorf("expected price path through chainlink feed, got %s", replayedItems)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
@@ -18,6 +19,7 @@ import (
 	iamqp "github.com/supragya/EtherScope/libs/amqp"
 	cfg "github.com/supragya/EtherScope/libs/config"
 	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/networks"
 	"github.com/supragya/EtherScope/libs/service"
 	"github.com/supragya/EtherScope/libs/util"
 	"github.com/supragya/EtherScope/services/ethrpc"
@@ -47,11 +49,13 @@ type OracleNodeImpl struct {
 	maxCPUParallels     int    // user requested CPU threads to allocate to the process
 	maxBlockSpanPerCall uint64 // max block spans to log per initial filtering call
 	feedRegistry        common.Address
+	feedRegistryHeight  uint64 // height feed registry was deployed at
 	feedFile            string
 
 	// Internal Data Structures
 	moniker            string    // user defined moniker for this node
 	network            string    // user defined evm compatible network name
+	chainID            uint64    // expected chain ID of network, 0 if unknown
 	nodeID             uuid.UUID // system generated node identifier unique for each run
 	indexedHeight      uint64
 	currentHeight      uint64
@@ -87,6 +91,17 @@ func (n *OracleNodeImpl) OnStart(ctx context.Context) error {
 		return err
 	}
 
+	if n.chainID != 0 {
+		chainID, err := n.EthRPC.GetChainID()
+		if err != nil {
+			return fmt.Errorf("could not fetch chain ID from ethrpc: %w", err)
+		}
+		if chainID != n.chainID {
+			return fmt.Errorf("ethrpc is on chain ID %d, expected %d for network %s",
+				chainID, n.chainID, n.network)
+		}
+	}
+
 	if err := n.OutputSink.Start(ctx); err != nil {
 		n.log.Info("Error initializing output sink, will reattempt connection until ready")
 	}
@@ -122,7 +137,7 @@ func (n *OracleNodeImpl) OnStop() {
 
 // SetupInitial sets up initial information that the oracleindexer needs
 func (n *OracleNodeImpl) setupInitial() {
-	start, end, stride := uint64(12864088), n.indexedHeight, uint64(1000) // Start of feed registry to now, each call indexing 100,000 blocks
+	start, end, stride := n.feedRegistryHeight, n.indexedHeight, uint64(1000) // Start of feed registry to now, each call indexing 100,000 blocks
 	n.log.Infof("need to index %d blocks for feed data, %d to %d", end-start, start, end)
 
 	if _, err := os.Stat(n.feedFile); err == nil {
@@ -569,11 +584,35 @@ func NewOracleNodeWithViperFields(log logger.Logger) (service.Service, error) {
 		return nil, err
 	}
 
+	// Feed registry defaults to that of network profile
+	var (
+		network            = viper.GetString(OracleNodeCFGSection + ".network")
+		feedRegistry       = viper.GetString(OracleNodeCFGSection + ".chainlinkFeedRegistry")
+		feedRegistryHeight = viper.GetUint64(OracleNodeCFGSection + ".chainlinkFeedRegistryHeight")
+	)
+	profile, hasProfile := networks.Get(network)
+	if feedRegistry == "" {
+		if !hasProfile || !profile.HasFeedRegistry() {
+			return nil, errors.New("no chainlink feed registry known for network " + network +
+				", set chainlinkFeedRegistry")
+		}
+		feedRegistry = profile.ChainlinkFeedRegistry.Hex()
+		feedRegistryHeight = profile.ChainlinkFeedRegistryHeight
+	}
+	if !common.IsHexAddress(feedRegistry) {
+		return nil, errors.New("invalid chainlinkFeedRegistry: " + feedRegistry)
+	}
+	// Deploy height of profile's registry is known even if set explicitly
+	if feedRegistryHeight == 0 && hasProfile && profile.HasFeedRegistry() &&
+		common.HexToAddress(feedRegistry) == profile.ChainlinkFeedRegistry {
+		feedRegistryHeight = profile.ChainlinkFeedRegistryHeight
+	}
+
 	// Setup ethrpc
 	if ethrpcType != "mspool" {
 		log.Fatal("unsupported ethrpc: " + ethrpcType)
 	}
-	_ethrpc, err := ethrpc.NewMSPoolEthRPCWithViperFields(log.With("service", "ethrpc"))
+	_ethrpc, err := ethrpc.NewMSPoolEthRPCWithViperFields(log.With("service", "ethrpc"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -589,9 +628,11 @@ func NewOracleNodeWithViperFields(log logger.Logger) (service.Service, error) {
 		maxBlockSpanPerCall: viper.GetUint64(OracleNodeCFGSection + ".maxBlockSpanPerCall"),
 		quitCh:              make(chan struct{}, 1),
 		moniker:             viper.GetString(OracleNodeCFGSection + ".moniker"),
-		network:             viper.GetString(OracleNodeCFGSection + ".network"),
+		network:             network,
+		chainID:             networks.ExpectedChainID(network, viper.GetUint64(OracleNodeCFGSection+".chainID")),
 		prodcheck:           viper.GetBool(OracleNodeCFGSection + ".prodcheck"),
-		feedRegistry:        common.HexToAddress(viper.GetString(OracleNodeCFGSection + ".chainlinkFeedRegistry")),
+		feedRegistry:        common.HexToAddress(feedRegistry),
+		feedRegistryHeight:  feedRegistryHeight,
 		feedFile:            viper.GetString(OracleNodeCFGSection + ".feedFile"),
 
 		feedMap:            make(map[itypes.Tuple2[common.Address, common.Address]]common.Address),
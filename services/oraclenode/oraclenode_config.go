@@ -41,6 +41,14 @@ var (
 			Info:      cfg.SArr("evm compatible network name"),
 			Default:   "ethereum-mainnet",
 		},
+		{
+			Name:      "chainID",
+			Type:      "uint64",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("chain ID ethrpc upstreams are expected to be on,",
+				"checked at startup. 0 takes chain ID from network profile"),
+			Default: 0,
+		},
 		{
 			Name:      "startBlock",
 			Type:      "uint64",
@@ -93,15 +101,27 @@ var (
 			Name:      "chainlinkFeedRegistry",
 			Type:      "string",
 			Necessity: "always needed",
-			Info:      cfg.SArr("contract address where feeds are registered and confirmed"),
-			Default:   "0x47Fb2585D2C56Fe188D0E6ec628a38b74fCeeeDf",
+			Info: cfg.SArr("contract address where feeds are registered and confirmed.",
+				"leave empty to use registry from network profile"),
+			Default: "",
+		},
+		{
+			Name:      "chainlinkFeedRegistryHeight",
+			Type:      "uint64",
+			Necessity: cfg.Optional,
+			Info: cfg.SArr("height chainlinkFeedRegistry was deployed at, feeds",
+				"are scanned from here on. 0 takes height from network",
+				"profile if chainlinkFeedRegistry is empty or is the",
+				"registry of profile"),
+			Default: 0,
 		},
 		{
 			Name:      "feedFile",
 			Type:      "string",
 			Necessity: "always needed",
-			Info:      cfg.SArr("file saving feeds for chainlink"),
-			Default:   "feeds.csv",
+			Info: cfg.SArr("file saving feeds for chainlink. heartbeats are not",
+				"on chain and not saved, pricing uses a day unless given"),
+			Default: "feeds.csv",
 		},
 	}
 )
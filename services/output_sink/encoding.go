@@ -0,0 +1,60 @@
+package outputsink
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/supragya/EtherScope/libs/payloadpb"
+	"github.com/supragya/EtherScope/version"
+)
+
+const (
+	EncodingJSON     = "json"
+	EncodingProtobuf = "protobuf"
+)
+
+// ProtoPayload is implemented by payloads having a protobuf
+// representation, see libs/payloadpb
+type ProtoPayload interface {
+	ToProto() *payloadpb.Payload
+}
+
+// payloadEncoder encodes payloads for sinks supporting `encoding`
+type payloadEncoder struct {
+	encoding string
+	indent   bool // indent json output
+}
+
+func newPayloadEncoder(encoding string, indent bool) (payloadEncoder, error) {
+	switch encoding {
+	case "":
+		encoding = EncodingJSON
+	case EncodingJSON, EncodingProtobuf:
+	default:
+		return payloadEncoder{}, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+	return payloadEncoder{encoding, indent}, nil
+}
+
+// encode returns encoded payload tagged with version.PersistenceVersion
+// along with its content type
+func (e payloadEncoder) encode(payload interface{}) ([]byte, string, error) {
+	if e.encoding == EncodingProtobuf {
+		pp, ok := payload.(ProtoPayload)
+		if !ok {
+			return nil, "", fmt.Errorf("payload %T has no protobuf encoding", payload)
+		}
+		pb := pp.ToProto()
+		pb.PersistenceVersion = uint32(version.PersistenceVersion)
+		body, err := payloadpb.Encode(pb)
+		return body, "application/x-protobuf", err
+	}
+
+	wrapped := WrappedPayload{version.PersistenceVersion, payload}
+	if e.indent {
+		body, err := json.MarshalIndent(wrapped, "", " ")
+		return body, "application/json", err
+	}
+	body, err := json.Marshal(wrapped)
+	return body, "application/json", err
+}
@@ -0,0 +1,337 @@
+package outputsink
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	cfg "github.com/supragya/EtherScope/libs/config"
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/service"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/spf13/viper"
+)
+
+var (
+	FanoutCFGSection   = "outputSinkFanout"
+	FanoutCFGNecessity = "needed if `node.outputSinkType` == fanout"
+	FanoutCFGHeader    = cfg.SArr("fanout is an impl for OutputSink used",
+		"by indexer to send indexed information to several",
+		"output sinks at once. each child sink is configured",
+		"in its own section (e.g. `outputSinkRabbitMQ`)")
+	FanoutCFGFields = [...]cfg.Field{
+		{
+			Name:      "sinks",
+			Type:      "[]sink",
+			Necessity: "always needed",
+			Info: cfg.SArr("child sinks to send payloads to. each entry needs",
+				"`type` of sink (rabbitmq, kafka, postgres or file),",
+				"optionally `name` (defaults to type) and `section` to",
+				"configure the sink from (defaults to section of type,",
+				"e.g. `outputSinkKafka`), so a type can be listed more",
+				"than once with a section each,",
+				"`required`: node waits on required sinks before",
+				"moving to next block, failures of others are logged",
+				"and dropped, `maxAttempts` and `retryInterval` for",
+				"sending to the sink and optionally `includeTypes`,",
+				"`excludeTypes` (item types, e.g. `uniswapv2swap`) and",
+				"`processingTypes` (`user` and / or `pricing`, defaults",
+				"to `user`) to filter items sent to the sink"),
+			Default: "\n    - type: rabbitmq" +
+				"\n      required: true" +
+				"\n      maxAttempts: 3" +
+				"\n      retryInterval: 2s" +
+				"\n    - type: file" +
+				"\n      required: false" +
+				"\n      maxAttempts: 1" +
+				"\n      retryInterval: 1s" +
+				"\n      excludeTypes:\n      - erc20transfer",
+		},
+	}
+)
+
+// fanoutChildSpec is a single entry of `outputSinkFanout.sinks`
+type fanoutChildSpec struct {
+	Type            string        `mapstructure:"type"`
+	Name            string        `mapstructure:"name"`
+	Section         string        `mapstructure:"section"`
+	Required        bool          `mapstructure:"required"`
+	MaxAttempts     uint          `mapstructure:"maxAttempts"`
+	RetryInterval   time.Duration `mapstructure:"retryInterval"`
+	IncludeTypes    []string      `mapstructure:"includeTypes"`
+	ExcludeTypes    []string      `mapstructure:"excludeTypes"`
+	ProcessingTypes []string      `mapstructure:"processingTypes"`
+}
+
+// ItemFilter decides which items of a payload reach a child sink.
+// Rollbacks are never filtered out
+type ItemFilter struct {
+	IncludeTypes    map[string]bool // if non empty, only these item types
+	ExcludeTypes    map[string]bool
+	ProcessingTypes map[itypes.ProcessingType]bool
+}
+
+func (f ItemFilter) Keep(item interface{}) bool {
+	if _, ok := item.(*itypes.Rollback); ok {
+		return true
+	}
+	itemType := itypes.ItemType(item)
+	if len(f.IncludeTypes) > 0 && !f.IncludeTypes[itemType] {
+		return false
+	}
+	if f.ExcludeTypes[itemType] {
+		return false
+	}
+	if ptype, ok := itypes.ItemProcessingType(item); ok && !f.ProcessingTypes[ptype] {
+		return false
+	}
+	return true
+}
+
+func (f ItemFilter) apply(payload interface{}) interface{} {
+	if filterable, ok := payload.(FilterablePayload); ok {
+		return filterable.FilterItems(f.Keep)
+	}
+	return payload
+}
+
+// FanoutChild is a sink payloads are fanned out to
+type FanoutChild struct {
+	Name          string
+	Sink          OutputSink
+	Required      bool
+	MaxAttempts   uint
+	RetryInterval time.Duration
+	Filter        ItemFilter
+}
+
+type FanoutOutputSinkImpl struct {
+	service.BaseService
+
+	// Parameters
+	log      logger.Logger
+	children []*FanoutChild
+
+	// Delivery state of last payload. Node re-sends a payload on
+	// failure, children which already acked it are skipped then
+	pending interface{}
+	acked   []bool
+}
+
+// OnStart starts the fanout OutputSink along with all children. It
+// implements service.Service.
+func (n *FanoutOutputSinkImpl) OnStart(ctx context.Context) error {
+	var startErr error
+	for _, child := range n.children {
+		if err := child.Sink.Start(ctx); err != nil {
+			n.log.Warn("error starting child output sink",
+				"sink", child.Name,
+				"required", child.Required,
+				"error", err)
+			if child.Required && startErr == nil {
+				startErr = fmt.Errorf("OutputSinkStartupError: %s: %w", child.Name, err)
+			}
+		}
+	}
+	return startErr
+}
+
+// OnStop stops the fanout OutputSink. It implements service.Service
+func (n *FanoutOutputSinkImpl) OnStop() {
+	for _, child := range n.children {
+		if child.Sink.IsRunning() {
+			child.Sink.Stop()
+		}
+	}
+}
+
+// Send sends payload to all children in parallel, each retried
+// independently. Error is returned only if a required child fails
+func (n *FanoutOutputSinkImpl) Send(payload interface{}) error {
+	if !n.isResend(payload) {
+		n.pending = payload
+		n.acked = make([]bool, len(n.children))
+	}
+
+	errs := make([]error, len(n.children))
+	wg := sync.WaitGroup{}
+	for idx, child := range n.children {
+		if n.acked[idx] {
+			continue
+		}
+		wg.Add(1)
+		go func(idx int, child *FanoutChild) {
+			defer wg.Done()
+			errs[idx] = n.sendToChild(child, child.Filter.apply(payload))
+		}(idx, child)
+	}
+	wg.Wait()
+
+	var (
+		failed   []string
+		firstErr error
+	)
+	for idx, child := range n.children {
+		if n.acked[idx] {
+			continue
+		}
+		// Optional children are not retried on re-send
+		n.acked[idx] = errs[idx] == nil || !child.Required
+		if errs[idx] == nil {
+			continue
+		}
+		if !child.Required {
+			n.log.Warn("dropping payload for optional output sink",
+				"sink", child.Name,
+				"error", errs[idx])
+			continue
+		}
+		failed = append(failed, child.Name)
+		if firstErr == nil {
+			firstErr = errs[idx]
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("OutputSinkPublishError required sinks %s failed, Caused by: %w",
+			strings.Join(failed, ", "), firstErr)
+	}
+	return nil
+}
+
+func (n *FanoutOutputSinkImpl) sendToChild(child *FanoutChild, payload interface{}) error {
+	for attempt := uint(1); ; attempt++ {
+		err := child.Sink.Send(payload)
+		if err == nil {
+			return nil
+		}
+		if attempt >= child.MaxAttempts {
+			return err
+		}
+		n.log.Debug("retrying send onto child output sink",
+			"sink", child.Name,
+			"attempt", attempt,
+			"error", err)
+		time.Sleep(child.RetryInterval)
+	}
+}
+
+func (n *FanoutOutputSinkImpl) isResend(payload interface{}) bool {
+	if n.pending == nil || payload == nil {
+		return false
+	}
+	if !reflect.TypeOf(payload).Comparable() {
+		return false
+	}
+	return n.pending == payload
+}
+
+func parseProcessingTypes(ptypes []string) (map[itypes.ProcessingType]bool, error) {
+	if len(ptypes) == 0 {
+		ptypes = []string{itypes.UserRequested.ToString()}
+	}
+	parsed := make(map[itypes.ProcessingType]bool)
+	for _, ptype := range ptypes {
+		switch ptype {
+		case itypes.UserRequested.ToString():
+			parsed[itypes.UserRequested] = true
+		case itypes.PricingEngineRequest.ToString():
+			parsed[itypes.PricingEngineRequest] = true
+		default:
+			return nil, fmt.Errorf("unknown processing type: %s", ptype)
+		}
+	}
+	return parsed, nil
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+// NewFanoutOutputSink returns a fanout OutputSink sending to given
+// children
+func NewFanoutOutputSink(log logger.Logger, children []*FanoutChild) OutputSink {
+	for _, child := range children {
+		if child.MaxAttempts == 0 {
+			child.MaxAttempts = 1
+		}
+		if child.Filter.ProcessingTypes == nil {
+			child.Filter.ProcessingTypes = map[itypes.ProcessingType]bool{itypes.UserRequested: true}
+		}
+	}
+	outs := &FanoutOutputSinkImpl{
+		log:      log,
+		children: children,
+	}
+	outs.BaseService = *service.NewBaseService(log, "outputsink", outs)
+	return outs
+}
+
+func NewFanoutOutputSinkWithViperFields(log logger.Logger) (OutputSink, error) {
+	for _, mf := range FanoutCFGFields {
+		if err := cfg.EnsureFieldIntegrity(FanoutCFGSection, mf); err != nil {
+			return nil, err
+		}
+	}
+
+	var specs []fanoutChildSpec
+	if err := viper.UnmarshalKey(FanoutCFGSection+".sinks", &specs); err != nil {
+		return nil, fmt.Errorf("invalid %s.sinks: %w", FanoutCFGSection, err)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("%s.sinks should list at least one sink", FanoutCFGSection)
+	}
+
+	names, sections := make(map[string]bool), make(map[string]bool)
+	for idx := range specs {
+		spec := &specs[idx]
+		if spec.Type == "fanout" {
+			return nil, fmt.Errorf("invalid %s.sinks: fanout cannot be nested", FanoutCFGSection)
+		}
+		if spec.Name == "" {
+			spec.Name = spec.Type
+		}
+		if spec.Section == "" {
+			spec.Section = defaultSinkSections[spec.Type]
+		}
+		// Sinks sharing a section would send to same destination
+		if names[spec.Name] || sections[spec.Section] {
+			return nil, fmt.Errorf("invalid %s.sinks: %s listed more than once, set `name` and `section`",
+				FanoutCFGSection, spec.Name)
+		}
+		names[spec.Name], sections[spec.Section] = true, true
+	}
+
+	children := []*FanoutChild{}
+	for _, spec := range specs {
+		ptypes, err := parseProcessingTypes(spec.ProcessingTypes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s.sinks (%s): %w", FanoutCFGSection, spec.Name, err)
+		}
+		sink, err := newOutputSinkInSection(spec.Type, spec.Section, log.With("sink", spec.Name))
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, &FanoutChild{
+			Name:          spec.Name,
+			Sink:          sink,
+			Required:      spec.Required,
+			MaxAttempts:   spec.MaxAttempts,
+			RetryInterval: spec.RetryInterval,
+			Filter: ItemFilter{
+				IncludeTypes:    toSet(spec.IncludeTypes),
+				ExcludeTypes:    toSet(spec.ExcludeTypes),
+				ProcessingTypes: ptypes,
+			},
+		})
+	}
+
+	return NewFanoutOutputSink(log, children), nil
+}
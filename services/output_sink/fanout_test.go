@@ -0,0 +1,186 @@
+package outputsink_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/service"
+	outs "github.com/supragya/EtherScope/services/output_sink"
+	itypes "github.com/supragya/EtherScope/types"
+)
+
+// OutputSink stub recording payloads, failing first `failures` sends
+type testChildSink struct {
+	service.BaseService
+	mu       sync.Mutex
+	failures int
+	sends    int
+	received []interface{}
+}
+
+func (s *testChildSink) OnStart(ctx context.Context) error { return nil }
+func (s *testChildSink) OnStop()                           {}
+
+func (s *testChildSink) Send(payload interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sends++
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("send failed")
+	}
+	s.received = append(s.received, payload)
+	return nil
+}
+
+func newTestChildSink(failures int) *testChildSink {
+	sink := &testChildSink{failures: failures}
+	sink.BaseService = *service.NewBaseService(logger.NewNopLogger(), "testsink", sink)
+	return sink
+}
+
+// Payload stub supporting item filtering
+type testFanoutPayload struct {
+	items []interface{}
+}
+
+func (p *testFanoutPayload) FilterItems(keep func(item interface{}) bool) interface{} {
+	filtered := &testFanoutPayload{}
+	for _, item := range p.items {
+		if keep(item) {
+			filtered.items = append(filtered.items, item)
+		}
+	}
+	return filtered
+}
+
+var _ = Describe("Fanout", func() {
+	var testMessage = &testFanoutPayload{
+		items: []interface{}{
+			&itypes.Swap{Type: "uniswapv2swap", ProcessingType: itypes.UserRequested},
+			&itypes.Transfer{Type: "erc20transfer", ProcessingType: itypes.UserRequested},
+			&itypes.Mint{Type: "uniswapv2mint", ProcessingType: itypes.PricingEngineRequest},
+			&itypes.Rollback{Type: "rollback"},
+		},
+	}
+
+	itemTypes := func(payload interface{}) []string {
+		types := []string{}
+		for _, item := range payload.(*testFanoutPayload).items {
+			types = append(types, itypes.ItemType(item))
+		}
+		return types
+	}
+
+	It("should filter items per child", func() {
+		all := newTestChildSink(0)
+		swapsOnly := newTestChildSink(0)
+		withPricing := newTestChildSink(0)
+		sink := outs.NewFanoutOutputSink(logger.NewNopLogger(), []*outs.FanoutChild{
+			{Name: "all", Sink: all, Required: true},
+			{Name: "swaps", Sink: swapsOnly, Filter: outs.ItemFilter{
+				IncludeTypes: map[string]bool{"uniswapv2swap": true},
+			}},
+			{Name: "pricing", Sink: withPricing, Filter: outs.ItemFilter{
+				ExcludeTypes: map[string]bool{"erc20transfer": true},
+				ProcessingTypes: map[itypes.ProcessingType]bool{
+					itypes.UserRequested:        true,
+					itypes.PricingEngineRequest: true,
+				},
+			}},
+		})
+		Expect(sink.Start(context.Background())).To(BeNil())
+		Expect(sink.Send(testMessage)).To(BeNil())
+
+		Expect(itemTypes(all.received[0])).To(Equal([]string{"uniswapv2swap", "erc20transfer", "rollback"}))
+		Expect(itemTypes(swapsOnly.received[0])).To(Equal([]string{"uniswapv2swap", "rollback"}))
+		Expect(itemTypes(withPricing.received[0])).To(Equal([]string{"uniswapv2swap", "uniswapv2mint", "rollback"}))
+	})
+
+	It("should retry children independently", func() {
+		flaky := newTestChildSink(2)
+		healthy := newTestChildSink(0)
+		sink := outs.NewFanoutOutputSink(logger.NewNopLogger(), []*outs.FanoutChild{
+			{Name: "flaky", Sink: flaky, Required: true, MaxAttempts: 3},
+			{Name: "healthy", Sink: healthy, Required: true, MaxAttempts: 3},
+		})
+		Expect(sink.Send(testMessage)).To(BeNil())
+		Expect(flaky.sends).To(Equal(3))
+		Expect(healthy.sends).To(Equal(1))
+	})
+
+	It("should ignore failing optional children", func() {
+		broken := newTestChildSink(10)
+		sink := outs.NewFanoutOutputSink(logger.NewNopLogger(), []*outs.FanoutChild{
+			{Name: "broken", Sink: broken, Required: false, MaxAttempts: 2},
+		})
+		Expect(sink.Send(testMessage)).To(BeNil())
+		Expect(broken.sends).To(Equal(2))
+	})
+
+	It("should fail on required child and resend only to it", func() {
+		broken := newTestChildSink(1)
+		healthy := newTestChildSink(0)
+		sink := outs.NewFanoutOutputSink(logger.NewNopLogger(), []*outs.FanoutChild{
+			{Name: "broken", Sink: broken, Required: true},
+			{Name: "healthy", Sink: healthy, Required: true},
+		})
+		Expect(sink.Send(testMessage)).To(MatchError(ContainSubstring("OutputSinkPublishError")))
+		Expect(sink.Send(testMessage)).To(BeNil())
+		Expect(broken.received).To(HaveLen(1))
+		Expect(healthy.received).To(HaveLen(1))
+
+		// A new payload goes to every child again
+		Expect(sink.Send(&testFanoutPayload{})).To(BeNil())
+		Expect(healthy.received).To(HaveLen(2))
+	})
+
+	It("should send to several sinks of a type configured from own sections", func() {
+		sinks := []interface{}{}
+		directories := []string{}
+		for _, name := range []string{"archiveA", "archiveB"} {
+			directory, err := os.MkdirTemp("", "escope_fanout_sink")
+			Expect(err).To(BeNil())
+			DeferCleanup(os.RemoveAll, directory)
+			directories = append(directories, directory)
+
+			viper.GetViper().Set(name+".directory", directory)
+			viper.GetViper().Set(name+".blocksPerFile", 10)
+			sinks = append(sinks, map[string]interface{}{
+				"type":     "file",
+				"name":     name,
+				"section":  name,
+				"required": true,
+			})
+		}
+		viper.GetViper().Set(outs.FanoutCFGSection+".sinks", sinks)
+
+		sink, err := outs.NewFanoutOutputSinkWithViperFields(logger.NewNopLogger())
+		Expect(err).To(BeNil())
+		Expect(sink.Start(context.Background())).To(BeNil())
+		DeferCleanup(sink.Stop)
+		Expect(sink.Send(blockAt(8))).To(BeNil())
+
+		for _, directory := range directories {
+			_, err := os.Stat(filepath.Join(directory, "testnet", outs.FileManifestName))
+			Expect(err).To(BeNil())
+		}
+	})
+
+	It("should reject sinks sharing a section", func() {
+		viper.GetViper().Set(outs.FanoutCFGSection+".sinks", []interface{}{
+			map[string]interface{}{"type": "file", "name": "archiveA"},
+			map[string]interface{}{"type": "file", "name": "archiveB"},
+		})
+		_, err := outs.NewFanoutOutputSinkWithViperFields(logger.NewNopLogger())
+		Expect(err).To(MatchError(ContainSubstring("listed more than once")))
+	})
+})
@@ -0,0 +1,327 @@
+package outputsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	cfg "github.com/supragya/EtherScope/libs/config"
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/service"
+	itypes "github.com/supragya/EtherScope/types"
+	"github.com/supragya/EtherScope/version"
+	"github.com/spf13/viper"
+)
+
+var (
+	FileCFGSection   = "outputSinkFile"
+	FileCFGNecessity = "needed if `node.outputSinkType` == file"
+	FileCFGHeader    = cfg.SArr("file is an impl for OutputSink used",
+		"by indexer to write indexed information to local",
+		"NDJSON files partitioned by network and block range.",
+		"each network directory carries a manifest.json",
+		"listing heights covered by each file")
+	FileCFGFields = [...]cfg.Field{
+		{
+			Name:      "directory",
+			Type:      "string",
+			Necessity: "always needed",
+			Info:      cfg.SArr("directory to write output files into"),
+			Default:   "escope_output",
+		},
+		{
+			Name:      "blocksPerFile",
+			Type:      "uint64",
+			Necessity: "always needed",
+			Info: cfg.SArr("width of block range covered by a single file.",
+				"file for height h covers [h - h % blocksPerFile,",
+				"h - h % blocksPerFile + blocksPerFile)"),
+			Default: 10000,
+		},
+	}
+)
+
+const FileManifestName = "manifest.json"
+
+// FileManifest lists files of a network directory along with
+// heights whose payloads have been written into them
+type FileManifest struct {
+	PersistenceVersion uint8
+	Network            string
+	BlocksPerFile      uint64
+	Files              []*FileManifestEntry
+}
+
+type FileManifestEntry struct {
+	File       string
+	FromHeight uint64
+	ToHeight   uint64
+	Payloads   uint64
+	Heights    []HeightRange // covered heights, sorted and non overlapping
+}
+
+// HeightRange is an inclusive range of block heights
+type HeightRange struct {
+	From uint64
+	To   uint64
+}
+
+type FileOutputSinkImpl struct {
+	service.BaseService
+
+	// Parameters
+	log           logger.Logger
+	directory     string
+	blocksPerFile uint64
+
+	// State
+	manifests map[string]*FileManifest // manifest per network
+	file      *os.File                 // currently open output file
+	fileName  string                   // path of currently open output file
+}
+
+// OnStart starts the file OutputSink. It implements service.Service.
+func (n *FileOutputSinkImpl) OnStart(ctx context.Context) error {
+	if err := os.MkdirAll(n.directory, 0755); err != nil {
+		return fmt.Errorf("OutputSinkStartupError: %w", err)
+	}
+	return nil
+}
+
+// OnStop stops the file OutputSink. It implements service.Service
+func (n *FileOutputSinkImpl) OnStop() {
+	if n.file != nil {
+		n.file.Close()
+		n.file = nil
+	}
+}
+
+func (n *FileOutputSinkImpl) Send(payload interface{}) error {
+	keyed, ok := payload.(HeightKeyedPayload)
+	if !ok {
+		return fmt.Errorf("OutputSinkUnsupportedPayload: %T", payload)
+	}
+	height := keyed.Height()
+
+	network := "unknown"
+	if block, ok := payload.(BlockPayload); ok {
+		_, network = block.Source()
+	}
+
+	manifest, err := n.manifest(network)
+	if err != nil {
+		return fmt.Errorf("OutputSinkUnavailable Caused By: %w", err)
+	}
+	entry := n.entryFor(manifest, height)
+
+	if err := n.write(filepath.Join(n.directory, network, entry.File), payload); err != nil {
+		n.log.Warn("Error writing payload to file: " + fmt.Sprint(err))
+		return fmt.Errorf("OutputSinkPublishError Caused by: %w", err)
+	}
+
+	entry.Payloads++
+	if rollback := rollbackOf(payload); rollback != nil {
+		// Heights above common ancestor are to be re-sent
+		for _, e := range manifest.Files {
+			e.Heights = truncateHeightsAbove(e.Heights, rollback.CommonAncestor)
+		}
+	} else {
+		entry.Heights = addHeight(entry.Heights, height)
+	}
+
+	if err := n.writeManifest(manifest); err != nil {
+		n.log.Warn("Error writing manifest: " + fmt.Sprint(err))
+		return fmt.Errorf("OutputSinkPublishError Caused by: %w", err)
+	}
+
+	n.log.Debug("wrote payload onto outputsink file",
+		"height", height,
+		"segment", entry.File)
+	return nil
+}
+
+// write appends payload as a single NDJSON line, switching over
+// to a new file when payload falls in a different partition
+func (n *FileOutputSinkImpl) write(fileName string, payload interface{}) error {
+	if n.fileName != fileName {
+		if n.file != nil {
+			n.file.Close()
+			n.file = nil
+		}
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(fileName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		n.file, n.fileName = file, fileName
+	}
+
+	line, err := json.Marshal(WrappedPayload{version.PersistenceVersion, payload})
+	if err != nil {
+		return err
+	}
+	if _, err := n.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	// Manifest should never list heights not on disk
+	return n.file.Sync()
+}
+
+func (n *FileOutputSinkImpl) manifest(network string) (*FileManifest, error) {
+	if manifest, ok := n.manifests[network]; ok {
+		return manifest, nil
+	}
+
+	manifest := &FileManifest{
+		PersistenceVersion: version.PersistenceVersion,
+		Network:            network,
+		BlocksPerFile:      n.blocksPerFile,
+		Files:              []*FileManifestEntry{},
+	}
+	data, err := os.ReadFile(filepath.Join(n.directory, network, FileManifestName))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, manifest); err != nil {
+			return nil, err
+		}
+		if manifest.BlocksPerFile != n.blocksPerFile {
+			return nil, fmt.Errorf("manifest for %s has blocksPerFile %d, configured %d",
+				network, manifest.BlocksPerFile, n.blocksPerFile)
+		}
+	}
+
+	n.manifests[network] = manifest
+	return manifest, nil
+}
+
+// entryFor returns manifest entry for file covering height, adding
+// one if not already present
+func (n *FileOutputSinkImpl) entryFor(manifest *FileManifest, height uint64) *FileManifestEntry {
+	from := height - height%n.blocksPerFile
+	to := from + n.blocksPerFile - 1
+
+	idx := sort.Search(len(manifest.Files), func(i int) bool {
+		return manifest.Files[i].FromHeight >= from
+	})
+	if idx < len(manifest.Files) && manifest.Files[idx].FromHeight == from {
+		return manifest.Files[idx]
+	}
+
+	entry := &FileManifestEntry{
+		File:       fmt.Sprintf("%012d-%012d.ndjson", from, to),
+		FromHeight: from,
+		ToHeight:   to,
+		Heights:    []HeightRange{},
+	}
+	manifest.Files = append(manifest.Files, nil)
+	copy(manifest.Files[idx+1:], manifest.Files[idx:])
+	manifest.Files[idx] = entry
+	return entry
+}
+
+// writeManifest replaces manifest on disk atomically
+func (n *FileOutputSinkImpl) writeManifest(manifest *FileManifest) error {
+	data, err := json.MarshalIndent(manifest, "", " ")
+	if err != nil {
+		return err
+	}
+	fileName := filepath.Join(n.directory, manifest.Network, FileManifestName)
+	if err := os.WriteFile(fileName+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(fileName+".tmp", fileName)
+}
+
+func rollbackOf(payload interface{}) *itypes.Rollback {
+	block, ok := payload.(BlockPayload)
+	if !ok || block.Synopsis() != nil {
+		return nil
+	}
+	for _, item := range block.BlockItems() {
+		if rollback, ok := item.(*itypes.Rollback); ok {
+			return rollback
+		}
+	}
+	return nil
+}
+
+// addHeight adds height to sorted non overlapping ranges
+func addHeight(ranges []HeightRange, height uint64) []HeightRange {
+	idx := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].To+1 >= height
+	})
+	if idx < len(ranges) && ranges[idx].From <= height && height <= ranges[idx].To {
+		return ranges
+	}
+
+	joinsPrev := idx < len(ranges) && ranges[idx].To+1 == height
+	if joinsPrev {
+		ranges[idx].To = height
+		// Close the gap to next range if any
+		if idx+1 < len(ranges) && ranges[idx+1].From == height+1 {
+			ranges[idx].To = ranges[idx+1].To
+			ranges = append(ranges[:idx+1], ranges[idx+2:]...)
+		}
+		return ranges
+	}
+	if idx < len(ranges) && ranges[idx].From == height+1 {
+		ranges[idx].From = height
+		return ranges
+	}
+
+	ranges = append(ranges, HeightRange{})
+	copy(ranges[idx+1:], ranges[idx:])
+	ranges[idx] = HeightRange{height, height}
+	return ranges
+}
+
+// truncateHeightsAbove drops all heights above given height
+func truncateHeightsAbove(ranges []HeightRange, height uint64) []HeightRange {
+	truncated := []HeightRange{}
+	for _, r := range ranges {
+		if r.From > height {
+			break
+		}
+		if r.To > height {
+			r.To = height
+		}
+		truncated = append(truncated, r)
+	}
+	return truncated
+}
+
+func NewFileOutputSinkWithViperFields(log logger.Logger) (OutputSink, error) {
+	return newFileOutputSinkInSection(log, FileCFGSection)
+}
+
+// newFileOutputSinkInSection returns a file OutputSink configured
+// from given config section
+func newFileOutputSinkInSection(log logger.Logger, section string) (OutputSink, error) {
+	for _, mf := range FileCFGFields {
+		if err := cfg.EnsureFieldIntegrity(section, mf); err != nil {
+			return nil, err
+		}
+	}
+
+	blocksPerFile := viper.GetUint64(section + ".blocksPerFile")
+	if blocksPerFile == 0 {
+		return nil, fmt.Errorf("%s.blocksPerFile should be non zero", section)
+	}
+
+	outs := &FileOutputSinkImpl{
+		log:           log,
+		directory:     viper.GetString(section + ".directory"),
+		blocksPerFile: blocksPerFile,
+		manifests:     make(map[string]*FileManifest),
+	}
+	outs.BaseService = *service.NewBaseService(log, "outputsink", outs)
+	return outs, nil
+}
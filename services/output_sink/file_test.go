@@ -0,0 +1,148 @@
+package outputsink_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	outs "github.com/supragya/EtherScope/services/output_sink"
+	itypes "github.com/supragya/EtherScope/types"
+)
+
+// Payload stub keyed by height
+type testFilePayload struct {
+	testBlockPayload
+	Height_ uint64
+}
+
+func (p testFilePayload) Height() uint64 {
+	return p.Height_
+}
+
+func blockAt(height uint64) testFilePayload {
+	return testFilePayload{
+		testBlockPayload: testBlockPayload{synopsis: &itypes.BlockSynopsis{Height: height}},
+		Height_:          height,
+	}
+}
+
+var _ = Describe("File", func() {
+	var directory string
+	var testOutputSinkFile outs.OutputSink
+
+	setupSink := func() {
+		var err error
+		testOutputSinkFile, err = outs.NewFileOutputSinkWithViperFields(logger.NewNopLogger())
+		Expect(err).To(BeNil())
+		Expect(testOutputSinkFile.Start(context.Background())).To(BeNil())
+	}
+
+	readManifest := func() outs.FileManifest {
+		data, err := os.ReadFile(filepath.Join(directory, "testnet", outs.FileManifestName))
+		Expect(err).To(BeNil())
+		manifest := outs.FileManifest{}
+		Expect(json.Unmarshal(data, &manifest)).To(BeNil())
+		return manifest
+	}
+
+	countLines := func(file string) int {
+		f, err := os.Open(filepath.Join(directory, "testnet", file))
+		Expect(err).To(BeNil())
+		defer f.Close()
+		lines := 0
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := outs.WrappedPayload{}
+			Expect(json.Unmarshal(scanner.Bytes(), &line)).To(BeNil())
+			lines++
+		}
+		return lines
+	}
+
+	BeforeEach(func() {
+		var err error
+		directory, err = os.MkdirTemp("", "escope_file_sink")
+		Expect(err).To(BeNil())
+		DeferCleanup(os.RemoveAll, directory)
+
+		viper.GetViper().Set(outs.FileCFGSection+".directory", directory)
+		viper.GetViper().Set(outs.FileCFGSection+".blocksPerFile", 10)
+	})
+
+	AfterEach(func() {
+		if testOutputSinkFile != nil && testOutputSinkFile.IsRunning() {
+			testOutputSinkFile.Stop()
+		}
+	})
+
+	It("should reject zero blocksPerFile", func() {
+		viper.GetViper().Set(outs.FileCFGSection+".blocksPerFile", 0)
+		_, err := outs.NewFileOutputSinkWithViperFields(logger.NewNopLogger())
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should reject payloads without height", func() {
+		setupSink()
+		Expect(testOutputSinkFile.Send("payload")).To(MatchError(
+			ContainSubstring("OutputSinkUnsupportedPayload"),
+		))
+	})
+
+	It("should partition payloads by block range and list heights in manifest", func() {
+		setupSink()
+		for _, h := range []uint64{8, 9, 10, 11, 13} {
+			Expect(testOutputSinkFile.Send(blockAt(h))).To(BeNil())
+		}
+
+		manifest := readManifest()
+		Expect(manifest.Network).To(Equal("testnet"))
+		Expect(manifest.Files).To(HaveLen(2))
+		Expect(manifest.Files[0].File).To(Equal("000000000000-000000000009.ndjson"))
+		Expect(manifest.Files[0].Heights).To(Equal([]outs.HeightRange{{From: 8, To: 9}}))
+		Expect(manifest.Files[1].File).To(Equal("000000000010-000000000019.ndjson"))
+		Expect(manifest.Files[1].Heights).To(Equal([]outs.HeightRange{{From: 10, To: 11}, {From: 13, To: 13}}))
+
+		Expect(countLines(manifest.Files[0].File)).To(Equal(2))
+		Expect(countLines(manifest.Files[1].File)).To(Equal(3))
+	})
+
+	It("should drop heights above common ancestor on rollback", func() {
+		setupSink()
+		for _, h := range []uint64{8, 9, 10, 11} {
+			Expect(testOutputSinkFile.Send(blockAt(h))).To(BeNil())
+		}
+		Expect(testOutputSinkFile.Send(testFilePayload{
+			testBlockPayload: testBlockPayload{items: []interface{}{
+				&itypes.Rollback{Type: "rollback", CommonAncestor: 8},
+			}},
+			Height_: 8,
+		})).To(BeNil())
+
+		manifest := readManifest()
+		Expect(manifest.Files[0].Heights).To(Equal([]outs.HeightRange{{From: 8, To: 8}}))
+		Expect(manifest.Files[1].Heights).To(BeEmpty())
+		Expect(countLines(manifest.Files[0].File)).To(Equal(3))
+	})
+
+	It("should resume from manifest on disk", func() {
+		setupSink()
+		Expect(testOutputSinkFile.Send(blockAt(3))).To(BeNil())
+		testOutputSinkFile.Stop()
+
+		setupSink()
+		Expect(testOutputSinkFile.Send(blockAt(4))).To(BeNil())
+
+		manifest := readManifest()
+		Expect(manifest.Files).To(HaveLen(1))
+		Expect(manifest.Files[0].Heights).To(Equal([]outs.HeightRange{{From: 3, To: 4}}))
+		Expect(manifest.Files[0].Payloads).To(Equal(uint64(2)))
+		Expect(countLines(manifest.Files[0].File)).To(Equal(2))
+	})
+})
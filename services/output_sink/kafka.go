@@ -0,0 +1,255 @@
+package outputsink
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	cfg "github.com/supragya/EtherScope/libs/config"
+	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/service"
+	"github.com/Shopify/sarama"
+	"github.com/spf13/viper"
+)
+
+var (
+	KafkaCFGSection   = "outputSinkKafka"
+	KafkaCFGNecessity = "needed if `node.outputSinkType` == kafka"
+	KafkaCFGHeader    = cfg.SArr("kafka is an impl for OutputSink used",
+		"by indexer to send indexed information to the",
+		"backend. uses an idempotent producer and keys",
+		"messages by block height")
+	KafkaCFGFields = [...]cfg.Field{
+		{
+			Name:      "brokers",
+			Type:      "[]string",
+			Necessity: "always needed",
+			Info:      cfg.SArr("kafka bootstrap brokers as host:port"),
+			Default:   "\n    - 127.0.0.1:9092",
+		},
+		{
+			Name:      "topic",
+			Type:      "string",
+			Necessity: "always needed",
+			Info: cfg.SArr("topic to output processed info onto. with",
+				"`topicPerEventType` this topic receives block",
+				"synopsis only"),
+			Default: "escope_processed",
+		},
+		{
+			Name:      "topicPerEventType",
+			Type:      "bool",
+			Necessity: "always needed",
+			Info: cfg.SArr("if set to true items are sent to `<topic>.<type>`",
+				"(e.g. `escope_processed.uniswapv2swap`), one",
+				"message per event type per block"),
+			Default: false,
+		},
+		{
+			Name:      "clientID",
+			Type:      "string",
+			Necessity: "always needed",
+			Info:      cfg.SArr("client id presented to kafka brokers"),
+			Default:   "escope",
+		},
+		{
+			Name:      "encoding",
+			Type:      "string",
+			Necessity: "always needed",
+			Info: cfg.SArr("payload encoding. Use `json` or `protobuf`",
+				"(schema in libs/payloadpb)"),
+			Default: "json",
+		},
+		{
+			Name:      "kafkaVersion",
+			Type:      "string",
+			Necessity: "always needed",
+			Info: cfg.SArr("kafka protocol version of brokers. idempotent",
+				"producer needs at least 0.11.0.0"),
+			Default: "2.1.0",
+		},
+	}
+)
+
+type KafkaOutputSinkImpl struct {
+	service.BaseService
+
+	// Parameters
+	log               logger.Logger
+	brokers           []string
+	topic             string
+	topicPerEventType bool
+	encoder           payloadEncoder
+	config            *sarama.Config
+	disconnectTime    time.Time
+
+	// Connections
+	producer sarama.SyncProducer
+}
+
+// OnStart starts the kafka OutputSink. It implements service.Service.
+func (n *KafkaOutputSinkImpl) OnStart(ctx context.Context) error {
+	if err := n.connect(); err != nil {
+		n.log.Info(fmt.Sprintf("Unable to connect to Kafka: %s", err))
+		return fmt.Errorf("OutputSinkStartupError: %w", err)
+	}
+	return nil
+}
+
+// OnStop stops the kafka OutputSink. It implements service.Service
+func (n *KafkaOutputSinkImpl) OnStop() {
+	if n.producer != nil {
+		n.producer.Close()
+	}
+}
+
+func (n *KafkaOutputSinkImpl) connect() error {
+	producer, err := sarama.NewSyncProducer(n.brokers, n.config)
+	if err != nil {
+		if n.disconnectTime.IsZero() {
+			n.disconnectTime = time.Now()
+		}
+		return fmt.Errorf("OutputSinkDialError caused by: %w", err)
+	}
+
+	if n.disconnectTime.IsZero() {
+		n.log.Info("Kafka connected")
+	} else {
+		n.log.Info(fmt.Sprintf("Kafka reconnected. Downtime: %dms",
+			time.Since(n.disconnectTime).Milliseconds()))
+		n.disconnectTime = time.Time{}
+	}
+
+	n.producer = producer
+	return nil
+}
+
+func (n *KafkaOutputSinkImpl) Send(payload interface{}) error {
+	if n.producer == nil {
+		if err := n.connect(); err != nil {
+			return fmt.Errorf("OutputSinkUnavailable Caused By: %w", err)
+		}
+	}
+
+	msgs, err := n.messages(payload)
+	if err != nil {
+		return err
+	}
+
+	if err := n.producer.SendMessages(msgs); err != nil {
+		n.log.Warn("Error publishing message to Kafka: " + fmt.Sprint(err))
+		return fmt.Errorf("OutputSinkPublishError Caused by: %w", err)
+	}
+
+	n.log.Debug("sent message onto outputsink kafka",
+		"msgs", len(msgs),
+		"topic", n.topic)
+
+	return nil
+}
+
+// messages builds kafka messages for payload. Messages are keyed by
+// block height so that a block always lands on the same partition
+func (n *KafkaOutputSinkImpl) messages(payload interface{}) ([]*sarama.ProducerMessage, error) {
+	var key sarama.Encoder
+	if keyed, ok := payload.(HeightKeyedPayload); ok {
+		key = sarama.StringEncoder(strconv.FormatUint(keyed.Height(), 10))
+	}
+
+	splittable, ok := payload.(SplittablePayload)
+	if !n.topicPerEventType || !ok {
+		msg, err := n.message(n.topic, key, payload)
+		if err != nil {
+			return nil, err
+		}
+		return []*sarama.ProducerMessage{msg}, nil
+	}
+
+	base, parts := splittable.SplitByEventType()
+	msg, err := n.message(n.topic, key, base)
+	if err != nil {
+		return nil, err
+	}
+	msgs := []*sarama.ProducerMessage{msg}
+
+	eventTypes := make([]string, 0, len(parts))
+	for eventType := range parts {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	for _, eventType := range eventTypes {
+		msg, err := n.message(n.topic+"."+eventType, key, parts[eventType])
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+func (n *KafkaOutputSinkImpl) message(topic string,
+	key sarama.Encoder,
+	payload interface{}) (*sarama.ProducerMessage, error) {
+	item, contentType, err := n.encoder.encode(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   key,
+		Value: sarama.ByteEncoder(item),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("content-type"), Value: []byte(contentType)},
+		},
+		Timestamp: time.Now(),
+	}, nil
+}
+
+func NewKafkaOutputSinkWithViperFields(log logger.Logger) (OutputSink, error) {
+	return newKafkaOutputSinkInSection(log, KafkaCFGSection)
+}
+
+// newKafkaOutputSinkInSection returns a kafka OutputSink configured
+// from given config section
+func newKafkaOutputSinkInSection(log logger.Logger, section string) (OutputSink, error) {
+	for _, mf := range KafkaCFGFields {
+		if err := cfg.EnsureFieldIntegrity(section, mf); err != nil {
+			return nil, err
+		}
+	}
+
+	kafkaVersion, err := sarama.ParseKafkaVersion(viper.GetString(section + ".kafkaVersion"))
+	if err != nil {
+		return nil, err
+	}
+	if !kafkaVersion.IsAtLeast(sarama.V0_11_0_0) {
+		return nil, fmt.Errorf("kafka version %s does not support idempotent producers", kafkaVersion)
+	}
+
+	encoder, err := newPayloadEncoder(viper.GetString(section+".encoding"), false)
+	if err != nil {
+		return nil, err
+	}
+
+	config := sarama.NewConfig()
+	config.ClientID = viper.GetString(section + ".clientID")
+	config.Version = kafkaVersion
+	// Idempotent producer: no duplicates or reordering on retries
+	config.Producer.Idempotent = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+	config.Net.MaxOpenRequests = 1
+
+	outs := &KafkaOutputSinkImpl{
+		log:               log,
+		brokers:           viper.GetStringSlice(section + ".brokers"),
+		topic:             viper.GetString(section + ".topic"),
+		topicPerEventType: viper.GetBool(section + ".topicPerEventType"),
+		encoder:           encoder,
+		config:            config,
+	}
+	outs.BaseService = *service.NewBaseService(log, "outputsink", outs)
+	return outs, nil
+}
@@ -0,0 +1,180 @@
+package outputsink_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/Shopify/sarama"
+	"github.com/spf13/viper"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	outs "github.com/supragya/EtherScope/services/output_sink"
+)
+
+// Payload stub splittable by event type
+type testKafkaPayload struct {
+	Height_ uint64
+	Items   map[string]interface{}
+}
+
+func (p testKafkaPayload) Height() uint64 {
+	return p.Height_
+}
+
+func (p testKafkaPayload) SplitByEventType() (interface{}, map[string]interface{}) {
+	return testKafkaPayload{Height_: p.Height_}, p.Items
+}
+
+var _ = Describe("Kafka", func() {
+	var broker *sarama.MockBroker
+	var brokerClosed bool
+	var produceResponse *sarama.MockProduceResponse
+	var testOutputSinkKafka outs.OutputSink
+	var testMessage = testKafkaPayload{
+		Height_: 100,
+		Items: map[string]interface{}{
+			"uniswapv2swap": "A",
+			"erc20transfer": "B",
+		},
+	}
+
+	setupSink := func(topicPerEventType bool) {
+		viper.GetViper().Set(outs.KafkaCFGSection+".brokers", []interface{}{broker.Addr()})
+		viper.GetViper().Set(outs.KafkaCFGSection+".topic", "testtopic")
+		viper.GetViper().Set(outs.KafkaCFGSection+".topicPerEventType", topicPerEventType)
+		viper.GetViper().Set(outs.KafkaCFGSection+".clientID", "testclient")
+		viper.GetViper().Set(outs.KafkaCFGSection+".kafkaVersion", "2.1.0")
+		viper.GetViper().Set(outs.KafkaCFGSection+".encoding", "json")
+
+		var err error
+		testOutputSinkKafka, err = outs.NewKafkaOutputSinkWithViperFields(logger.NewNopLogger())
+		Expect(err).To(BeNil())
+	}
+
+	produceRequests := func() int {
+		count := 0
+		for _, rr := range broker.History() {
+			if _, ok := rr.Request.(*sarama.ProduceRequest); ok {
+				count++
+			}
+		}
+		return count
+	}
+
+	BeforeEach(func() {
+		broker = sarama.NewMockBroker(GinkgoT(), 1)
+		brokerClosed = false
+		// Idempotent producer speaks produce v3 (record batches)
+		produceResponse = sarama.NewMockProduceResponse(GinkgoT()).SetVersion(3)
+		broker.SetHandlerByMap(map[string]sarama.MockResponse{
+			"MetadataRequest": sarama.NewMockMetadataResponse(GinkgoT()).
+				SetBroker(broker.Addr(), broker.BrokerID()).
+				SetController(broker.BrokerID()).
+				SetLeader("testtopic", 0, broker.BrokerID()).
+				SetLeader("testtopic.uniswapv2swap", 0, broker.BrokerID()).
+				SetLeader("testtopic.erc20transfer", 0, broker.BrokerID()),
+			"InitProducerIDRequest": sarama.NewMockWrapper(&sarama.InitProducerIDResponse{
+				ProducerID:    1000,
+				ProducerEpoch: 1,
+			}),
+			"ProduceRequest": produceResponse,
+		})
+	})
+
+	AfterEach(func() {
+		if testOutputSinkKafka != nil && testOutputSinkKafka.IsRunning() {
+			testOutputSinkKafka.Stop()
+		}
+		if !brokerClosed {
+			broker.Close()
+		}
+	})
+
+	Context("Config", func() {
+		It("should reject kafka versions without idempotent producers", func() {
+			setupSink(false)
+			viper.GetViper().Set(outs.KafkaCFGSection+".kafkaVersion", "0.10.2.0")
+			_, err := outs.NewKafkaOutputSinkWithViperFields(logger.NewNopLogger())
+			Expect(err).To(MatchError(ContainSubstring("idempotent")))
+		})
+	})
+
+	Context("Encoding", func() {
+		It("should reject unknown encodings", func() {
+			setupSink(false)
+			viper.GetViper().Set(outs.KafkaCFGSection+".encoding", "xml")
+			_, err := outs.NewKafkaOutputSinkWithViperFields(logger.NewNopLogger())
+			Expect(err).To(MatchError(ContainSubstring("unsupported encoding")))
+		})
+
+		It("should fail sending payloads without protobuf representation", func() {
+			setupSink(false)
+			viper.GetViper().Set(outs.KafkaCFGSection+".encoding", "protobuf")
+			testOutputSinkKafka, _ = outs.NewKafkaOutputSinkWithViperFields(logger.NewNopLogger())
+			Expect(testOutputSinkKafka.Start(context.Background())).To(BeNil())
+			Expect(testOutputSinkKafka.Send(testMessage)).To(MatchError(
+				ContainSubstring("no protobuf encoding"),
+			))
+		})
+	})
+
+	Context("Start", func() {
+		It("should return err when brokers are unreachable", func() {
+			setupSink(false)
+			broker.Close()
+			brokerClosed = true
+			Expect(testOutputSinkKafka.Start(context.Background())).To(MatchError(
+				ContainSubstring("OutputSinkDialError"),
+			))
+		})
+
+		It("should return nil error following successful connection", func() {
+			setupSink(false)
+			Expect(testOutputSinkKafka.Start(context.Background())).To(BeNil())
+		})
+	})
+
+	Context("Send", func() {
+		It("should send single message onto topic", func() {
+			setupSink(false)
+			Expect(testOutputSinkKafka.Start(context.Background())).To(BeNil())
+			Expect(testOutputSinkKafka.Send(testMessage)).To(BeNil())
+			Expect(produceRequests()).To(BeNumerically(">=", 1))
+		})
+
+		It("should not send onto topic per event type unless configured", func() {
+			setupSink(false)
+			produceResponse.SetError("testtopic.erc20transfer", 0, sarama.ErrMessageSizeTooLarge)
+			Expect(testOutputSinkKafka.Start(context.Background())).To(BeNil())
+			Expect(testOutputSinkKafka.Send(testMessage)).To(BeNil())
+		})
+
+		It("should send onto topic per event type", func() {
+			setupSink(true)
+			produceResponse.SetError("testtopic.erc20transfer", 0, sarama.ErrMessageSizeTooLarge)
+			Expect(testOutputSinkKafka.Start(context.Background())).To(BeNil())
+			Expect(testOutputSinkKafka.Send(testMessage)).To(MatchError(
+				ContainSubstring("OutputSinkPublishError"),
+			))
+		})
+
+		It("should return OutputSinkPublishError err when broker rejects", func() {
+			setupSink(false)
+			produceResponse.SetError("testtopic", 0, sarama.ErrMessageSizeTooLarge)
+			Expect(testOutputSinkKafka.Start(context.Background())).To(BeNil())
+			Expect(testOutputSinkKafka.Send(testMessage)).To(MatchError(
+				ContainSubstring("OutputSinkPublishError"),
+			))
+		})
+
+		It("should return OutputSinkUnavailable err when never connected", func() {
+			setupSink(false)
+			broker.Close()
+			brokerClosed = true
+			Expect(testOutputSinkKafka.Send(testMessage)).To(MatchError(
+				ContainSubstring("OutputSinkUnavailable"),
+			))
+		})
+	})
+})
@@ -1,7 +1,12 @@
 package outputsink
 
 import (
+	"fmt"
+
+	iamqp "github.com/supragya/EtherScope/libs/amqp"
+	logger "github.com/supragya/EtherScope/libs/log"
 	"github.com/supragya/EtherScope/libs/service"
+	itypes "github.com/supragya/EtherScope/types"
 )
 
 type OutputSink interface {
@@ -9,3 +14,74 @@ type OutputSink interface {
 
 	Send(payload interface{}) error
 }
+
+// HeightKeyedPayload is implemented by payloads bound to a block
+// height. Sinks may use the height for partitioning and ordering
+type HeightKeyedPayload interface {
+	Height() uint64
+}
+
+// SplittablePayload is implemented by payloads which can be broken
+// down into payloads holding items of a single event type each
+type SplittablePayload interface {
+	// SplitByEventType returns payload stripped of all items along
+	// with per event type payloads keyed by event type
+	SplitByEventType() (interface{}, map[string]interface{})
+}
+
+// BlockPayload is implemented by payloads carrying indexed items of a
+// single block. Sinks writing to structured storage use it to get at
+// payload contents without knowing the concrete payload type
+type BlockPayload interface {
+	// Synopsis returns block synopsis, nil for rollback payloads
+	Synopsis() *itypes.BlockSynopsis
+	// BlockItems returns indexed items of the block
+	BlockItems() []interface{}
+	// Source returns moniker and network of originating node
+	Source() (string, string)
+}
+
+// FilterablePayload is implemented by payloads whose items can be
+// selectively dropped
+type FilterablePayload interface {
+	// FilterItems returns a copy of payload holding only items for
+	// which keep returns true
+	FilterItems(keep func(item interface{}) bool) interface{}
+}
+
+// NewOutputSinkWithViperFields returns an OutputSink of given type
+// configured using its own config section
+func NewOutputSinkWithViperFields(sinkType string, log logger.Logger) (OutputSink, error) {
+	if sinkType == "fanout" {
+		return NewFanoutOutputSinkWithViperFields(log)
+	}
+	section, ok := defaultSinkSections[sinkType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported outputsink: %s", sinkType)
+	}
+	return newOutputSinkInSection(sinkType, section, log)
+}
+
+// Config section each sink type is configured from by default
+var defaultSinkSections = map[string]string{
+	"rabbitmq": RabbitMQCFGSection,
+	"kafka":    KafkaCFGSection,
+	"postgres": PostgresCFGSection,
+	"file":     FileCFGSection,
+}
+
+// newOutputSinkInSection returns an OutputSink of given type configured
+// using given config section, allowing several sinks of a type
+func newOutputSinkInSection(sinkType string, section string, log logger.Logger) (OutputSink, error) {
+	switch sinkType {
+	case "rabbitmq":
+		return newRabbitMQOutputSinkInSection(log, section, &iamqp.AMQPImpl{})
+	case "kafka":
+		return newKafkaOutputSinkInSection(log, section)
+	case "postgres":
+		return newPostgresOutputSinkInSection(log, section, OpenPostgres)
+	case "file":
+		return newFileOutputSinkInSection(log, section)
+	}
+	return nil, fmt.Errorf("unsupported outputsink: %s", sinkType)
+}
@@ -0,0 +1,174 @@
+package outputsink_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ethereum/go-ethereum/common"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+
+	logger "github.com/supragya/EtherScope/libs/log"
+	outs "github.com/supragya/EtherScope/services/output_sink"
+	itypes "github.com/supragya/EtherScope/types"
+)
+
+// Payload stub exposing block contents
+type testBlockPayload struct {
+	synopsis *itypes.BlockSynopsis
+	items    []interface{}
+}
+
+func (p testBlockPayload) Synopsis() *itypes.BlockSynopsis {
+	return p.synopsis
+}
+
+func (p testBlockPayload) BlockItems() []interface{} {
+	return p.items
+}
+
+func (p testBlockPayload) Source() (string, string) {
+	return "testnode", "testnet"
+}
+
+var _ = Describe("Postgres", func() {
+	var db *sql.DB
+	var mock sqlmock.Sqlmock
+	var testOutputSinkPostgres outs.OutputSink
+	var txHash = common.HexToHash("0xabcd")
+	var testMessage = testBlockPayload{
+		synopsis: &itypes.BlockSynopsis{
+			Height:                 100,
+			BlockTime:              1670000000,
+			EventsUserDistribution: map[string]uint64{"uniswapv2swap": 1},
+		},
+		items: []interface{}{
+			&itypes.Swap{
+				Type:        "uniswapv2swap",
+				LogIdx:      3,
+				Transaction: txHash,
+				Height:      100,
+				Amount0:     big.NewFloat(1.5),
+				Amount1:     big.NewFloat(-3),
+				Price0:      &itypes.PriceResult{Price: big.NewFloat(2)},
+			},
+			&itypes.Transfer{
+				Type:        "erc20transfer",
+				LogIdx:      4,
+				Transaction: txHash,
+				Height:      100,
+				Amount:      big.NewFloat(10),
+			},
+			&itypes.GenericEvent{
+				Type:   "genericevent",
+				LogIdx: 5,
+			},
+		},
+	}
+
+	setupSink := func(openErr error) {
+		viper.GetViper().Set(outs.PostgresCFGSection+".connectionString", "postgresql://test")
+		viper.GetViper().Set(outs.PostgresCFGSection+".writeTimeout", "5s")
+
+		var err error
+		testOutputSinkPostgres, err = outs.NewPostgresOutputSinkWithViperFields(logger.NewNopLogger(),
+			func(string) (*sql.DB, error) {
+				return db, openErr
+			})
+		Expect(err).To(BeNil())
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, mock, err = sqlmock.New(sqlmock.MonitorPingsOption(true))
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		Expect(mock.ExpectationsWereMet()).To(BeNil())
+	})
+
+	Context("Start", func() {
+		It("should return err when database cannot be pinged", func() {
+			setupSink(nil)
+			mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+			mock.ExpectClose()
+			Expect(testOutputSinkPostgres.Start(context.Background())).To(MatchError(
+				ContainSubstring("OutputSinkDialError"),
+			))
+		})
+
+		It("should return nil error following successful connection", func() {
+			setupSink(nil)
+			mock.ExpectPing()
+			Expect(testOutputSinkPostgres.Start(context.Background())).To(BeNil())
+		})
+	})
+
+	Context("Send", func() {
+		BeforeEach(func() {
+			setupSink(nil)
+			mock.ExpectPing()
+			Expect(testOutputSinkPostgres.Start(context.Background())).To(BeNil())
+		})
+
+		It("should write a block in one transaction", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO blocks").
+				WithArgs(uint64(100), uint64(1670000000), "testnode", "testnet",
+					sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+					sqlmock.AnyArg(), sqlmock.AnyArg(), `{"uniswapv2swap":1}`).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("INSERT INTO pool_actions_geth").
+				WithArgs(txHash.Hex(), uint(3), "uniswapv2swap", uint64(100), sqlmock.AnyArg(),
+					sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+					sqlmock.AnyArg(), sqlmock.AnyArg(), "1.5", "-3", nil, nil, nil, "2", nil).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("INSERT INTO transfers_geth").
+				WithArgs(txHash.Hex(), uint(4), "erc20transfer", uint64(100), sqlmock.AnyArg(),
+					sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+					"10", nil).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			Expect(testOutputSinkPostgres.Send(testMessage)).To(BeNil())
+		})
+
+		It("should delete rows above common ancestor on rollback", func() {
+			mock.ExpectBegin()
+			for _, table := range []string{"blocks", "pool_actions_geth", "transfers_geth"} {
+				mock.ExpectExec("DELETE FROM " + table).
+					WithArgs(uint64(90)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			mock.ExpectCommit()
+
+			Expect(testOutputSinkPostgres.Send(testBlockPayload{
+				items: []interface{}{&itypes.Rollback{Type: "rollback", CommonAncestor: 90}},
+			})).To(BeNil())
+		})
+
+		It("should roll back transaction and return OutputSinkPublishError on failure", func() {
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO blocks").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("INSERT INTO pool_actions_geth").
+				WillReturnError(errors.New("constraint violated"))
+			mock.ExpectRollback()
+
+			Expect(testOutputSinkPostgres.Send(testMessage)).To(MatchError(
+				ContainSubstring("OutputSinkPublishError"),
+			))
+		})
+
+		It("should reject payloads not carrying a block", func() {
+			Expect(testOutputSinkPostgres.Send("payload")).To(MatchError(
+				ContainSubstring("OutputSinkUnsupportedPayload"),
+			))
+		})
+	})
+})
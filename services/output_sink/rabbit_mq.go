@@ -2,16 +2,17 @@ package outputsink
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 
 	iamqp "github.com/supragya/EtherScope/libs/amqp"
 	cfg "github.com/supragya/EtherScope/libs/config"
 	logger "github.com/supragya/EtherScope/libs/log"
 	"github.com/supragya/EtherScope/libs/service"
-	"github.com/supragya/EtherScope/version"
 	"github.com/spf13/viper"
 	"github.com/streadway/amqp"
 )
@@ -93,6 +94,39 @@ var (
 			Info:      cfg.SArr("queue no wait"),
 			Default:   false,
 		},
+		{
+			Name:      "encoding",
+			Type:      "string",
+			Necessity: "always needed",
+			Info: cfg.SArr("payload encoding. Use `json` or `protobuf`",
+				"(schema in libs/payloadpb)"),
+			Default: "json",
+		},
+		{
+			Name:      "maxInFlight",
+			Type:      "uint64",
+			Necessity: "always needed",
+			Info: cfg.SArr("maximum number of payloads published but not yet",
+				"confirmed by broker. send blocks once reached"),
+			Default: 64,
+		},
+		{
+			Name:      "confirmTimeout",
+			Type:      "time.Duration",
+			Necessity: "always needed",
+			Info: cfg.SArr("time to wait for broker confirms when in-flight",
+				"window is full before failing send"),
+			Default: "30s",
+		},
+		{
+			Name:      "spoolDirectory",
+			Type:      "string",
+			Necessity: "always needed",
+			Info: cfg.SArr("disk location for unconfirmed payloads, replayed",
+				"after reconnect and restart. empty keeps them",
+				"in memory only"),
+			Default: "rmq.spool",
+		},
 	}
 )
 
@@ -111,6 +145,10 @@ type RabbitMQOutputSinkImpl struct {
 	autoDelete       bool
 	exclusive        bool
 	noWait           bool
+	maxInFlight      int
+	encoder          payloadEncoder
+	contentType      string
+	confirmTimeout   time.Duration
 	disconnectTime   time.Time
 	connecting       bool
 	amqpImpl         iamqp.AMQP
@@ -118,10 +156,22 @@ type RabbitMQOutputSinkImpl struct {
 	// Connections
 	connection iamqp.AMQPConnection
 	channel    iamqp.AMQPChannel
+
+	// Publisher confirms, guarded by mu
+	mu         sync.Mutex
+	confirmed  *sync.Cond             // signalled on confirms and channel close
+	spool      *rmqSpool              // payloads awaiting confirm
+	inFlight   map[uint64]uint64      // delivery tag -> spool id
+	returned   map[uint64]amqp.Return // spool id -> return of unroutable publishing
+	returnErr  error                  // set on return, fails next send
+	nextTag    uint64                 // delivery tag of next publishing
+	generation uint64                 // bumped per channel, stale confirms are ignored
 }
 
 // OnStart starts the rabbitmq OutputSink. It implements service.Service.
 func (n *RabbitMQOutputSinkImpl) OnStart(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
 	if err := n.connect(); err != nil {
 		n.disconnectTime = time.Now()
 		n.log.Info(fmt.Sprintf("Unable to connect to RabbitMQ: %s", err))
@@ -132,8 +182,12 @@ func (n *RabbitMQOutputSinkImpl) OnStart(ctx context.Context) error {
 
 // OnStop stops the rabbitmq OutputSink. It implements service.Service
 func (n *RabbitMQOutputSinkImpl) OnStop() {
-	n.channel.Close()
-	n.connection.Close()
+	if n.channel != nil {
+		n.channel.Close()
+	}
+	if n.connection != nil {
+		n.connection.Close()
+	}
 }
 
 type WrappedPayload struct {
@@ -176,6 +230,13 @@ func (n *RabbitMQOutputSinkImpl) connect() error {
 		return fmt.Errorf("OutputSinkChannelError caused by: %w", err)
 	}
 
+	if err := channelRabbitMQ.Confirm(false); err != nil {
+		if n.disconnectTime.IsZero() {
+			n.disconnectTime = time.Now()
+		}
+		return fmt.Errorf("OutputSinkChannelError caused by: %w", err)
+	}
+
 	if n.disconnectTime.IsZero() {
 		n.log.Info("RabbitMQ connected")
 	} else {
@@ -186,36 +247,189 @@ func (n *RabbitMQOutputSinkImpl) connect() error {
 
 	n.connection = connectRabbitMQ
 	n.channel = channelRabbitMQ
-	return nil
+
+	// Confirms of previous channel will never arrive
+	n.generation++
+	n.inFlight = make(map[uint64]uint64)
+	n.returned = make(map[uint64]amqp.Return)
+	n.nextTag = 1
+	confirms := channelRabbitMQ.NotifyPublish(make(chan amqp.Confirmation, n.maxInFlight))
+	returns := channelRabbitMQ.NotifyReturn(make(chan amqp.Return, n.maxInFlight))
+	go n.handleConfirms(confirms, returns, n.generation)
+
+	return n.replay()
 }
 
-func (n *RabbitMQOutputSinkImpl) Send(payload interface{}) error {
-	if n.connection == nil || n.connection.IsClosed() {
-		if err := n.connect(); err != nil {
-			return fmt.Errorf("OutputSinkUnavailable Caused By: %w", err)
+// replay republishes all unconfirmed payloads in order of spooling
+func (n *RabbitMQOutputSinkImpl) replay() error {
+	ids := n.spool.ids()
+	if len(ids) == 0 {
+		return nil
+	}
+	n.log.Info("replaying unconfirmed payloads onto RabbitMQ", "count", len(ids))
+	for _, id := range ids {
+		if err := n.publish(id); err != nil {
+			// Force reconnect and full replay on next send
+			n.connection = nil
+			return fmt.Errorf("OutputSinkReplayError caused by: %w", err)
 		}
 	}
+	return nil
+}
 
-	item, err := json.MarshalIndent(WrappedPayload{version.PersistenceVersion, payload}, "", " ")
-	if err != nil {
-		return err
+// publish publishes spooled payload as persistent message, tracking
+// it until broker confirms it. Publishing is mandatory, payloads the
+// broker cannot route to queue are returned instead of dropped
+func (n *RabbitMQOutputSinkImpl) publish(id uint64) error {
+	body, ok := n.spool.get(id)
+	if !ok {
+		return nil
 	}
-
-	err = n.channel.Publish(
+	err := n.channel.Publish(
 		"",          // exchange
 		n.queueName, // queue name
 		true,        // mandatory
 		false,       // immediate
 		amqp.Publishing{
-			ContentType:     "application/json",
-			ContentEncoding: "application/json",
+			MessageId:       strconv.FormatUint(id, 10),
+			ContentType:     n.contentType,
+			ContentEncoding: n.contentType,
+			DeliveryMode:    amqp.Persistent,
 			Timestamp:       time.Now(),
-			Body:            item,
+			Body:            body,
 		}, // message to publish
 	)
+	if err != nil {
+		return err
+	}
+	n.inFlight[n.nextTag] = id
+	n.nextTag++
+	return nil
+}
 
+// handleConfirms drops acked payloads from spool and republishes
+// nacked ones until confirms channel is closed along with channel.
+// Returned payloads stay spooled and fail next send, so that they are
+// replayed on reconnect
+func (n *RabbitMQOutputSinkImpl) handleConfirms(confirms chan amqp.Confirmation,
+	returns chan amqp.Return,
+	generation uint64) {
+	for confirm := range confirms {
+		n.mu.Lock()
+		if generation != n.generation {
+			n.mu.Unlock()
+			continue
+		}
+		n.collectReturns(returns)
+		id, ok := n.inFlight[confirm.DeliveryTag]
+		delete(n.inFlight, confirm.DeliveryTag)
+		ret, returned := n.returned[id]
+		delete(n.returned, id)
+		if ok && returned {
+			n.log.Warn("RabbitMQ returned unroutable payload",
+				"spoolID", id,
+				"code", ret.ReplyCode,
+				"reason", ret.ReplyText)
+			n.returnErr = fmt.Errorf("payload returned by broker: %d %s", ret.ReplyCode, ret.ReplyText)
+		} else if ok && confirm.Ack {
+			if err := n.spool.remove(id); err != nil {
+				n.log.Warn("Error removing confirmed payload from spool: " + fmt.Sprint(err))
+			}
+		} else if ok {
+			n.log.Warn("RabbitMQ nacked payload, republishing", "spoolID", id)
+			if err := n.publish(id); err != nil {
+				n.log.Warn("Error republishing nacked payload, will replay on reconnect: " + fmt.Sprint(err))
+			}
+		}
+		n.confirmed.Broadcast()
+		n.mu.Unlock()
+	}
+
+	n.mu.Lock()
+	n.confirmed.Broadcast()
+	n.mu.Unlock()
+}
+
+// collectReturns records returns received so far. Broker sends return
+// of a publishing before its ack, so it is collected by the time ack
+// is handled. Needs mu held
+func (n *RabbitMQOutputSinkImpl) collectReturns(returns chan amqp.Return) {
+	for {
+		select {
+		case ret, ok := <-returns:
+			if !ok {
+				return
+			}
+			if id, err := strconv.ParseUint(ret.MessageId, 10, 64); err == nil {
+				n.returned[id] = ret
+			}
+		default:
+			return
+		}
+	}
+}
+
+// waitForWindow blocks until in-flight window has room. Needs mu held
+func (n *RabbitMQOutputSinkImpl) waitForWindow() error {
+	if len(n.inFlight) < n.maxInFlight {
+		return nil
+	}
+	deadline := time.Now().Add(n.confirmTimeout)
+	timer := time.AfterFunc(n.confirmTimeout, func() {
+		n.mu.Lock()
+		n.confirmed.Broadcast()
+		n.mu.Unlock()
+	})
+	defer timer.Stop()
+
+	for len(n.inFlight) >= n.maxInFlight {
+		if !time.Now().Before(deadline) {
+			return errors.New("timed out waiting for publisher confirms")
+		}
+		n.confirmed.Wait()
+	}
+	return nil
+}
+
+// Send spools payload and publishes it. Payload stays spooled until
+// broker confirms it and is replayed after reconnect otherwise
+func (n *RabbitMQOutputSinkImpl) Send(payload interface{}) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.connection == nil || n.connection.IsClosed() {
+		if err := n.connect(); err != nil {
+			return fmt.Errorf("OutputSinkUnavailable Caused By: %w", err)
+		}
+	}
+
+	item, _, err := n.encoder.encode(payload)
+	if err != nil {
+		return err
+	}
+
+	if err := n.waitForWindow(); err != nil {
+		n.log.Warn("Error publishing message to RabbitMQ: " + fmt.Sprint(err))
+		return fmt.Errorf("OutputSinkPublishError Caused by: %w", err)
+	}
+
+	if n.returnErr != nil {
+		err := n.returnErr
+		n.returnErr = nil
+		// Reconnect replays returned payloads ahead of new ones
+		n.connection.Close()
+		n.connection = nil
+		return fmt.Errorf("OutputSinkPublishError Caused by: %w", err)
+	}
+
+	id, err := n.spool.add(item)
 	if err != nil {
-		n.log.Warn("Error publishing message to RabbitMQ: " + fmt.Sprint(err) + ", caching message")
+		return fmt.Errorf("OutputSinkSpoolError Caused by: %w", err)
+	}
+
+	if err := n.publish(id); err != nil {
+		n.spool.remove(id)
+		n.log.Warn("Error publishing message to RabbitMQ: " + fmt.Sprint(err))
 		if n.disconnectTime.IsZero() {
 			n.disconnectTime = time.Now()
 		}
@@ -224,25 +438,56 @@ func (n *RabbitMQOutputSinkImpl) Send(payload interface{}) error {
 
 	n.log.Debug("sent message onto outputsink rmq",
 		"msglen", len(item),
-		"queue", n.queueName)
+		"queue", n.queueName,
+		"inflight", len(n.inFlight))
 
 	return nil
 }
 
 func NewRabbitMQOutputSinkWithViperFields(log logger.Logger, amqpImpl iamqp.AMQP) (OutputSink, error) {
+	return newRabbitMQOutputSinkInSection(log, RabbitMQCFGSection, amqpImpl)
+}
+
+// newRabbitMQOutputSinkInSection returns a rabbitmq OutputSink configured
+// from given config section
+func newRabbitMQOutputSinkInSection(log logger.Logger, section string, amqpImpl iamqp.AMQP) (OutputSink, error) {
 	outs := &RabbitMQOutputSinkImpl{
 		log:              log,
-		queueName:        viper.GetString(RabbitMQCFGSection + ".queue"),
-		secureConnection: viper.GetBool(RabbitMQCFGSection + ".secureConnection"),
-		host:             viper.GetString(RabbitMQCFGSection + ".host"),
-		port:             viper.GetUint64(RabbitMQCFGSection + ".port"),
-		user:             viper.GetString(RabbitMQCFGSection + ".user"),
-		pass:             viper.GetString(RabbitMQCFGSection + ".pass"),
-		durable:          viper.GetBool(RabbitMQCFGSection + ".queueIsDurable"),  // durable
-		autoDelete:       viper.GetBool(RabbitMQCFGSection + ".queueAutoDelete"), // auto delete
-		exclusive:        viper.GetBool(RabbitMQCFGSection + ".queueExclusive"),  // exclusive
-		noWait:           viper.GetBool(RabbitMQCFGSection + ".queueNoWait"),     // no wait
+		queueName:        viper.GetString(section + ".queue"),
+		secureConnection: viper.GetBool(section + ".secureConnection"),
+		host:             viper.GetString(section + ".host"),
+		port:             viper.GetUint64(section + ".port"),
+		user:             viper.GetString(section + ".user"),
+		pass:             viper.GetString(section + ".pass"),
+		durable:          viper.GetBool(section + ".queueIsDurable"),  // durable
+		autoDelete:       viper.GetBool(section + ".queueAutoDelete"), // auto delete
+		exclusive:        viper.GetBool(section + ".queueExclusive"),  // exclusive
+		noWait:           viper.GetBool(section + ".queueNoWait"),     // no wait
+		maxInFlight:      viper.GetInt(section + ".maxInFlight"),
+		confirmTimeout:   viper.GetDuration(section + ".confirmTimeout"),
+		inFlight:         make(map[uint64]uint64),
+	}
+	if outs.maxInFlight <= 0 {
+		outs.maxInFlight = 64
+	}
+	if outs.confirmTimeout <= 0 {
+		outs.confirmTimeout = 30 * time.Second
+	}
+	encoder, err := newPayloadEncoder(viper.GetString(section+".encoding"), true)
+	if err != nil {
+		return nil, err
+	}
+	outs.encoder = encoder
+	outs.contentType = "application/json"
+	if encoder.encoding == EncodingProtobuf {
+		outs.contentType = "application/x-protobuf"
+	}
+	spool, err := newRMQSpool(viper.GetString(section+".spoolDirectory"), encoder.encoding)
+	if err != nil {
+		return nil, err
 	}
+	outs.spool = spool
+	outs.confirmed = sync.NewCond(&outs.mu)
 	outs.BaseService = *service.NewBaseService(log, "outputsink", outs)
 	outs.amqpImpl = amqpImpl
 	return outs, nil
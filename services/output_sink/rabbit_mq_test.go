@@ -3,6 +3,8 @@ package outputsink_test
 import (
 	"context"
 	"errors"
+	"os"
+	"sync/atomic"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -11,7 +13,9 @@ import (
 
 	iamqp "github.com/supragya/EtherScope/libs/amqp"
 	logger "github.com/supragya/EtherScope/libs/log"
+	"github.com/supragya/EtherScope/libs/payloadpb"
 	outs "github.com/supragya/EtherScope/services/output_sink"
+	"github.com/supragya/EtherScope/version"
 )
 
 /* Struct definitions with stubbing functions */
@@ -27,8 +31,11 @@ type (
 	}
 
 	TestAMQPChannelImpl struct {
-		PublishImpl func(string, string, bool, bool, amqp.Publishing) error
-		CloseImpl   func() error
+		PublishImpl       func(string, string, bool, bool, amqp.Publishing) error
+		CloseImpl         func() error
+		ConfirmImpl       func(bool) error
+		NotifyPublishImpl func(chan amqp.Confirmation) chan amqp.Confirmation
+		NotifyReturnImpl  func(chan amqp.Return) chan amqp.Return
 	}
 )
 
@@ -70,12 +77,40 @@ func (channel TestAMQPChannelImpl) Publish(exchange string, key string, mandator
 }
 
 func (channel TestAMQPChannelImpl) Close() error {
-	if channel.PublishImpl != nil {
-		return channel.Close()
+	if channel.CloseImpl != nil {
+		return channel.CloseImpl()
+	}
+	return nil
+}
+
+func (channel TestAMQPChannelImpl) Confirm(noWait bool) error {
+	if channel.ConfirmImpl != nil {
+		return channel.ConfirmImpl(noWait)
 	}
 	return nil
 }
 
+func (channel TestAMQPChannelImpl) NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation {
+	if channel.NotifyPublishImpl != nil {
+		return channel.NotifyPublishImpl(confirm)
+	}
+	return confirm
+}
+
+func (channel TestAMQPChannelImpl) NotifyReturn(c chan amqp.Return) chan amqp.Return {
+	if channel.NotifyReturnImpl != nil {
+		return channel.NotifyReturnImpl(c)
+	}
+	return c
+}
+
+// Payload stub with protobuf representation
+type testProtoPayload struct{}
+
+func (testProtoPayload) ToProto() *payloadpb.Payload {
+	return &payloadpb.Payload{Network: "testnet"}
+}
+
 /* Begin Tests */
 var _ = Describe("RabbitMq", func() {
 	var testLogger logger.Logger
@@ -98,6 +133,10 @@ var _ = Describe("RabbitMq", func() {
 		viper.GetViper().Set(outs.RabbitMQCFGSection+".queueAutoDelete", true)
 		viper.GetViper().Set(outs.RabbitMQCFGSection+".queueExclusive", false)
 		viper.GetViper().Set(outs.RabbitMQCFGSection+".queueNoWait", false)
+		viper.GetViper().Set(outs.RabbitMQCFGSection+".maxInFlight", 64)
+		viper.GetViper().Set(outs.RabbitMQCFGSection+".confirmTimeout", "1s")
+		viper.GetViper().Set(outs.RabbitMQCFGSection+".spoolDirectory", "")
+		viper.GetViper().Set(outs.RabbitMQCFGSection+".encoding", "json")
 
 		// Create test implementations
 		testAMQP = TestAMQPImpl{
@@ -133,6 +172,13 @@ var _ = Describe("RabbitMq", func() {
 			))
 		})
 
+		It("should return err when channel cannot be put in confirm mode", func() {
+			testRabbitMQChannel.ConfirmImpl = func(bool) error { return errors.New("Test Confirm error") }
+			Expect(testOutputSinkRMQ.Start(context.Background())).To(MatchError(
+				ContainSubstring("OutputSinkChannelError"),
+			))
+		})
+
 		It("should return nil error following successful connection", func() {
 			Expect(testOutputSinkRMQ.Start(context.Background())).To(BeNil())
 		})
@@ -169,6 +215,155 @@ var _ = Describe("RabbitMq", func() {
 		It("should return nil when connected and Publish returns normally", func() {
 			Expect(testOutputSinkRMQ.Send(testMessage)).To(BeNil())
 		})
+
+		It("should publish persistent messages", func() {
+			var published []amqp.Publishing
+			testRabbitMQChannel.PublishImpl = func(s1, s2 string, b1, b2 bool, p amqp.Publishing) error {
+				published = append(published, p)
+				return nil
+			}
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(BeNil())
+			Expect(published).To(HaveLen(1))
+			Expect(published[0].DeliveryMode).To(Equal(amqp.Persistent))
+		})
+	})
+
+	Context("Encoding", func() {
+		It("should publish protobuf payloads tagged with persistence version", func() {
+			viper.GetViper().Set(outs.RabbitMQCFGSection+".encoding", "protobuf")
+			testOutputSinkRMQ, _ = outs.NewRabbitMQOutputSinkWithViperFields(testLogger, &testAMQP)
+
+			var published []amqp.Publishing
+			testRabbitMQChannel.PublishImpl = func(s1, s2 string, b1, b2 bool, p amqp.Publishing) error {
+				published = append(published, p)
+				return nil
+			}
+			Expect(testOutputSinkRMQ.Send(testProtoPayload{})).To(BeNil())
+			Expect(published[0].ContentType).To(Equal("application/x-protobuf"))
+
+			decoded, err := payloadpb.Decode(published[0].Body)
+			Expect(err).To(BeNil())
+			Expect(decoded.Network).To(Equal("testnet"))
+			Expect(decoded.PersistenceVersion).To(Equal(uint32(version.PersistenceVersion)))
+		})
+	})
+
+	Context("Publisher confirms", func() {
+		var confirms chan amqp.Confirmation
+		var returns chan amqp.Return
+		var published int32
+		publishedCount := func() int32 { return atomic.LoadInt32(&published) }
+
+		BeforeEach(func() {
+			viper.GetViper().Set(outs.RabbitMQCFGSection+".maxInFlight", 1)
+			viper.GetViper().Set(outs.RabbitMQCFGSection+".confirmTimeout", "100ms")
+			testOutputSinkRMQ, _ = outs.NewRabbitMQOutputSinkWithViperFields(testLogger, &testAMQP)
+
+			atomic.StoreInt32(&published, 0)
+			testRabbitMQChannel.NotifyPublishImpl = func(c chan amqp.Confirmation) chan amqp.Confirmation {
+				confirms = c
+				return c
+			}
+			testRabbitMQChannel.NotifyReturnImpl = func(c chan amqp.Return) chan amqp.Return {
+				returns = c
+				return c
+			}
+			testRabbitMQChannel.PublishImpl = func(s1, s2 string, b1, b2 bool, p amqp.Publishing) error {
+				atomic.AddInt32(&published, 1)
+				return nil
+			}
+		})
+
+		It("should fail send when in-flight window stays full", func() {
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(BeNil())
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(MatchError(
+				ContainSubstring("OutputSinkPublishError"),
+			))
+			Expect(publishedCount()).To(Equal(int32(1)))
+		})
+
+		It("should free in-flight window on ack", func() {
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(BeNil())
+			confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(BeNil())
+			Expect(publishedCount()).To(Equal(int32(2)))
+		})
+
+		It("should republish on nack", func() {
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(BeNil())
+			confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: false}
+			Eventually(publishedCount).Should(Equal(int32(2)))
+		})
+
+		It("should replay unconfirmed payloads after reconnect", func() {
+			closed := false
+			testConnection.IsClosedImpl = func() bool { return closed }
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(BeNil())
+
+			closed = true
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(MatchError(
+				ContainSubstring("OutputSinkPublishError"),
+			))
+			// Unconfirmed payload replayed onto new channel
+			Expect(publishedCount()).To(Equal(int32(2)))
+		})
+
+		It("should keep returned payloads spooled and fail next send", func() {
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(BeNil())
+			returns <- amqp.Return{MessageId: "1", ReplyCode: 312, ReplyText: "NO_ROUTE"}
+			confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(MatchError(
+				ContainSubstring("NO_ROUTE"),
+			))
+			Expect(publishedCount()).To(Equal(int32(1)))
+
+			// Returned payload replayed onto new channel
+			testOutputSinkRMQ.Send(testMessage)
+			Expect(publishedCount()).To(Equal(int32(2)))
+		})
+
+		It("should name spool files by encoding", func() {
+			directory, err := os.MkdirTemp("", "escope_rmq_spool")
+			Expect(err).To(BeNil())
+			DeferCleanup(os.RemoveAll, directory)
+			viper.GetViper().Set(outs.RabbitMQCFGSection+".spoolDirectory", directory)
+			viper.GetViper().Set(outs.RabbitMQCFGSection+".encoding", "protobuf")
+
+			testOutputSinkRMQ, _ = outs.NewRabbitMQOutputSinkWithViperFields(testLogger, &testAMQP)
+			Expect(testOutputSinkRMQ.Send(testProtoPayload{})).To(BeNil())
+			files, _ := os.ReadDir(directory)
+			Expect(files).To(HaveLen(1))
+			Expect(files[0].Name()).To(HaveSuffix(".pb"))
+
+			// Payloads of previous encoding are not replayed as json
+			viper.GetViper().Set(outs.RabbitMQCFGSection+".encoding", "json")
+			_, err = outs.NewRabbitMQOutputSinkWithViperFields(testLogger, &testAMQP)
+			Expect(err).To(MatchError(ContainSubstring("protobuf encoded")))
+		})
+
+		It("should replay payloads spooled on disk after restart", func() {
+			directory, err := os.MkdirTemp("", "escope_rmq_spool")
+			Expect(err).To(BeNil())
+			DeferCleanup(os.RemoveAll, directory)
+			viper.GetViper().Set(outs.RabbitMQCFGSection+".spoolDirectory", directory)
+
+			testOutputSinkRMQ, _ = outs.NewRabbitMQOutputSinkWithViperFields(testLogger, &testAMQP)
+			Expect(testOutputSinkRMQ.Send(testMessage)).To(BeNil())
+			Expect(publishedCount()).To(Equal(int32(1)))
+
+			restarted, err := outs.NewRabbitMQOutputSinkWithViperFields(testLogger, &testAMQP)
+			Expect(err).To(BeNil())
+			Expect(restarted.Start(context.Background())).To(BeNil())
+			Expect(publishedCount()).To(Equal(int32(2)))
+
+			// Acked payloads leave the spool
+			confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+			Eventually(func() int {
+				files, _ := os.ReadDir(directory)
+				return len(files)
+			}).Should(Equal(0))
+		})
 	})
 
 })
@@ -0,0 +1,133 @@
+package outputsink
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Suffix of spool files per payload encoding
+var rmqSpoolSuffixes = map[string]string{
+	EncodingJSON:     ".json",
+	EncodingProtobuf: ".pb",
+}
+
+// rmqSpool keeps payloads published to rabbitmq but not yet confirmed
+// by the broker, to be replayed after reconnect. With a directory set
+// entries are persisted on disk and survive restarts
+type rmqSpool struct {
+	directory string
+	suffix    string
+	nextID    uint64
+	entries   map[uint64][]byte
+}
+
+// newRMQSpool returns spool of payloads of given encoding. Spooled
+// payloads of another encoding cannot be replayed, they are to be
+// drained using previous encoding first
+func newRMQSpool(directory string, encoding string) (*rmqSpool, error) {
+	spool := &rmqSpool{
+		directory: directory,
+		suffix:    rmqSpoolSuffixes[encoding],
+		nextID:    1,
+		entries:   make(map[uint64][]byte),
+	}
+	if directory == "" {
+		return spool, nil
+	}
+
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, err
+	}
+	files, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() {
+			continue
+		}
+		if !strings.HasSuffix(name, spool.suffix) {
+			for other, suffix := range rmqSpoolSuffixes {
+				if strings.HasSuffix(name, suffix) {
+					return nil, fmt.Errorf("spool %s holds %s encoded payload %s", directory, other, name)
+				}
+			}
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, spool.suffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		body, err := os.ReadFile(filepath.Join(directory, name))
+		if err != nil {
+			return nil, err
+		}
+		spool.entries[id] = body
+		if id >= spool.nextID {
+			spool.nextID = id + 1
+		}
+	}
+	return spool, nil
+}
+
+func (s *rmqSpool) fileName(id uint64) string {
+	return filepath.Join(s.directory, fmt.Sprintf("%020d%s", id, s.suffix))
+}
+
+// add spools body, returning its spool id
+func (s *rmqSpool) add(body []byte) (uint64, error) {
+	id := s.nextID
+	if s.directory != "" {
+		file, err := os.OpenFile(s.fileName(id), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return 0, err
+		}
+		_, err = file.Write(body)
+		if err == nil {
+			err = file.Sync()
+		}
+		file.Close()
+		if err != nil {
+			os.Remove(s.fileName(id))
+			return 0, err
+		}
+	}
+	s.nextID++
+	s.entries[id] = body
+	return id, nil
+}
+
+func (s *rmqSpool) get(id uint64) ([]byte, bool) {
+	body, ok := s.entries[id]
+	return body, ok
+}
+
+func (s *rmqSpool) remove(id uint64) error {
+	if _, ok := s.entries[id]; !ok {
+		return nil
+	}
+	delete(s.entries, id)
+	if s.directory != "" {
+		return os.Remove(s.fileName(id))
+	}
+	return nil
+}
+
+// ids returns spool ids in order of spooling
+func (s *rmqSpool) ids() []uint64 {
+	ids := make([]uint64, 0, len(s.entries))
+	for id := range s.entries {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (s *rmqSpool) len() int {
+	return len(s.entries)
+}
@@ -13,6 +13,33 @@ type WrappedCLMetadata struct {
 	Oracle      common.Address
 	From        common.Address
 	To          common.Address
+	Heartbeat   uint64 // max age of round in seconds, 0 for default
+}
+
+// CEXMetadata describes a CEX price edge of Token to USD. Price is
+// as of block with timestamp Time
+type CEXMetadata struct {
+	Description string
+	Token       common.Address
+	Symbol      string
+	Price       *big.Float
+	Time        uint64
+}
+
+// RejectedCEXMetadata notes a CEX price not used while pricing, along
+// with reason it was rejected for
+type RejectedCEXMetadata struct {
+	Description string
+	Symbol      string
+	Reason      string
+}
+
+// RejectedOracleMetadata notes a chainlink feed not trusted while
+// pricing, along with reason it was rejected for
+type RejectedOracleMetadata struct {
+	Description string
+	Oracle      common.Address
+	Reason      string
 }
 
 type ChainlinkLatestRoundData struct {
@@ -38,9 +65,15 @@ type CounterpartyPriceDerivationInfo struct {
 	SelfQty           *big.Float
 }
 
+// PriceResult is USD price of a token along with path of route chosen.
+// LiquidityUSD is liquidity of thinnest dex hop on route, nil if route
+// is through oracles alone. Confidence in [0, 1] is share of liquidity
+// of candidate routes agreeing with price
 type PriceResult struct {
-	Price *big.Float
-	Path  []interface{}
+	Price        *big.Float
+	Path         []interface{}
+	LiquidityUSD *big.Float
+	Confidence   float64
 }
 
 type UniV2Metadata struct {
@@ -52,6 +85,20 @@ type UniV2Metadata struct {
 	Res1        *big.Float
 }
 
+// UniV3Metadata describes a uniswap v3 pool edge. Res0 and Res1 are
+// virtual reserves backed by in-range liquidity at SqrtPriceX96, so
+// Res1 / Res0 is the spot price of Token0 in Token1
+type UniV3Metadata struct {
+	Description  string
+	Pool         common.Address
+	Token0       common.Address
+	Token1       common.Address
+	SqrtPriceX96 *big.Int
+	Liquidity    *big.Int
+	Res0         *big.Float
+	Res1         *big.Float
+}
+
 type CounterPartyResolutionMetadata struct {
 	Description string
 	Price       *big.Float
@@ -59,6 +106,8 @@ type CounterPartyResolutionMetadata struct {
 
 func init() {
 	gob.Register(UniV2Metadata{})
+	gob.Register(UniV3Metadata{})
+	gob.Register(CEXMetadata{})
 	gob.Register(ChainlinkLatestRoundData{})
 	gob.Register(WrappedCLMetadata{})
 }
@@ -116,6 +116,51 @@ type Swap struct {
 	ExtraData      interface{}
 }
 
+// UniV3SwapExtraData is pool state post swap as reported by a
+// uniswap v3 Swap log, carried in Swap.ExtraData
+type UniV3SwapExtraData struct {
+	SqrtPriceX96 *big.Int
+	Liquidity    *big.Int
+	Tick         *big.Int
+	Decimals0    uint8
+	Decimals1    uint8
+}
+
+// GenericEvent is an event of a user configured contract, decoded
+// using the contract's ABI. Fields are in order of ABI inputs
+type GenericEvent struct {
+	Type           string
+	ProcessingType ProcessingType `json:"-"`
+	LogIdx         uint
+	Transaction    common.Hash
+	Time           uint64
+	Height         uint64
+	Contract       common.Address
+	Event          string
+	Signature      string
+	Fields         []GenericField
+}
+
+// GenericField is a single decoded input of a GenericEvent. Type is
+// the solidity type as in ABI. Indexed dynamic types (string, bytes,
+// arrays) only carry the keccak256 hash of the value
+type GenericField struct {
+	Name    string
+	Type    string
+	Indexed bool
+	Value   interface{}
+}
+
+// Rollback is emitted when the node detects a chain reorganisation.
+// All items previously sent for heights in (CommonAncestor, OrphanedHeight]
+// are to be considered invalid and will be re-sent by the node.
+type Rollback struct {
+	Type           string
+	CommonAncestor uint64
+	OrphanedHeight uint64
+	OrphanedHashes []common.Hash
+}
+
 type BlockSynopsis struct {
 	Height                  uint64
 	BlockTime               uint64
@@ -128,6 +173,44 @@ type BlockSynopsis struct {
 	EventsIndexed           []string
 }
 
+// ItemType returns Type of an indexed item, "unknown" for
+// anything else
+func ItemType(item interface{}) string {
+	switch i := item.(type) {
+	case *Mint:
+		return i.Type
+	case *Burn:
+		return i.Type
+	case *Swap:
+		return i.Type
+	case *Transfer:
+		return i.Type
+	case *GenericEvent:
+		return i.Type
+	case *Rollback:
+		return i.Type
+	}
+	return "unknown"
+}
+
+// ItemProcessingType returns ProcessingType of an indexed item. ok is
+// false for items not bound to a processing type, e.g. rollbacks
+func ItemProcessingType(item interface{}) (ptype ProcessingType, ok bool) {
+	switch i := item.(type) {
+	case *Mint:
+		return i.ProcessingType, true
+	case *Burn:
+		return i.ProcessingType, true
+	case *Swap:
+		return i.ProcessingType, true
+	case *Transfer:
+		return i.ProcessingType, true
+	case *GenericEvent:
+		return i.ProcessingType, true
+	}
+	return 0, false
+}
+
 func toHash(str string) common.Hash {
 	return *(*common.Hash)(crypto.Keccak256([]byte(str)))
 }
@@ -164,6 +247,12 @@ func setTopic(topicString, infoString string) common.Hash {
 	return topicHash
 }
 
+// RegisterTopic makes event signature topicString available under
+// name infoString, for use by processors outside of this package
+func RegisterTopic(topicString, infoString string) common.Hash {
+	return setTopic(topicString, infoString)
+}
+
 func GetTopicForString(topicString string) (common.Hash, bool) {
 	val, ok := topicMap[topicString]
 	return val, ok
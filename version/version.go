@@ -27,7 +27,9 @@ var gover string = "unknownver"
 // Persistence version -- database compatibility index.
 // NOT TO be supplied compile time. Should be hardcoded.
 // PersistenceVersion 6 added ExtraData in itypes.Swap to accomodate arbitrary data between the DEXes
-var PersistenceVersion uint8 = 6
+// PersistenceVersion 7 added itypes.Rollback payloads emitted on chain reorganisations
+// PersistenceVersion 8 added itypes.GenericEvent for ABI decoded events of user configured contracts
+var PersistenceVersion uint8 = 8
 
 var RootCmdVersion string = prepareVersionString()
 